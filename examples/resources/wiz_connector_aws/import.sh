@@ -5,7 +5,7 @@
 # - Make sure that the `auth_params` field is set to the same values as set when the resource was created outside of Terraform.
 #   This is due to the way we need to handle change as under normal diff conditions, `auth_params` requires a resource recreation.
 #
-# - For `auth_params` include `customerRoleArn`. If using outposts, also include `outPostId` and `diskAnalyzer` structure.
+# - For `auth_params` include `customer_role_arn`. If using outposts, also include `outpost_id` and the `disk_analyzer` block.
 #
 # For more information, refer to the examples in the documentation.
 #
@@ -1,48 +1,40 @@
 # Provision a simple AWS connector, opting for a single region
 resource "wiz_connector_aws" "example" {
   name = "example"
-  auth_params = jsonencode({
-    "customerRoleARN" : "arn:aws:iam::100000000009:role/wiz-customer",
-  })
+  auth_params {
+    customer_role_arn = "arn:aws:iam::100000000009:role/wiz-customer"
+  }
 
-  extra_config = jsonencode(
-    {
-      "skipOrganizationScan" : true,
-      "optedInRegions" : ["us-east-1"],
-      "excludedAccounts" : [],
-      "excludedOUs" : [],
-      "auditLogMonitorEnabled" : false
-    }
-  )
+  extra_config {
+    skip_organization_scan    = true
+    opted_in_regions          = ["us-east-1"]
+    excluded_accounts         = []
+    excluded_ous              = []
+    audit_log_monitor_enabled = false
+  }
 }
 
 # Provision an AWS connector with Outpost that uses a custom config
 resource "wiz_connector_aws" "example" {
   name = "example"
-  auth_params = jsonencode({
-    "customerRoleARN" : "arn:aws:iam::100000000009:role/wiz-customer",
-    "outpostId" : "078862d0-a62f-406c-b966-13445af34c0d",
-    "diskAnalyzer" : {
-      "scanner" : {
-        "roleARN" : "arn:aws:iam::100000000009:role/outpost-scanner"
-      }
-    },
-  })
-
-  extra_config = jsonencode(
-    {
-      "auditLogMonitorEnabled" : false,
-      "excludedAccounts" : ["100000000009", "100000000010", "100000000013"],
-      "excludedOUs" : ["EXCLUDE-ME"],
-      "auditLogMonitorEnabled" : false,
-      "skipOrganizationScan" : true,
-      "optedInRegions" : [],
-      "cloudTrailConfig" : {
-        "bucketName" : "buckethere",
-        "bucketSubAccount" : "000000000012",
-        "trailOrg" : "o-myorg"
-      }
+  auth_params {
+    customer_role_arn = "arn:aws:iam::100000000009:role/wiz-customer"
+    outpost_id        = "078862d0-a62f-406c-b966-13445af34c0d"
+    disk_analyzer {
+      scanner_role_arn = "arn:aws:iam::100000000009:role/outpost-scanner"
     }
-  )
+  }
 
+  extra_config {
+    excluded_accounts         = ["100000000009", "100000000010", "100000000013"]
+    excluded_ous              = ["EXCLUDE-ME"]
+    audit_log_monitor_enabled = false
+    skip_organization_scan    = true
+    opted_in_regions          = []
+    cloud_trail {
+      bucket_name        = "buckethere"
+      bucket_sub_account = "000000000012"
+      trail_org          = "o-myorg"
+    }
+  }
 }
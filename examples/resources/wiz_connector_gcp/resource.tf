@@ -1,37 +1,33 @@
 # Provision a simple GCP connector, organization-wide
 resource "wiz_connector_gcp" "example" {
   name = "example"
-  auth_params = jsonencode({
-    "isManagedIdentity" : true,
-    "organization_id" : "o-example"
-  })
+  auth_params {
+    is_managed_identity = true
+    organization_id     = "o-example"
+  }
 
-  extra_config = jsonencode(
-    {
-      "projects" : [],
-      "excludedProjects" : [],
-      "includedFolders" : [],
-      "excludedFolders" : [],
-      "auditLogMonitorEnabled" : false
-    }
-  )
+  extra_config {
+    projects                  = []
+    excluded_projects         = []
+    included_folders          = []
+    excluded_folders          = []
+    audit_log_monitor_enabled = false
+  }
 }
 
 # Provision a GCP connector targeting an individual Google project
 resource "wiz_connector_gcp" "example" {
   name = "example"
-  auth_params = jsonencode({
-    "isManagedIdentity" : true,
-    "project_id" : "exmaple-project-id"
-  })
+  auth_params {
+    is_managed_identity = true
+    project_id          = "exmaple-project-id"
+  }
 
-  extra_config = jsonencode(
-    {
-      "projects" : [],
-      "excludedProjects" : [],
-      "includedFolders" : [],
-      "excludedFolders" : [],
-      "auditLogMonitorEnabled" : false
-    }
-  )
+  extra_config {
+    projects                  = []
+    excluded_projects         = []
+    included_folders          = []
+    excluded_folders          = []
+    audit_log_monitor_enabled = false
+  }
 }
@@ -0,0 +1,3 @@
+# details is not stored by Wiz, imported associations have the default details of 'undefined'
+# The id for importing a wiz_control_associations has to be in this format: 'association|<control_id>,<control_id>|<security_sub_category_id>,<security_sub_category_id>'
+terraform import wiz_control_associations.example_import "association|301e5fd0-6a1a-42a7-99f5-3b0436d55a7f,a5fbd955-ed78-445a-827a-06d6cbe5aab2|2e5bc0d5-835b-4b4c-99cf-b1c6ace90a52,708ec4a1-1a5c-4cb3-9c52-511229c5bb35"
@@ -13,8 +13,8 @@ import (
 // to validate pagination functionality
 func TestAccDatasourceWizCloudAccounts_basic(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcCommon)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcCommon)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDatasourceWizCloudAccountsBasic(1),
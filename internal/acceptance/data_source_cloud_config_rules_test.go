@@ -11,8 +11,8 @@ import (
 // wiz_cloud_config_rules.
 func TestAccDatasourceWizCloudConfigRules_basic(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcCommon)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcCommon)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDatasourceWizCloudConfigRulesBasic,
@@ -16,8 +16,8 @@ func TestAccDatasourceWizSubscriptionResourceGroups_basic(t *testing.T) {
 	subscriptionID := os.Getenv("WIZ_SUBSCRIPTION_ID")
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcSubscriptionResourceGroups)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcSubscriptionResourceGroups)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDatasourceWizSubscriptionResourceGroupsBasic(subscriptionID),
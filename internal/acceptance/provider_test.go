@@ -1,24 +1,30 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/provider"
 )
 
 // required/common environment variables for acceptance tests
 var commonEnvVars = []string{"WIZ_URL", "WIZ_AUTH_CLIENT_ID", "WIZ_AUTH_CLIENT_SECRET"}
 
-// providerFactories are used to instantiate a provider during acceptance testing.
+// protoV6ProviderFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
 // to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"wiz": func() (*schema.Provider, error) {
-		return provider.New("dev")(), nil
+var protoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"wiz": func() (tfprotov6.ProviderServer, error) {
+		serverFactory, err := provider.NewProtocol6ProviderServer(context.Background(), "dev")
+		if err != nil {
+			return nil, err
+		}
+		return serverFactory(), nil
 	},
 }
 
@@ -13,8 +13,8 @@ func TestAccResourceWizAutomationRuleJiraCreateTicket_basic(t *testing.T) {
 	rName := acctest.RandomWithPrefix(ResourcePrefix)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcJira)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcJira)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizAutomationRuleJiraCreateTicketBasic(rName),
@@ -13,8 +13,8 @@ func TestAccResourceWizAutomationRuleJiraTransitionTicket_basic(t *testing.T) {
 	rName := acctest.RandomWithPrefix(ResourcePrefix)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcServiceNow)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcServiceNow)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizAutomationRuleJiraTransitionTicketBasic(rName),
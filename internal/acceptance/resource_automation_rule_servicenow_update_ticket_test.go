@@ -12,8 +12,8 @@ func TestAccResourceWizAutomationRuleServiceNowUpdateTicket_basic(t *testing.T)
 	rName := acctest.RandomWithPrefix(ResourcePrefix)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcServiceNow)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcServiceNow)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizAutomationRuleServiceNowUpdateTicketBasic(rName),
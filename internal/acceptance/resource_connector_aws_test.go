@@ -13,8 +13,8 @@ func TestAccResourceWizConnectorAws_basic(t *testing.T) {
 	rName := acctest.RandomWithPrefix(ResourcePrefix)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcCommon)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcCommon)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizConnectorAwsBasic(rName),
@@ -61,13 +61,18 @@ func TestAccResourceWizConnectorAws_basic(t *testing.T) {
 					),
 					resource.TestCheckResourceAttr(
 						"wiz_connector_aws.foo",
-						"extra_config",
-						"{\"auditLogMonitorEnabled\":false,\"excludedAccounts\":[\"100000000009\"],\"excludedOUs\":[\"DEV\"],\"optedInRegions\":[\"us-east-1\"],\"skipOrganizationScan\":true}",
+						"extra_config.0.skip_organization_scan",
+						"true",
 					),
 					resource.TestCheckResourceAttr(
 						"wiz_connector_aws.foo",
-						"auth_params",
-						fmt.Sprintf("{\"customerRoleARN\":\"arn:aws:iam::000000000000:role/%s\"}", rName),
+						"extra_config.0.opted_in_regions.0",
+						"us-east-1",
+					),
+					resource.TestCheckResourceAttr(
+						"wiz_connector_aws.foo",
+						"auth_params.0.customer_role_arn",
+						fmt.Sprintf("arn:aws:iam::000000000000:role/%s", rName),
 					),
 				),
 			},
@@ -79,18 +84,16 @@ func testResourceWizConnectorAwsBasic(rName string) string {
 	return fmt.Sprintf(`
 	resource "wiz_connector_aws" "foo" {
 		name = "%[1]s"
-		auth_params = jsonencode({
-			"customerRoleARN" : "arn:aws:iam::000000000000:role/%[1]s",
-		})
-		extra_config = jsonencode(
-			{
-				"skipOrganizationScan" : true,
-				"optedInRegions" : ["us-east-1"],
-				"excludedAccounts" : ["100000000009"],
-				"excludedOUs" : ["DEV"],
-				"auditLogMonitorEnabled" : false
-			}
-		)
+		auth_params {
+			customer_role_arn = "arn:aws:iam::000000000000:role/%[1]s"
+		}
+		extra_config {
+			skip_organization_scan    = true
+			opted_in_regions          = ["us-east-1"]
+			excluded_accounts         = ["100000000009"]
+			excluded_ous              = ["DEV"]
+			audit_log_monitor_enabled = false
+		}
 	}
 `, rName)
 }
@@ -13,8 +13,8 @@ func TestAccResourceWizConnectorGcp_basic(t *testing.T) {
 	rName := acctest.RandomWithPrefix(ResourcePrefix)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcCommon)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcCommon)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizConnectorGcpBasic(rName),
@@ -31,8 +31,8 @@ func TestAccResourceWizConnectorGcp_basic(t *testing.T) {
 					),
 					resource.TestCheckResourceAttr(
 						"wiz_connector_gcp.foo",
-						"auth_params",
-						"{\"folder_id\":\"123456\",\"isManagedIdentity\":true}",
+						"auth_params.0.folder_id",
+						"123456",
 					),
 					resource.TestMatchResourceAttr(
 						"wiz_connector_gcp.foo",
@@ -46,8 +46,8 @@ func TestAccResourceWizConnectorGcp_basic(t *testing.T) {
 					),
 					resource.TestCheckResourceAttr(
 						"wiz_connector_gcp.foo",
-						"extra_config",
-						"{\"auditLogMonitorEnabled\":false,\"excludedFolders\":[],\"excludedProjects\":[],\"includedFolders\":[],\"projects\":[]}",
+						"extra_config.0.audit_log_monitor_enabled",
+						"false",
 					),
 				),
 			},
@@ -59,19 +59,13 @@ func testResourceWizConnectorGcpBasic(rName string) string {
 	return fmt.Sprintf(`
 	resource "wiz_connector_gcp" "foo" {
 		name = "%[1]s"
-		auth_params = jsonencode({
-		  "isManagedIdentity" : true,
-		  "folder_id" : "123456",
-		})
-		extra_config = jsonencode(
-		  {
-			"projects" : [],
-			"excludedProjects" : [],
-			"includedFolders" : [],
-			"excludedFolders" : [],
-			"auditLogMonitorEnabled" : false,
-		  }
-		)
+		auth_params {
+		  is_managed_identity = true
+		  folder_id           = "123456"
+		}
+		extra_config {
+		  audit_log_monitor_enabled = false
+		}
 	  }
 `, rName)
 }
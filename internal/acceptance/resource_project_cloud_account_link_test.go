@@ -13,8 +13,8 @@ func TestAccResourceWizProjectCloudAccountLink_basic(t *testing.T) {
 	cloudAccountID := os.Getenv("WIZ_SUBSCRIPTION_ID")
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TcProjectCloudAccountLink) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TcProjectCloudAccountLink) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizProjectCloudAccountLinkBasic(projectID, cloudAccountID),
@@ -14,8 +14,8 @@ func TestAccResourceWizReportGraphQuery_basic(t *testing.T) {
 	projectID := os.Getenv("WIZ_PROJECT_ID")
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcReportGraphQuery)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcReportGraphQuery)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizReportGraphQueryBasic(rName, projectID),
@@ -14,8 +14,8 @@ func TestAccResourceWizSAMLGroupMapping_basic(t *testing.T) {
 	projectID := os.Getenv("WIZ_PROJECT_ID")
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TcSAMLGroupMapping) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TcSAMLGroupMapping) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizSAMLGroupMappingBasic(samlIdpID, providerGroupID, projectID),
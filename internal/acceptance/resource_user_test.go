@@ -15,8 +15,8 @@ func TestAccResourceWizUser_basic(t *testing.T) {
 	smtpDomain := os.Getenv("WIZ_SMTP_DOMAIN")
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t, TestCase(TcUser)) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t, TestCase(TcUser)) },
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testResourceWizUserBasic(rName, smtpDomain),
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// MaxBatchSize is the maximum number of operations sent in a single batched request, larger batches are split
+var MaxBatchSize = 50
+
+var (
+	batchRootField     = regexp.MustCompile(`^\s*(\w+)`)
+	batchVariable      = regexp.MustCompile(`\$(\w+)`)
+	batchVariableNames = regexp.MustCompile(`\$(\w+)\s*:`)
+)
+
+// BatchOperation struct - a single operation of a batched request
+// Query is a complete document with a single root field, as it would be sent to ProcessRequest, e.g.
+// `query control($id: ID!) { control(id: $id) { id } }`
+// Vars are encoded as they are by ProcessRequest, mutation variables are wrapped in an input variable
+// Once processed, the result is unmarshalled into Data and the errors reported for the operation are stored in Diags and Errors
+type BatchOperation struct {
+	Query  string
+	Vars   interface{}
+	Data   interface{}
+	Diags  diag.Diagnostics
+	Errors GraphQLErrors
+}
+
+// parsedOperation struct - the parts of an operation needed to merge it into a batched document
+type parsedOperation struct {
+	definitions string
+	selection   string
+	field       string
+	variables   map[string]json.RawMessage
+}
+
+// ProcessBatchRequest func - send several operations of the same kind as aliased fields of a single graphql document
+// Each aliased result and error is mapped back to its operation, so the caller checks the Diags of every operation
+// The returned diagnostics report failures that prevented the operations from being processed, e.g. an invalid query or an http error
+// When the api nulls the complete response because of an error in one operation, the remaining operations are sent individually
+func ProcessBatchRequest(ctx context.Context, m interface{}, operations []*BatchOperation, resourceType string, operation string) (diags diag.Diagnostics) {
+	tflog.Info(ctx, "client.ProcessBatchRequest called...")
+	tflog.Debug(ctx, fmt.Sprintf("Received %d operations for %s %s", len(operations), resourceType, operation))
+
+	ctx, span := startOperationSpan(ctx, resourceType, operation)
+	defer func() {
+		config.EndSpan(ctx, span, diags)
+	}()
+
+	size := MaxBatchSize
+	if size < 1 {
+		size = 1
+	}
+	for start := 0; start < len(operations); start += size {
+		end := start + size
+		if end > len(operations) {
+			end = len(operations)
+		}
+		diags = append(diags, processBatch(ctx, m, operations[start:end], resourceType, operation)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return diags
+}
+
+// processBatch func - send a single batched document and distribute the results
+func processBatch(ctx context.Context, m interface{}, operations []*BatchOperation, resourceType string, operation string) (diags diag.Diagnostics) {
+	// merge the operations into a single document, each root field is aliased and its variables are prefixed with the alias
+	var definitions, selections []string
+	variables := map[string]json.RawMessage{}
+	fields := make([]string, len(operations))
+	for i, op := range operations {
+		parsed, err := parseBatchOperation(op, operation)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		alias := batchAlias(i)
+		rename := func(s string) string {
+			return batchVariable.ReplaceAllString(s, "$$"+alias+"_$1")
+		}
+		if parsed.definitions != "" {
+			definitions = append(definitions, rename(parsed.definitions))
+		}
+		selections = append(selections, fmt.Sprintf("%s: %s", alias, rename(parsed.selection)))
+		for name, value := range parsed.variables {
+			variables[alias+"_"+name] = value
+		}
+		fields[i] = parsed.field
+	}
+
+	keyword := "query"
+	if operation != "read" {
+		keyword = "mutation"
+	}
+	document := fmt.Sprintf("%s Batch", keyword)
+	if len(definitions) > 0 {
+		document += fmt.Sprintf("(%s)", strings.Join(definitions, ", "))
+	}
+	document += fmt.Sprintf(" {\n%s\n}", strings.Join(selections, "\n"))
+	tflog.Debug(ctx, fmt.Sprintf("Batched document: %s", document))
+
+	// call the api
+	rbody, diags := executeRequest(ctx, m, &GraphQLRequest{Query: document, Variables: variables}, resourceType, operation)
+	if diags.HasError() {
+		return diags
+	}
+
+	response := struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors GraphQLErrors              `json:"errors"`
+	}{}
+	err := json.Unmarshal(rbody, &response)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	// map the errors to the operations by alias, errors without an alias apply to every operation
+	var unattributed GraphQLErrors
+	for _, gqlError := range response.Errors {
+		index := -1
+		if len(gqlError.Path) > 0 {
+			if alias, ok := gqlError.Path[0].(string); ok {
+				fmt.Sscanf(alias, "b%d", &index)
+			}
+		}
+		if index < 0 || index >= len(operations) {
+			unattributed = append(unattributed, gqlError)
+			continue
+		}
+		gqlError.Path = gqlError.Path[1:]
+		operations[index].Errors = append(operations[index].Errors, gqlError)
+	}
+
+	var retry []*BatchOperation
+	for i, op := range operations {
+		op.Errors = append(op.Errors, unattributed...)
+		if len(op.Errors) > 0 {
+			op.Diags = append(op.Diags, op.Errors.Diagnostics(resourceType, operation)...)
+		}
+
+		raw, ok := response.Data[batchAlias(i)]
+		if !ok {
+			// the result of this operation was nulled by an error in another operation
+			if len(op.Errors) == 0 && len(operations) > 1 {
+				retry = append(retry, op)
+			}
+			continue
+		}
+		data, err := json.Marshal(map[string]json.RawMessage{fields[i]: raw})
+		if err != nil {
+			op.Diags = append(op.Diags, diag.FromErr(err)...)
+			continue
+		}
+		if err := json.Unmarshal(data, op.Data); err != nil {
+			op.Diags = append(op.Diags, diag.FromErr(err)...)
+		}
+	}
+
+	// send the operations without a result on their own
+	for _, op := range retry {
+		tflog.Debug(ctx, "Batched response was nulled by an error, sending the operation on its own")
+		diags = append(diags, processBatch(ctx, m, []*BatchOperation{op}, resourceType, operation)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// batchAlias func - the alias of the operation at index i of a batch
+func batchAlias(i int) string {
+	return fmt.Sprintf("b%d", i)
+}
+
+// parseBatchOperation func - split an operation into its variable definitions, root selection and encoded variables
+func parseBatchOperation(op *BatchOperation, operation string) (parsedOperation, error) {
+	var parsed parsedOperation
+
+	open := strings.Index(op.Query, "{")
+	closing := strings.LastIndex(op.Query, "}")
+	if open < 0 || closing < open {
+		return parsed, fmt.Errorf("batched query has no selection set: %s", op.Query)
+	}
+
+	// the variable definitions are enclosed in the parentheses of the operation header
+	header := op.Query[:open]
+	if start := strings.Index(header, "("); start >= 0 {
+		end := strings.LastIndex(header, ")")
+		if end < start {
+			return parsed, fmt.Errorf("batched query has malformed variable definitions: %s", op.Query)
+		}
+		parsed.definitions = strings.TrimSpace(header[start+1 : end])
+	}
+
+	parsed.selection = strings.TrimSpace(op.Query[open+1 : closing])
+	field := batchRootField.FindStringSubmatch(parsed.selection)
+	if field == nil {
+		return parsed, fmt.Errorf("batched query has no root field: %s", op.Query)
+	}
+	parsed.field = field[1]
+
+	// encode the variables as ProcessRequest does, keeping only the declared variables
+	vars := op.Vars
+	if operation != "read" {
+		vars = &MutationInput{Input: op.Vars}
+	}
+	parsed.variables = map[string]json.RawMessage{}
+	if vars != nil {
+		raw, err := json.Marshal(vars)
+		if err != nil {
+			return parsed, err
+		}
+		encoded := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return parsed, fmt.Errorf("variables for a batched request must encode to a json object: %w", err)
+		}
+		for _, name := range batchVariableNames.FindAllStringSubmatch(parsed.definitions, -1) {
+			if value, ok := encoded[name[1]]; ok {
+				parsed.variables[name[1]] = value
+			}
+		}
+	}
+
+	return parsed, nil
+}
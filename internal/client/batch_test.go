@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal"
+)
+
+const testBatchQuery = `query thing (
+  $id: ID!
+){
+  thing(
+    id: $id
+  ) {
+    id
+  }
+}`
+
+// testThing struct
+type testThing struct {
+	Thing struct {
+		ID string `json:"id"`
+	} `json:"thing"`
+}
+
+// newBatchServer returns an api server resolving every aliased thing to its id variable, ids starting with missing return a NOT_FOUND error
+// When nullData is set, an error nulls the data of the complete response
+func newBatchServer(t *testing.T, nullData bool) (*httptest.Server, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Query     string            `json:"query"`
+			Variables map[string]string `json:"variables"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		queries = append(queries, request.Query)
+
+		names := make([]string, 0, len(request.Variables))
+		for name := range request.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		data := map[string]interface{}{}
+		var errors []map[string]interface{}
+		for _, name := range names {
+			alias := strings.TrimSuffix(name, "_id")
+			id := request.Variables[name]
+			if strings.HasPrefix(id, "missing") {
+				errors = append(errors, map[string]interface{}{
+					"message":    fmt.Sprintf("thing %s not found", id),
+					"path":       []string{alias, "thing"},
+					"extensions": map[string]string{"code": "NOT_FOUND"},
+				})
+				data[alias] = nil
+				continue
+			}
+			data[alias] = map[string]string{"id": id}
+		}
+
+		response := map[string]interface{}{"data": data}
+		if len(errors) > 0 {
+			response["errors"] = errors
+			if nullData {
+				response["data"] = nil
+			}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+// newBatchOperations returns a read operation for each id
+func newBatchOperations(ids ...string) []*BatchOperation {
+	operations := make([]*BatchOperation, 0, len(ids))
+	for _, id := range ids {
+		operations = append(operations, &BatchOperation{
+			Query: testBatchQuery,
+			Vars:  &internal.QueryVariables{ID: id},
+			Data:  &testThing{},
+		})
+	}
+	return operations
+}
+
+func TestProcessBatchRequest(t *testing.T) {
+	server, queries := newBatchServer(t, false)
+
+	operations := newBatchOperations("one", "missing", "three")
+	diags := ProcessBatchRequest(context.Background(), newPagedProviderConf(server.URL), operations, "things", "read")
+	assert.Empty(t, diags)
+
+	// the operations are sent as aliased fields of a single document
+	assert.Len(t, *queries, 1)
+	assert.Contains(t, (*queries)[0], "query Batch($b0_id:ID!$b1_id:ID!$b2_id:ID!)")
+	assert.Contains(t, (*queries)[0], "b1:thing(id:$b1_id)")
+
+	// each result and error is mapped back to its operation
+	assert.Equal(t, "one", operations[0].Data.(*testThing).Thing.ID)
+	assert.Empty(t, operations[0].Diags)
+	assert.Equal(t, "three", operations[2].Data.(*testThing).Thing.ID)
+	assert.Empty(t, operations[2].Diags)
+
+	assert.Empty(t, operations[1].Data.(*testThing).Thing.ID)
+	assert.True(t, operations[1].Diags.HasError())
+	assert.True(t, IsNotFound(operations[1].Errors))
+	assert.Equal(t, []interface{}{"thing"}, operations[1].Errors[0].Path)
+}
+
+func TestProcessBatchRequestNulledData(t *testing.T) {
+	server, queries := newBatchServer(t, true)
+
+	// the operations without errors are sent again on their own
+	operations := newBatchOperations("one", "missing", "three")
+	diags := ProcessBatchRequest(context.Background(), newPagedProviderConf(server.URL), operations, "things", "read")
+	assert.Empty(t, diags)
+	assert.Len(t, *queries, 3)
+
+	assert.Equal(t, "one", operations[0].Data.(*testThing).Thing.ID)
+	assert.True(t, IsNotFound(operations[1].Errors))
+	assert.Equal(t, "three", operations[2].Data.(*testThing).Thing.ID)
+}
+
+func TestProcessBatchRequestSplitsLargeBatches(t *testing.T) {
+	server, queries := newBatchServer(t, false)
+
+	previous := MaxBatchSize
+	MaxBatchSize = 2
+	t.Cleanup(func() { MaxBatchSize = previous })
+
+	operations := newBatchOperations("one", "two", "three", "four", "five")
+	diags := ProcessBatchRequest(context.Background(), newPagedProviderConf(server.URL), operations, "things", "read")
+	assert.Empty(t, diags)
+	assert.Len(t, *queries, 3)
+	for i, id := range []string{"one", "two", "three", "four", "five"} {
+		assert.Equal(t, id, operations[i].Data.(*testThing).Thing.ID)
+	}
+}
+
+func TestProcessBatchRequestStopsAtDeadline(t *testing.T) {
+	server, queries := newBatchServer(t, false)
+
+	previous := MaxBatchSize
+	MaxBatchSize = 2
+	t.Cleanup(func() { MaxBatchSize = previous })
+
+	// the deadline passes while the first batch is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	conf := newPagedProviderConf(server.URL)
+	conf.HTTPClient = &http.Client{
+		Transport: &mockRoundTripper{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				defer cancel()
+				return http.DefaultTransport.RoundTrip(req)
+			},
+		},
+	}
+
+	operations := newBatchOperations("one", "two", "three", "four", "five")
+	diags := ProcessBatchRequest(ctx, conf, operations, "things", "read")
+	assert.Len(t, *queries, 1)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Operation cancelled", diags[0].Summary)
+	}
+	assert.Empty(t, operations[2].Data.(*testThing).Thing.ID)
+}
+
+func TestParseBatchOperation(t *testing.T) {
+	// mutation variables are wrapped in an input variable
+	parsed, err := parseBatchOperation(&BatchOperation{
+		Query: `mutation UpdateThing($input: UpdateThingInput!) { updateThing(input: $input) { thing { id } } }`,
+		Vars:  map[string]string{"id": "one"},
+	}, "update")
+	assert.NoError(t, err)
+	assert.Equal(t, "$input: UpdateThingInput!", parsed.definitions)
+	assert.Equal(t, "updateThing", parsed.field)
+	assert.JSONEq(t, `{"id": "one"}`, string(parsed.variables["input"]))
+
+	// undeclared variables are not sent
+	parsed, err = parseBatchOperation(&BatchOperation{Query: testBatchQuery, Vars: &internal.QueryVariables{ID: "one", First: 5}}, "read")
+	assert.NoError(t, err)
+	assert.Len(t, parsed.variables, 1)
+
+	_, err = parseBatchOperation(&BatchOperation{Query: "query thing"}, "read")
+	assert.Error(t, err)
+}
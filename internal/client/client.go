@@ -5,38 +5,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/http/httputil"
 	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"wiz.io/hashicorp/terraform-provider-wiz/internal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
-	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
 // GraphQLRequest struct
 type GraphQLRequest struct {
-	Query     string      `json:"query"`
-	Variables interface{} `json:"variables"`
+	Query      string      `json:"query,omitempty"`
+	Variables  interface{} `json:"variables"`
+	Extensions interface{} `json:"extensions,omitempty"`
 }
 
 // MutationPayload struct
 type MutationPayload struct {
-	Data   interface{} `json:"data"`
-	Errors []struct {
-		Message    string `json:"message,omitempty"`
-		Extensions struct {
-			Code      string `json:"code,omitempty"`
-			Exception struct {
-				Message string   `json:"message,omitempty"`
-				Path    []string `json:"path,omitempty"`
-			} `json:"exception,omitempty"`
-		} `json:"extensions,omitempty"`
-	} `json:"errors,omitempty"`
+	Data   interface{}   `json:"data"`
+	Errors GraphQLErrors `json:"errors,omitempty"`
 }
 
 // MutationInput struct
@@ -46,73 +38,46 @@ type MutationInput struct {
 
 // ProcessRequest func - process the unpaginated request
 func ProcessRequest(ctx context.Context, m interface{}, vars, data interface{}, query, resourceType, operation string) (diags diag.Diagnostics) {
+	diags, _ = ProcessRequestWithErrors(ctx, m, vars, data, query, resourceType, operation)
+	return diags
+}
+
+// ProcessRequestWithErrors func - process the unpaginated request and return the typed errors reported by the api
+// gqlErrors is empty unless the api returned a GraphQL errors list, use IsNotFound and similar helpers to branch on it
+func ProcessRequestWithErrors(ctx context.Context, m interface{}, vars, data interface{}, query, resourceType, operation string) (diags diag.Diagnostics, gqlErrors GraphQLErrors) {
 	tflog.Info(ctx, "client.ProcessRequest called...")
-	tflog.Debug(ctx, fmt.Sprintf("Received vars: %T, %s", vars, utils.PrettyPrint(vars)))
+	ctx, span := startOperationSpan(ctx, resourceType, operation)
+	defer func() {
+		config.EndSpan(ctx, span, diags)
+	}()
+	tflog.Debug(ctx, fmt.Sprintf("Received vars: %T, %s", vars, m.(*config.ProviderConf).Redactor.Redact(utils.PrettyPrint(vars))))
 	tflog.Debug(ctx, fmt.Sprintf("Received query: %T, %s", query, query))
 	tflog.Debug(ctx, fmt.Sprintf("Received resourceType/operation: %s %s", resourceType, operation))
 
-	// get an http client
-	client := m.(*config.ProviderConf).HTTPClient
-
-	// encode the request body (graphql query and variables)
-	b := new(bytes.Buffer)
+	// build the request (graphql query and variables)
+	request := &GraphQLRequest{Query: query}
 	switch op := operation; op {
 	case "read":
-		err := json.NewEncoder(b).Encode(GraphQLRequest{Query: query, Variables: vars})
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
-		tflog.Debug(ctx, fmt.Sprintf("%s %s request variables: %s", resourceType, operation, utils.PrettyPrint(vars)))
+		request.Variables = vars
+		tflog.Debug(ctx, fmt.Sprintf("%s %s request variables: %s", resourceType, operation, m.(*config.ProviderConf).Redactor.Redact(utils.PrettyPrint(vars))))
 	default:
 		input := &MutationInput{}
 		input.Input = vars
-		err := json.NewEncoder(b).Encode(GraphQLRequest{Query: query, Variables: input})
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
-		tflog.Debug(ctx, fmt.Sprintf("%s %s request variables: %s", resourceType, operation, utils.PrettyPrint(input)))
-	}
-
-	// create the http request, set the user agent, setup the authentication token, log the request
-	request, error, diags := CreateRequest(ctx, m, b, diags, resourceType, operation)
-	if error {
-		return diags
+		request.Variables = input
+		tflog.Debug(ctx, fmt.Sprintf("%s %s request variables: %s", resourceType, operation, m.(*config.ProviderConf).Redactor.Redact(utils.PrettyPrint(input))))
 	}
 
 	// call the api
-	resp, err := client.Do(request)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
-	}
-	defer resp.Body.Close()
-
-	// log the response
-	respDump, err := httputil.DumpResponse(resp, true)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
-	}
-	tflog.Debug(ctx, fmt.Sprintf("%s %s api response: %s", resourceType, operation, respDump))
-
-	// handle http errors
-	if resp.StatusCode != http.StatusOK {
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("HTTP Response (%d)", resp.StatusCode),
-			Detail:   fmt.Sprintf("Response: %s", respDump),
-		})
-	}
-
-	// read the response
-	rbody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	rbody, diags := executeRequest(ctx, m, request, resourceType, operation)
+	if diags.HasError() {
+		return diags, nil
 	}
 
 	// unmarshal the response
 	responseBody := &MutationPayload{Data: data}
-	err = json.Unmarshal(rbody, &responseBody)
+	err := json.Unmarshal(rbody, &responseBody)
 	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+		return append(diags, diag.FromErr(err)...), nil
 	}
 
 	// handle errors from the api
@@ -120,115 +85,74 @@ func ProcessRequest(ctx context.Context, m interface{}, vars, data interface{},
 	tflog.Debug(ctx, fmt.Sprintf("Error count: %d", errorCount))
 	if errorCount > 0 {
 		tflog.Debug(ctx, fmt.Sprintf("Errors returned from API (%d)", errorCount))
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("%s %s reported errors", resourceType, operation),
-			Detail:   fmt.Sprintf("Response: %s", utils.PrettyPrint(responseBody.Errors)),
-		})
+		return append(diags, responseBody.Errors.Diagnostics(resourceType, operation)...), responseBody.Errors
 	}
 
 	// log the return data
-	tflog.Debug(ctx, fmt.Sprintf("Wrote data: %T, %s", data, utils.PrettyPrint(data)))
+	tflog.Debug(ctx, fmt.Sprintf("Wrote data: %T, %s", data, m.(*config.ProviderConf).Redactor.Redact(utils.PrettyPrint(data))))
 
-	return diags
+	return diags, nil
 }
 
 // ProcessPagedRequest func - process the paginated request
+// data is a pointer to the struct each page is unmarshalled into, every page is returned as a new copy of data
 func ProcessPagedRequest(ctx context.Context, m interface{}, vars interface{}, data interface{}, query string, resourceType string, operation string, maxPages int) (diags diag.Diagnostics, allthedata []interface{}) {
+	diags, allthedata, _ = ProcessPagedRequestWithErrors(ctx, m, vars, data, query, resourceType, operation, maxPages)
+	return diags, allthedata
+}
+
+// ProcessPagedRequestWithErrors func - process the paginated request and also return the GraphQL errors of the page that failed
+func ProcessPagedRequestWithErrors(ctx context.Context, m interface{}, vars interface{}, data interface{}, query string, resourceType string, operation string, maxPages int) (diags diag.Diagnostics, allthedata []interface{}, gqlErrors GraphQLErrors) {
 
 	tflog.Info(ctx, "client.ProcessPagedRequest called...")
-	tflog.Debug(ctx, fmt.Sprintf("Received vars: %T, %s", vars, utils.PrettyPrint(vars)))
+	tflog.Debug(ctx, fmt.Sprintf("Received vars: %T, %s", vars, m.(*config.ProviderConf).Redactor.Redact(utils.PrettyPrint(vars))))
 	tflog.Debug(ctx, fmt.Sprintf("Received query: %T, %s", query, query))
 	tflog.Debug(ctx, fmt.Sprintf("Received resourceType/operation: %s %s", resourceType, operation))
 	tflog.Debug(ctx, fmt.Sprintf("Received maxPages: %d", maxPages))
 
-	// get an http client
-	client := m.(*config.ProviderConf).HTTPClient
-
-	// encode the request body (graphql query and variables)
-	b := new(bytes.Buffer)
-	switch op := operation; op {
-	case "read":
-		err := json.NewEncoder(b).Encode(GraphQLRequest{Query: query, Variables: vars})
-		if err != nil {
-			return append(diags, diag.FromErr(err)...), nil
-		}
-		tflog.Debug(ctx, fmt.Sprintf("%s %s request variables: %s", resourceType, operation, utils.PrettyPrint(vars)))
-	default:
-		return append(diags, diag.FromErr(fmt.Errorf("operation %s not supported for paged operations", operation))...), nil
+	if operation != "read" {
+		return append(diags, diag.FromErr(fmt.Errorf("operation %s not supported for paged operations", operation))...), nil, nil
 	}
 
-	// create the http request, set the user agent, setup the authentication token, log the request
-	request, error, diags := CreateRequest(ctx, m, b, diags, resourceType, operation)
-	if error {
-		return diags, nil
+	// copy the value of data to a new instance for every page
+	newPage := func() interface{} {
+		page := reflect.New(reflect.TypeOf(data).Elem())
+		page.Elem().Set(reflect.ValueOf(data).Elem())
+		return page.Interface()
 	}
 
 	var allData []interface{}
-	endCursor := ""
-	paginate := true
-	currentPage := 0
-	// loop through the pages, while there are more pages to process
-	// maxPages of 0 fetches all pages, there is an OR grouping for the third sub-condition
-	for paginate && maxPages >= 0 && (currentPage < maxPages || maxPages == 0) {
-		currentPage++
-		tflog.Debug(ctx, fmt.Sprintf("Processing page %d with a maximum of %d pages (maximum of 0 means unlimited)", currentPage, maxPages))
-		// make the request using `endCursor` if it's not empty
-		if endCursor != "" {
-			queryVars, ok := vars.(*internal.QueryVariables)
-			if !ok {
-				return append(diags, diag.FromErr(fmt.Errorf("unable to cast vars to internal.QueryVariables"))...), nil
-			}
-			queryVars.After = endCursor
-			vars = interface{}(queryVars)
-			err := json.NewEncoder(b).Encode(GraphQLRequest{Query: query, Variables: vars})
-			if err != nil {
-				return append(diags, diag.FromErr(err)...), nil
-			}
-
-			// create the http request, set the user agent, setup the authentication token, log the request
-			request, error, diags := CreateRequest(ctx, m, b, diags, resourceType, operation)
-			if error {
-				return diags, nil
-			}
-			// make the request and handle the response
-			error, diags, continuePaging, newEndCursor := RequestDo(ctx, client, request, diags, resourceType, operation, data, &allData)
-			if error {
-				return diags, nil
-			}
-
-			// update `endCursor` and `paginate`
-			endCursor = newEndCursor
-			paginate = continuePaging
-		} else {
-			// make the initial request without `endCursor`
-			error, diags, continuePaging, newEndCursor := RequestDo(ctx, client, request, diags, resourceType, operation, data, &allData)
-			if error {
-				return diags, nil
-			}
-			// update `endCursor` and `paginate`
-			endCursor = newEndCursor
-			paginate = continuePaging
-		}
-
-		if !paginate {
-			break // exit loop if there are no more pages to fetch
-		}
+	diags, gqlErrors = paginate(ctx, m, vars, query, resourceType, PageOptions{MaxPages: maxPages}, newPage, func(page interface{}) (bool, diag.Diagnostics) {
+		allData = append(allData, page)
+		return true, nil
+	})
+	if diags.HasError() {
+		return diags, nil, gqlErrors
 	}
 
-	return diags, allData
+	return diags, allData, nil
 }
 
 // CreateRequest func - create the http request
 func CreateRequest(ctx context.Context, m interface{}, b *bytes.Buffer, diags diag.Diagnostics, resourceType string, operation string) (*http.Request, bool, diag.Diagnostics) {
-	request, err := http.NewRequest("POST", m.(*config.ProviderConf).Settings.WizURL, b)
+	request, err := http.NewRequestWithContext(ctx, "POST", m.(*config.ProviderConf).Settings.WizURL, b)
 	if err != nil {
 		return nil, true, append(diags, diag.FromErr(err)...)
 	}
 
 	request.Header.Set("User-Agent", m.(*config.ProviderConf).UserAgent)
+	request.Header.Set(RequestIDHeader, requestID(ctx))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
 
-	authToken := fmt.Sprintf("%s %s", m.(*config.ProviderConf).TokenType, m.(*config.ProviderConf).Token)
+	// get a session token, this refreshes the token if it is about to expire
+	tokenType, token, tokenDiags := m.(*config.ProviderConf).GetToken(ctx)
+	if ctx.Err() != nil {
+		return nil, true, append(diags, requestErrorDiagnostics(ctx, ctx.Err(), resourceType, operation)...)
+	}
+	if tokenDiags.HasError() {
+		return nil, true, append(diags, tokenDiags...)
+	}
+	authToken := fmt.Sprintf("%s %s", tokenType, token)
 	request.Header.Add("Authorization", authToken)
 	request.Header.Add("Content-Type", "application/json")
 
@@ -236,129 +160,145 @@ func CreateRequest(ctx context.Context, m interface{}, b *bytes.Buffer, diags di
 	if err != nil {
 		return nil, true, append(diags, diag.FromErr(err)...)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("%s %s request: %s", resourceType, operation, reqDump))
+	tflog.Debug(ctx, fmt.Sprintf("%s %s request: %s", resourceType, operation, m.(*config.ProviderConf).Redactor.RedactDump(reqDump)))
 	return request, false, nil
 }
 
-// RequestDo func - make the http request and handle the response
-func RequestDo(ctx context.Context, client *http.Client, request *http.Request, diags diag.Diagnostics, resourceType string, operation string, data interface{}, alldata *[]interface{}) (error bool, diagnostics diag.Diagnostics, haspages bool, cursor string) {
+// executeRequest func - send the graphql request and return the raw response body
+// The query is sent minified, with persisted queries enabled only its hash is sent until the api asks for the full text
+func executeRequest(ctx context.Context, m interface{}, request *GraphQLRequest, resourceType string, operation string) ([]byte, diag.Diagnostics) {
+	conf := m.(*config.ProviderConf)
+	registered := Queries.Register(request.Query)
+	full := &GraphQLRequest{
+		Query:     registered.Text,
+		Variables: request.Variables,
+	}
+
+	if conf.Settings.PersistedQueries && !conf.PersistedQueriesUnsupported.Load() {
+		persisted := &GraphQLRequest{
+			Variables:  request.Variables,
+			Extensions: registered.Extensions(),
+		}
+		rbody, diags := sendRequest(ctx, m, persisted, resourceType, operation)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		switch persistedQueryError(rbody) {
+		case "":
+			return rbody, diags
+		case persistedQueryNotSupported:
+			// stop sending hashes to an api that does not support them
+			tflog.Debug(ctx, "Persisted queries are not supported by the api, sending the full query text")
+			conf.PersistedQueriesUnsupported.Store(true)
+		default:
+			// register the query by sending its text with the hash
+			tflog.Debug(ctx, fmt.Sprintf("Persisted query %s not found, sending the full query text", registered.Hash))
+			full.Extensions = registered.Extensions()
+		}
+	}
+
+	return sendRequest(ctx, m, full, resourceType, operation)
+}
+
+// sendRequest func - encode and send a single graphql request and return the raw response body
+func sendRequest(ctx context.Context, m interface{}, gqlRequest *GraphQLRequest, resourceType string, operation string) (rbody []byte, diags diag.Diagnostics) {
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(gqlRequest)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+
+	// trace the full request/response cycle
+	ctx, span := startRequestSpan(ctx, resourceType, operation)
+	defer func() {
+		span.end(ctx, diags)
+	}()
+
+	// serve identical read queries from the cache, mutations drop every cached response
+	cache := m.(*config.ProviderConf).Cache
+	var cacheKey string
+	var cacheGeneration uint64
+	if cache != nil {
+		if operation == "read" {
+			key, err := config.CacheKey(b.Bytes())
+			if err == nil {
+				cacheKey = key
+				if cached, ok := cache.Get(cacheKey); ok {
+					tflog.Debug(ctx, fmt.Sprintf("%s %s served from the read cache", resourceType, operation))
+					span.cacheHit = true
+					return cached, diags
+				}
+				cacheGeneration = cache.Generation()
+			}
+		} else {
+			// invalidate again once the mutation completed, reads sent while it was applied may return the objects before it
+			cache.Invalidate()
+			defer cache.Invalidate()
+		}
+	}
+
+	// get an http client
+	client := m.(*config.ProviderConf).HTTPClient
+
+	// create the http request, set the user agent, setup the authentication token, log the request
+	request, error, requestDiags := CreateRequest(ctx, m, b, nil, resourceType, operation)
+	diags = append(diags, requestDiags...)
+	if error {
+		return nil, diags
+	}
 
 	// call the api
 	resp, err := client.Do(request)
 	if err != nil {
-		return true, append(diags, diag.FromErr(err)...), false, ""
+		return nil, append(diags, requestErrorDiagnostics(ctx, err, resourceType, operation)...)
 	}
 	defer resp.Body.Close()
+	span.status = resp.StatusCode
 
-	// log the response
-	respDump, err := httputil.DumpResponse(resp, true)
+	// log the response, masking credentials such as service account secrets
+	dump, err := httputil.DumpResponse(resp, true)
 	if err != nil {
-		return true, append(diags, diag.FromErr(err)...), false, ""
+		return nil, append(diags, requestErrorDiagnostics(ctx, err, resourceType, operation)...)
 	}
+	respDump := m.(*config.ProviderConf).Redactor.RedactDump(dump)
 	tflog.Debug(ctx, fmt.Sprintf("%s %s api response: %s", resourceType, operation, respDump))
 
-	// handle http errors
+	// handle http errors, a rejected pre-issued token cannot be refreshed by the provider
+	if resp.StatusCode == http.StatusUnauthorized {
+		if rejectedDiags := config.RejectedTokenDiagnostics(m.(*config.ProviderConf).Settings); rejectedDiags != nil {
+			return nil, append(diags, rejectedDiags...)
+		}
+	}
 	if resp.StatusCode != http.StatusOK {
-		return true, append(diags, diag.Diagnostic{
+		return nil, append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("HTTP Response (%d)", resp.StatusCode),
 			Detail:   fmt.Sprintf("Response: %s", respDump),
-		}), false, ""
+		})
 	}
 
 	// read the response
-	rbody, err := ioutil.ReadAll(resp.Body)
+	rbody, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return true, append(diags, diag.FromErr(err)...), false, ""
+		return nil, append(diags, requestErrorDiagnostics(ctx, err, resourceType, operation)...)
 	}
 
-	// copy the value of data to a new instance
-	newData := reflect.New(reflect.TypeOf(data).Elem()).Interface()
-	reflect.ValueOf(newData).Elem().Set(reflect.ValueOf(data).Elem())
-
-	// unmarshal the response to the new instance
-	responseBody := &MutationPayload{Data: newData}
-	err = json.Unmarshal(rbody, &responseBody)
-	if err != nil {
-		return true, append(diags, diag.FromErr(err)...), false, ""
+	// only complete responses are cached
+	if cacheKey != "" && !hasGraphQLErrors(rbody) {
+		cache.Put(cacheKey, cacheGeneration, rbody)
 	}
 
-	// handle errors from the api
-	errorCount := len(responseBody.Errors)
-	tflog.Debug(ctx, fmt.Sprintf("Error count: %d", errorCount))
-	if errorCount > 0 {
-		tflog.Debug(ctx, fmt.Sprintf("Errors returned from API (%d)", errorCount))
-		return true, append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("%s %s reported errors", resourceType, operation),
-			Detail:   fmt.Sprintf("Response: %s", utils.PrettyPrint(responseBody.Errors)),
-		}), false, ""
-	}
-
-	// append the page of data to the Data slice, and set the data field in the response body to nil to avoid duplication
-	paginationDetails, err := ExtractPageInfo(responseBody.Data)
-	if err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Error extracting pagination details: %s", err))
-		return true, append(diags, diag.FromErr(err)...), false, ""
-	}
-
-	tflog.Debug(ctx, fmt.Sprintf("Pagination details: %s", utils.PrettyPrint(paginationDetails)))
-
-	// set the data field in the response body to nil to avoid duplication
-	responseBody.Data = nil
-
-	// append the new instance to the allData slice
-	*alldata = append(*alldata, newData)
-
-	// log the return data
-	tflog.Debug(ctx, fmt.Sprintf("Wrote paginated data: %T, %s", data, utils.PrettyPrint(data)))
-
-	if paginationDetails.HasNextPage {
-		return false, nil, true, paginationDetails.EndCursor
-	}
-
-	return false, nil, false, ""
+	return rbody, diags
 }
 
-// ExtractPageInfo func - extract the PageInfo struct from a generic response
-func ExtractPageInfo(data interface{}) (wiz.PageInfo, error) {
-	var pageInfo wiz.PageInfo
-
-	value := reflect.ValueOf(data)
-	if value.Kind() == reflect.Ptr {
-		value = value.Elem()
-	}
-	if value.Kind() != reflect.Struct {
-		return pageInfo, fmt.Errorf("data %v is not a struct", value.Kind())
-	}
-	// loop through the fields of the struct
-	for i := 0; i < value.NumField(); i++ {
-		field := value.Field(i)
-		fieldType := field.Type()
-
-		if fieldType.Kind() == reflect.Struct {
-			// loop through the fields of the struct
-			for j := 0; j < fieldType.NumField(); j++ {
-				subfield := fieldType.Field(j)
-				subfieldType := subfield.Type
-				// search for the PageInfo struct
-				if subfieldType.Kind() == reflect.Struct && subfieldType.Name() == "PageInfo" {
-					pageInfoValue := field.Field(j)
-					pageInfoType := pageInfoValue.Type()
-
-					for k := 0; k < pageInfoType.NumField(); k++ {
-						pageInfoField := pageInfoValue.Field(k)
-						switch pageInfoType.Field(k).Name {
-						case "EndCursor":
-							pageInfo.EndCursor = pageInfoField.String()
-						case "HasNextPage":
-							pageInfo.HasNextPage = pageInfoField.Bool()
-
-						}
-					}
-				}
-			}
-		}
+// hasGraphQLErrors func - report whether a response body contains a GraphQL errors list
+func hasGraphQLErrors(rbody []byte) bool {
+	response := struct {
+		Errors []json.RawMessage `json:"errors"`
+	}{}
+	if err := json.Unmarshal(rbody, &response); err != nil {
+		return true
 	}
-	return pageInfo, nil
+	return len(response.Errors) > 0
 }
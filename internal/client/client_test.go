@@ -3,17 +3,19 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/assert"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
-	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
 // mockRoundTripper struct
@@ -25,79 +27,6 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	return m.RoundTripFunc(req)
 }
 
-func TestRequestDo(t *testing.T) {
-	// Create a mock HTTP client
-	mockClient := &http.Client{}
-
-	// Create a mock HTTP server
-	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Write mock response
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"data": {"field": "value"}}`))
-	}))
-	defer mockServer.Close()
-
-	// Create a mock request
-	mockRequest, err := http.NewRequest("GET", mockServer.URL, nil)
-	assert.NoError(t, err)
-
-	// Create a mock data object
-	expectedData := &struct {
-		Field string `json:"field"`
-	}{Field: "value"}
-
-	// Create a mock slice for alldata
-	var mockAllData []interface{}
-
-	// Call the RequestDo function
-	errorBool, diagnostics, hasPages, cursor := RequestDo(context.Background(), mockClient, mockRequest, nil, "resourceType", "operation", expectedData, &mockAllData)
-
-	// Assertions
-	assert.False(t, errorBool)
-	assert.Empty(t, diagnostics)
-	assert.False(t, hasPages)
-	assert.Empty(t, cursor)
-	assert.Equal(t, 1, len(mockAllData))
-	assert.Equal(t, expectedData, mockAllData[0])
-}
-func TestExtractPageInfo(t *testing.T) {
-	// NestedStruct struct
-	type NestedStruct struct {
-		PageInfo wiz.PageInfo `json:"pageInfo"`
-	}
-	// ParentStruct struct
-	type ParentStruct struct {
-		Foo NestedStruct `json:"foo"`
-	}
-
-	data := ParentStruct{
-		Foo: NestedStruct{
-			PageInfo: wiz.PageInfo{
-				EndCursor:   "cursor123",
-				HasNextPage: true,
-			},
-		},
-	}
-
-	// Call the function
-	pageInfo, err := ExtractPageInfo(&data)
-
-	// Check for errors
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
-	}
-
-	// Check the extracted PageInfo values
-	expected := wiz.PageInfo{
-		EndCursor:   "cursor123",
-		HasNextPage: true,
-	}
-
-	if !reflect.DeepEqual(pageInfo, expected) {
-		t.Errorf("Unexpected PageInfo. Expected: %+v, but got: %+v", expected, pageInfo)
-	}
-}
-
 func TestCreateRequest(t *testing.T) {
 	ctx := context.TODO()
 
@@ -219,6 +148,29 @@ func TestProcessRequest(t *testing.T) {
 	// Add additional assertions as needed
 }
 
+func TestProcessRequestRedactsData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"clientId": "my-client", "clientSecret": "s3cr3t-value"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	data := &struct {
+		ClientID     string `json:"clientId"`
+		ClientSecret string `json:"clientSecret"`
+	}{}
+	diags := ProcessRequest(ctx, newPagedProviderConf(server.URL), nil, data, "mutation", "service_account", "create")
+	assert.Empty(t, diags)
+
+	// the secret is returned to the caller but never logged
+	assert.Equal(t, "s3cr3t-value", data.ClientSecret)
+	assert.Contains(t, output.String(), "Wrote data")
+	assert.Contains(t, output.String(), "my-client")
+	assert.NotContains(t, output.String(), "s3cr3t-value")
+}
+
 func TestProcessPagedRequest(t *testing.T) {
 	// Mock data
 	mockVars := struct {
@@ -276,3 +228,150 @@ func TestProcessPagedRequest(t *testing.T) {
 	assert.Empty(t, diags)
 	assert.Equal(t, len(allData), 1)
 }
+
+func TestProcessRequestCancelled(t *testing.T) {
+	// the mock transport blocks until the request context is cancelled
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			},
+		},
+	}
+
+	mockProviderConf := &config.ProviderConf{
+		HTTPClient: mockClient,
+		Settings: &config.Settings{
+			WizURL: "http://example.com",
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	mockData := struct {
+		Field string `json:"field"`
+	}{}
+	diags := ProcessRequest(ctx, mockProviderConf, struct{}{}, &mockData, "mock query", "mock resource", "read")
+
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Operation cancelled", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "deadline")
+
+	// paged requests stop before the first page once the context is done
+	diags, allData := ProcessPagedRequest(ctx, mockProviderConf, struct{}{}, &mockData, "mock query", "mock resource", "read", 0)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Operation cancelled", diags[0].Summary)
+	assert.Empty(t, allData)
+}
+
+func TestProcessRequestStaticTokenRejected(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusUnauthorized,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error": "unauthorized"}`)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}
+
+	mockProviderConf := &config.ProviderConf{
+		HTTPClient: mockClient,
+		Settings: &config.Settings{
+			WizURL:       "http://example.com",
+			WizAuthToken: "testtoken",
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+
+	mockData := struct {
+		Field string `json:"field"`
+	}{}
+	diags := ProcessRequest(context.Background(), mockProviderConf, struct{}{}, &mockData, "mock query", "mock resource", "read")
+
+	// the static token is reported as rejected instead of a bare http error
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Session token rejected", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "wiz_auth_token")
+}
+
+func TestProcessRequestReadCache(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"data": {"field": "value-%d"}}`, calls)
+	}))
+	defer server.Close()
+
+	conf := newPagedProviderConf(server.URL)
+	conf.Cache = config.NewResponseCache(time.Minute)
+
+	read := func(vars interface{}) string {
+		data := &struct {
+			Field string `json:"field"`
+		}{}
+		diags := ProcessRequest(context.Background(), conf, vars, data, "query", "things", "read")
+		assert.Empty(t, diags)
+		return data.Field
+	}
+
+	// identical reads are served from the cache
+	assert.Equal(t, "value-1", read(map[string]interface{}{"id": "1", "first": 5}))
+	assert.Equal(t, "value-1", read(map[string]interface{}{"first": 5, "id": "1"}))
+	assert.Equal(t, 1, calls)
+
+	// different variables are not
+	assert.Equal(t, "value-2", read(map[string]interface{}{"id": "2"}))
+
+	// a mutation of any resource type drops the cache, e.g. a control association changes the reads of controls
+	assert.Empty(t, ProcessRequest(context.Background(), conf, nil, &struct{}{}, "mutation", "thing_associations", "update"))
+	assert.Equal(t, "value-4", read(map[string]interface{}{"id": "1", "first": 5}))
+	assert.Equal(t, "value-4", read(map[string]interface{}{"id": "1", "first": 5}))
+	assert.Equal(t, 4, calls)
+}
+
+func TestProcessRequestReadCacheDuringMutation(t *testing.T) {
+	var conf *config.ProviderConf
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		request := struct {
+			Query string `json:"query"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		// the first read is answered with the objects as they were before a mutation sent meanwhile
+		if request.Query == "query" && n == 1 {
+			assert.Empty(t, ProcessRequest(context.Background(), conf, nil, &struct{}{}, "mutation", "others", "update"))
+			fmt.Fprint(w, `{"data": {"field": "before"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"field": "after"}}`)
+	}))
+	defer server.Close()
+
+	conf = newPagedProviderConf(server.URL)
+	conf.Cache = config.NewResponseCache(time.Minute)
+
+	read := func() string {
+		data := &struct {
+			Field string `json:"field"`
+		}{}
+		diags := ProcessRequest(context.Background(), conf, nil, data, "query", "things", "read")
+		assert.Empty(t, diags)
+		return data.Field
+	}
+
+	// the response of the read in flight is returned but not cached
+	assert.Equal(t, "before", read())
+	assert.Equal(t, "after", read())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
+)
+
+// GraphQLErrorException struct
+type GraphQLErrorException struct {
+	Message string   `json:"message,omitempty"`
+	Path    []string `json:"path,omitempty"`
+}
+
+// GraphQLErrorExtensions struct
+type GraphQLErrorExtensions struct {
+	Code      string                `json:"code,omitempty"`
+	Exception GraphQLErrorException `json:"exception,omitempty"`
+}
+
+// GraphQLError is a single entry in the errors list of a GraphQL response
+type GraphQLError struct {
+	Message    string                 `json:"message,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions GraphQLErrorExtensions `json:"extensions,omitempty"`
+}
+
+// Error implements the error interface
+func (e GraphQLError) Error() string {
+	msg := e.Message
+	if e.Extensions.Exception.Message != "" && e.Extensions.Exception.Message != msg {
+		msg = fmt.Sprintf("%s: %s", msg, e.Extensions.Exception.Message)
+	}
+	if e.Extensions.Code != "" {
+		return fmt.Sprintf("%s (%s)", msg, e.Extensions.Code)
+	}
+	return msg
+}
+
+// Code returns the error code from extensions.code
+func (e GraphQLError) Code() string {
+	return e.Extensions.Code
+}
+
+// HasCode reports whether the error code is one of codes
+func (e GraphQLError) HasCode(codes ...string) bool {
+	for _, code := range codes {
+		if strings.EqualFold(e.Extensions.Code, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributePath maps the exception path reported by the api to a schema attribute path
+// The path is relative to the mutation input, so the input and patch wrappers are skipped and field names are converted to snake_case
+func (e GraphQLError) AttributePath() cty.Path {
+	var path cty.Path
+	for _, step := range e.Extensions.Exception.Path {
+		if len(path) == 0 && (step == "input" || step == "patch") {
+			continue
+		}
+		if index, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(index)
+			continue
+		}
+		path = path.GetAttr(utils.CamelToSnake(step))
+	}
+	return path
+}
+
+// GraphQLErrors is the errors list of a GraphQL response
+type GraphQLErrors []GraphQLError
+
+// Error implements the error interface
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, gqlError := range e {
+		messages = append(messages, gqlError.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// HasCode reports whether any of the errors has one of codes
+func (e GraphQLErrors) HasCode(codes ...string) bool {
+	for _, gqlError := range e {
+		if gqlError.HasCode(codes...) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnostics returns one error diagnostic per GraphQL error, pointing at the offending attribute when the api reports one
+func (e GraphQLErrors) Diagnostics(resourceType string, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, gqlError := range e {
+		summary := fmt.Sprintf("%s %s reported errors", resourceType, operation)
+		if gqlError.Code() != "" {
+			summary = fmt.Sprintf("%s %s reported errors (%s)", resourceType, operation, gqlError.Code())
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        fmt.Sprintf("Response: %s", utils.PrettyPrint(gqlError)),
+			AttributePath: gqlError.AttributePath(),
+		})
+	}
+	return diags
+}
+
+// hasErrorCode reports whether err is or wraps a GraphQL error with one of codes
+func hasErrorCode(err error, codes []string) bool {
+	var gqlErrors GraphQLErrors
+	if errors.As(err, &gqlErrors) {
+		return gqlErrors.HasCode(codes...)
+	}
+	var gqlError GraphQLError
+	if errors.As(err, &gqlError) {
+		return gqlError.HasCode(codes...)
+	}
+	return false
+}
+
+// IsNotFound reports whether the api reported that the requested object does not exist
+func IsNotFound(err error) bool {
+	return hasErrorCode(err, wiz.GraphQLErrorCodeNotFound)
+}
+
+// IsUnauthorized reports whether the api rejected the session token or its permissions
+func IsUnauthorized(err error) bool {
+	return hasErrorCode(err, wiz.GraphQLErrorCodeUnauthorized)
+}
+
+// IsRateLimited reports whether the api throttled the request
+func IsRateLimited(err error) bool {
+	return hasErrorCode(err, wiz.GraphQLErrorCodeRateLimited)
+}
+
+// IsConflict reports whether the api reported that the object already exists or was modified concurrently
+func IsConflict(err error) bool {
+	return hasErrorCode(err, wiz.GraphQLErrorCodeConflict)
+}
+
+// requestErrorDiagnostics converts an error from calling the api to diagnostics
+// Cancellation of the context, by Ctrl-C or an expired operation deadline, is reported as a single clean diagnostic instead of a transport error
+func requestErrorDiagnostics(ctx context.Context, err error, resourceType string, operation string) diag.Diagnostics {
+	ctxErr := ctx.Err()
+	if ctxErr == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		ctxErr = err
+	}
+	if ctxErr == nil {
+		return diag.FromErr(err)
+	}
+
+	detail := fmt.Sprintf("The %s %s operation was cancelled before it completed.", resourceType, operation)
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		detail = fmt.Sprintf("The %s %s operation did not complete before its deadline. Raise the timeouts of the resource if the operation needs more time.", resourceType, operation)
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Operation cancelled",
+			Detail:   detail,
+		},
+	}
+}
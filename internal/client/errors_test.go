@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+func TestGraphQLErrorsUnmarshal(t *testing.T) {
+	payload := `{
+		"data": null,
+		"errors": [
+			{
+				"message": "Resource not found",
+				"path": ["control", 0],
+				"extensions": {
+					"code": "NOT_FOUND",
+					"exception": {
+						"message": "control does not exist",
+						"path": ["input", "patch", "securitySubCategories", "1"]
+					}
+				}
+			}
+		]
+	}`
+
+	responseBody := &MutationPayload{}
+	err := json.Unmarshal([]byte(payload), responseBody)
+	assert.NoError(t, err)
+	assert.Len(t, responseBody.Errors, 1)
+
+	gqlError := responseBody.Errors[0]
+	assert.Equal(t, "NOT_FOUND", gqlError.Code())
+	assert.Equal(t, "Resource not found: control does not exist (NOT_FOUND)", gqlError.Error())
+	assert.Equal(t, cty.GetAttrPath("security_sub_categories").IndexInt(1), gqlError.AttributePath())
+}
+
+func TestGraphQLErrorHelpers(t *testing.T) {
+	newErrors := func(code string) GraphQLErrors {
+		return GraphQLErrors{{Message: "message", Extensions: GraphQLErrorExtensions{Code: code}}}
+	}
+
+	assert.True(t, IsNotFound(newErrors("NOT_FOUND")))
+	assert.True(t, IsUnauthorized(newErrors("UNAUTHENTICATED")))
+	assert.True(t, IsUnauthorized(newErrors("FORBIDDEN")))
+	assert.True(t, IsRateLimited(newErrors("RATE_LIMIT_EXCEEDED")))
+	assert.True(t, IsConflict(newErrors("ALREADY_EXISTS")))
+	assert.False(t, IsNotFound(newErrors("INTERNAL")))
+	assert.False(t, IsNotFound(GraphQLErrors(nil)))
+	assert.False(t, IsNotFound(nil))
+
+	// wrapped errors are detected
+	assert.True(t, IsNotFound(fmt.Errorf("read failed: %w", newErrors("NOT_FOUND"))))
+	assert.True(t, IsConflict(newErrors("CONFLICT")[0]))
+}
+
+func TestGraphQLErrorsDiagnostics(t *testing.T) {
+	gqlErrors := GraphQLErrors{
+		{
+			Message: "invalid name",
+			Extensions: GraphQLErrorExtensions{
+				Code:      "BAD_USER_INPUT",
+				Exception: GraphQLErrorException{Path: []string{"input", "name"}},
+			},
+		},
+		{
+			Message: "internal error",
+		},
+	}
+
+	diags := gqlErrors.Diagnostics("project", "create")
+	assert.Len(t, diags, 2)
+	assert.Equal(t, "project create reported errors (BAD_USER_INPUT)", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[0].AttributePath)
+	assert.Equal(t, "project create reported errors", diags[1].Summary)
+	assert.Nil(t, diags[1].AttributePath)
+}
+
+func TestProcessRequestWithErrors(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				responseBody := []byte(`{"data": {"control": null}, "errors": [{"message": "not found", "extensions": {"code": "NOT_FOUND"}}]}`)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}
+
+	mockProviderConf := &config.ProviderConf{
+		HTTPClient: mockClient,
+		Settings: &config.Settings{
+			WizURL: "http://example.com",
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+
+	data := &struct {
+		Control *struct {
+			ID string `json:"id"`
+		} `json:"control"`
+	}{}
+	diags, gqlErrors := ProcessRequestWithErrors(context.TODO(), mockProviderConf, struct{}{}, data, "query", "control", "read")
+
+	assert.True(t, diags.HasError())
+	assert.True(t, IsNotFound(gqlErrors))
+}
+
+func TestProcessPagedRequestWithErrors(t *testing.T) {
+	mockClient := &http.Client{
+		Transport: &mockRoundTripper{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				responseBody := []byte(`{"data": null, "errors": [{"message": "not found", "extensions": {"code": "NOT_FOUND"}}]}`)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(responseBody)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}
+
+	mockProviderConf := &config.ProviderConf{
+		HTTPClient: mockClient,
+		Settings: &config.Settings{
+			WizURL: "http://example.com",
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+
+	data := &struct {
+		Field string `json:"field"`
+	}{}
+	diags, allData, gqlErrors := ProcessPagedRequestWithErrors(context.TODO(), mockProviderConf, struct{}{}, data, "query", "saml_idp", "read", 0)
+
+	assert.True(t, diags.HasError())
+	assert.Empty(t, allData)
+	assert.True(t, IsNotFound(gqlErrors))
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
+)
+
+// PageOptions controls how many pages are fetched and where the pagination details are found
+type PageOptions struct {
+	// MaxPages is the maximum number of pages to fetch, 0 fetches all pages and a negative value fetches none
+	MaxPages int
+	// PageInfoPath is the dot separated path to the pageInfo object in the response data, e.g. cloudAccounts.pageInfo
+	// When empty, the first pageInfo object found in the response data is used
+	PageInfoPath string
+}
+
+// Paginate func - fetch the pages of a paginated read query, passing each page to fn as it arrives
+// vars may be any struct or map that encodes to a json object, the `after` variable is set to the cursor of the previous page
+// and a fresh request body is encoded for every page
+// fn returns false to stop paging before the remaining pages are fetched
+func Paginate[T any](ctx context.Context, m interface{}, vars interface{}, query string, resourceType string, opts PageOptions, fn func(page *T) (bool, diag.Diagnostics)) diag.Diagnostics {
+	newPage := func() interface{} {
+		return new(T)
+	}
+	diags, _ := paginate(ctx, m, vars, query, resourceType, opts, newPage, func(page interface{}) (bool, diag.Diagnostics) {
+		return fn(page.(*T))
+	})
+	return diags
+}
+
+// Pages func - iterate over the pages of a paginated read query, the next page is only fetched once the current page is consumed
+// Breaking out of the loop stops paging, so callers can flatten and filter page by page and stop when they have enough results
+// Diagnostics are yielded once with a nil page, after which the iteration ends
+func Pages[T any](ctx context.Context, m interface{}, vars interface{}, query string, resourceType string, opts PageOptions) iter.Seq2[*T, diag.Diagnostics] {
+	return func(yield func(*T, diag.Diagnostics) bool) {
+		stopped := false
+		diags := Paginate(ctx, m, vars, query, resourceType, opts, func(page *T) (bool, diag.Diagnostics) {
+			if !yield(page, nil) {
+				stopped = true
+				return false, nil
+			}
+			return true, nil
+		})
+		if !stopped && len(diags) > 0 {
+			yield(nil, diags)
+		}
+	}
+}
+
+// paginate func - the untyped implementation of Paginate, newPage returns a pointer for each page to be unmarshalled into
+// The errors list of the response that stopped paging is returned along with the diagnostics
+func paginate(ctx context.Context, m interface{}, vars interface{}, query string, resourceType string, opts PageOptions, newPage func() interface{}, fn func(page interface{}) (bool, diag.Diagnostics)) (diags diag.Diagnostics, gqlErrors GraphQLErrors) {
+	operation := "read"
+	endCursor := ""
+
+	// a single span covers every page
+	ctx, span := startOperationSpan(ctx, resourceType, operation)
+	defer func() {
+		config.EndSpan(ctx, span, diags)
+	}()
+
+	// maxPages of 0 fetches all pages, there is an OR grouping for the second sub-condition
+	for currentPage := 1; opts.MaxPages >= 0 && (opts.MaxPages == 0 || currentPage <= opts.MaxPages); currentPage++ {
+		// stop paging as soon as the operation is cancelled
+		if ctx.Err() != nil {
+			return append(diags, requestErrorDiagnostics(ctx, ctx.Err(), resourceType, operation)...), nil
+		}
+		ctx := tflog.SetField(ctx, "page", currentPage)
+		tflog.Debug(ctx, fmt.Sprintf("Processing page %d with a maximum of %d pages (maximum of 0 means unlimited)", currentPage, opts.MaxPages))
+
+		// build a fresh request for this page
+		request, err := newPageRequest(query, vars, endCursor)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...), nil
+		}
+
+		// call the api
+		rbody, requestDiags := executeRequest(ctx, m, request, resourceType, operation)
+		diags = append(diags, requestDiags...)
+		if diags.HasError() {
+			return diags, nil
+		}
+
+		// unmarshal the response to a new page
+		page := newPage()
+		responseBody := &MutationPayload{Data: page}
+		err = json.Unmarshal(rbody, &responseBody)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...), nil
+		}
+
+		// handle errors from the api
+		errorCount := len(responseBody.Errors)
+		tflog.Debug(ctx, fmt.Sprintf("Error count: %d", errorCount))
+		if errorCount > 0 {
+			tflog.Debug(ctx, fmt.Sprintf("Errors returned from API (%d)", errorCount))
+			return append(diags, responseBody.Errors.Diagnostics(resourceType, operation)...), responseBody.Errors
+		}
+
+		// locate the pagination details
+		pageInfo, err := FindPageInfo(rbody, opts.PageInfoPath)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Error extracting pagination details: %s", err))
+			return append(diags, diag.FromErr(err)...), nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Pagination details: %+v", pageInfo))
+
+		// hand the page to the caller
+		continuePaging, pageDiags := fn(page)
+		diags = append(diags, pageDiags...)
+		if diags.HasError() || !continuePaging {
+			return diags, nil
+		}
+
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			break // exit loop if there are no more pages to fetch
+		}
+		if pageInfo.EndCursor == endCursor {
+			return append(diags, diag.FromErr(fmt.Errorf("%s %s returned the same cursor %q twice", resourceType, operation, endCursor))...), nil
+		}
+		endCursor = pageInfo.EndCursor
+	}
+
+	return diags, nil
+}
+
+// newPageRequest func - build the graphql request for a page, setting the `after` variable when a cursor is given
+func newPageRequest(query string, vars interface{}, after string) (*GraphQLRequest, error) {
+	variables := map[string]json.RawMessage{}
+	if vars != nil {
+		raw, err := json.Marshal(vars)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &variables); err != nil {
+			return nil, fmt.Errorf("variables for a paged request must encode to a json object: %w", err)
+		}
+		if variables == nil {
+			variables = map[string]json.RawMessage{}
+		}
+	}
+	if after != "" {
+		cursor, err := json.Marshal(after)
+		if err != nil {
+			return nil, err
+		}
+		variables["after"] = cursor
+	}
+
+	return &GraphQLRequest{Query: query, Variables: variables}, nil
+}
+
+// FindPageInfo func - extract the pageInfo object from the data of a raw graphql response
+// path is the dot separated path to the pageInfo object within the data, when empty the response is searched for the first pageInfo object
+// A response without a pageInfo object has no further pages
+func FindPageInfo(rbody []byte, path string) (wiz.PageInfo, error) {
+	var pageInfo wiz.PageInfo
+
+	response := struct {
+		Data interface{} `json:"data"`
+	}{}
+	err := json.Unmarshal(rbody, &response)
+	if err != nil {
+		return pageInfo, err
+	}
+
+	var found interface{}
+	if path != "" {
+		found = response.Data
+		for _, key := range strings.Split(path, ".") {
+			object, ok := found.(map[string]interface{})
+			if !ok {
+				return pageInfo, fmt.Errorf("pageInfo path %s not found in the response", path)
+			}
+			found, ok = object[key]
+			if !ok {
+				return pageInfo, fmt.Errorf("pageInfo path %s not found in the response", path)
+			}
+		}
+	} else {
+		found = searchPageInfo(response.Data)
+	}
+	if found == nil {
+		return pageInfo, nil
+	}
+
+	// convert the generic object to the PageInfo struct
+	raw, err := json.Marshal(found)
+	if err != nil {
+		return pageInfo, err
+	}
+	err = json.Unmarshal(raw, &pageInfo)
+	return pageInfo, err
+}
+
+// searchPageInfo func - return the first pageInfo object found, searching breadth first so the outermost connection wins
+func searchPageInfo(data interface{}) interface{} {
+	queue := []interface{}{data}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if pageInfo, ok := object["pageInfo"]; ok && pageInfo != nil {
+			return pageInfo
+		}
+
+		// visit the keys in a stable order
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			queue = append(queue, object[key])
+		}
+	}
+	return nil
+}
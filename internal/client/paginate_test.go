@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
+)
+
+// testPage struct
+type testPage struct {
+	Things struct {
+		Nodes    []string     `json:"nodes"`
+		PageInfo wiz.PageInfo `json:"pageInfo"`
+	} `json:"things"`
+}
+
+// newPagedServer returns an api server serving pages pages of two nodes each, and records the variables of every request
+func newPagedServer(t *testing.T, pages int) (*httptest.Server, *[]map[string]interface{}) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Variables map[string]interface{} `json:"variables"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request.Variables)
+
+		page := 0
+		if after, ok := request.Variables["after"].(string); ok {
+			fmt.Sscanf(after, "cursor-%d", &page)
+		}
+		hasNextPage := page+1 < pages
+		fmt.Fprintf(w, `{"data": {"things": {"nodes": ["node-%d-a", "node-%d-b"], "pageInfo": {"hasNextPage": %t, "endCursor": "cursor-%d"}}}}`, page, page, hasNextPage, page+1)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newPagedProviderConf(url string) *config.ProviderConf {
+	return &config.ProviderConf{
+		HTTPClient: &http.Client{},
+		Settings: &config.Settings{
+			WizURL: url,
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	server, requests := newPagedServer(t, 3)
+
+	vars := &internal.QueryVariables{First: 2}
+	var nodes []string
+	diags := Paginate(context.Background(), newPagedProviderConf(server.URL), vars, "query", "things", PageOptions{}, func(page *testPage) (bool, diag.Diagnostics) {
+		nodes = append(nodes, page.Things.Nodes...)
+		return true, nil
+	})
+
+	assert.Empty(t, diags)
+	assert.Equal(t, []string{"node-0-a", "node-0-b", "node-1-a", "node-1-b", "node-2-a", "node-2-b"}, nodes)
+
+	// every page is sent as a complete request body with the cursor of the previous page
+	assert.Len(t, *requests, 3)
+	assert.Nil(t, (*requests)[0]["after"])
+	assert.Equal(t, "cursor-1", (*requests)[1]["after"])
+	assert.Equal(t, "cursor-2", (*requests)[2]["after"])
+	for _, request := range *requests {
+		assert.Equal(t, float64(2), request["first"])
+	}
+
+	// the caller's variables are not modified
+	assert.Empty(t, vars.After)
+}
+
+func TestPaginateEarlyTermination(t *testing.T) {
+	server, requests := newPagedServer(t, 5)
+
+	// any variables struct with an after field works
+	vars := struct {
+		ProjectID string `json:"projectId"`
+		After     string `json:"after,omitempty"`
+	}{ProjectID: "project"}
+
+	pages := 0
+	diags := Paginate(context.Background(), newPagedProviderConf(server.URL), vars, "query", "things", PageOptions{PageInfoPath: "things.pageInfo"}, func(page *testPage) (bool, diag.Diagnostics) {
+		pages++
+		return pages < 2, nil
+	})
+
+	assert.Empty(t, diags)
+	assert.Equal(t, 2, pages)
+	assert.Len(t, *requests, 2)
+	assert.Equal(t, "project", (*requests)[1]["projectId"])
+}
+
+func TestPaginateMaxPages(t *testing.T) {
+	server, requests := newPagedServer(t, 5)
+
+	pages := 0
+	diags := Paginate(context.Background(), newPagedProviderConf(server.URL), map[string]interface{}{}, "query", "things", PageOptions{MaxPages: 3}, func(page *testPage) (bool, diag.Diagnostics) {
+		pages++
+		return true, nil
+	})
+
+	assert.Empty(t, diags)
+	assert.Equal(t, 3, pages)
+	assert.Len(t, *requests, 3)
+}
+
+func TestPaginateSinglePage(t *testing.T) {
+	// a response without pageInfo is a single page
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"field": "value"}}`))
+	}))
+	defer server.Close()
+
+	type fieldPage struct {
+		Field string `json:"field"`
+	}
+	expectedData := &fieldPage{Field: "value"}
+
+	// pages are decoded and handed over one at a time
+	var allData []interface{}
+	diags := Paginate(context.Background(), newPagedProviderConf(server.URL), nil, "query", "resourceType", PageOptions{}, func(page *fieldPage) (bool, diag.Diagnostics) {
+		allData = append(allData, page)
+		return true, nil
+	})
+
+	assert.Empty(t, diags)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, len(allData))
+	assert.Equal(t, expectedData, allData[0])
+}
+
+func TestFindPageInfoNested(t *testing.T) {
+	// NestedStruct struct
+	type NestedStruct struct {
+		PageInfo wiz.PageInfo `json:"pageInfo"`
+	}
+	// ParentStruct struct
+	type ParentStruct struct {
+		Foo NestedStruct `json:"foo"`
+	}
+
+	data := ParentStruct{
+		Foo: NestedStruct{
+			PageInfo: wiz.PageInfo{
+				EndCursor:   "cursor123",
+				HasNextPage: true,
+			},
+		},
+	}
+	rbody, err := json.Marshal(map[string]interface{}{"data": data})
+	assert.NoError(t, err)
+
+	// Call the function
+	pageInfo, err := FindPageInfo(rbody, "")
+
+	// Check for errors
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Check the extracted PageInfo values
+	expected := wiz.PageInfo{
+		EndCursor:   "cursor123",
+		HasNextPage: true,
+	}
+
+	if !reflect.DeepEqual(pageInfo, expected) {
+		t.Errorf("Unexpected PageInfo. Expected: %+v, but got: %+v", expected, pageInfo)
+	}
+}
+
+func TestFindPageInfo(t *testing.T) {
+	rbody := []byte(`{"data": {"foo": {"nodes": [{"children": {"pageInfo": {"hasNextPage": false}}}], "pageInfo": {"endCursor": "cursor123", "hasNextPage": true}}}}`)
+	expected := wiz.PageInfo{
+		EndCursor:   "cursor123",
+		HasNextPage: true,
+	}
+
+	// the outermost pageInfo is found without a path
+	pageInfo, err := FindPageInfo(rbody, "")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pageInfo)
+
+	// the pageInfo is found by path
+	pageInfo, err = FindPageInfo(rbody, "foo.pageInfo")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, pageInfo)
+
+	// a missing path is an error
+	_, err = FindPageInfo(rbody, "bar.pageInfo")
+	assert.Error(t, err)
+
+	// a response without pageInfo has no more pages
+	pageInfo, err = FindPageInfo([]byte(`{"data": {"field": "value"}}`), "")
+	assert.NoError(t, err)
+	assert.False(t, pageInfo.HasNextPage)
+}
+
+func TestPages(t *testing.T) {
+	server, requests := newPagedServer(t, 5)
+
+	// breaking out of the loop stops paging
+	var nodes []string
+	for page, diags := range Pages[testPage](context.Background(), newPagedProviderConf(server.URL), &internal.QueryVariables{}, "query", "things", PageOptions{}) {
+		assert.Empty(t, diags)
+		nodes = append(nodes, page.Things.Nodes...)
+		if len(nodes) >= 3 {
+			break
+		}
+	}
+	assert.Len(t, nodes, 4)
+	assert.Len(t, *requests, 2)
+
+	// errors are yielded with a nil page
+	calls := 0
+	for page, diags := range Pages[testPage](context.Background(), newPagedProviderConf("http://127.0.0.1:0"), &internal.QueryVariables{}, "query", "things", PageOptions{}) {
+		calls++
+		assert.Nil(t, page)
+		assert.True(t, diags.HasError())
+	}
+	assert.Equal(t, 1, calls)
+}
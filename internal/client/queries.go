@@ -0,0 +1,193 @@
+package client
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
+)
+
+// persisted query errors reported by the api, see https://www.apollographql.com/docs/apollo-server/performance/apq
+const (
+	persistedQueryNotFound     = "PersistedQueryNotFound"
+	persistedQueryNotSupported = "PersistedQueryNotSupported"
+)
+
+// RegisteredQuery struct - a minified query document and the sha256 hash identifying it as a persisted query
+type RegisteredQuery struct {
+	Text string
+	Hash string
+}
+
+// PersistedQueryExtensions struct
+type PersistedQueryExtensions struct {
+	PersistedQuery PersistedQuery `json:"persistedQuery"`
+}
+
+// PersistedQuery struct
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// Extensions func - the request extensions referring to the query by its hash
+func (q *RegisteredQuery) Extensions() *PersistedQueryExtensions {
+	return &PersistedQueryExtensions{
+		PersistedQuery: PersistedQuery{
+			Version:    1,
+			Sha256Hash: q.Hash,
+		},
+	}
+}
+
+// queryRegistryMaxQueries bounds the registry, it holds every query embedded in the resources while the batch documents,
+// which differ with each batch size, are evicted least recently used first
+const queryRegistryMaxQueries = 512
+
+// QueryRegistry minifies and hashes each query document once, the queries embedded in the resources are constant so the
+// registry is shared by every provider instance
+type QueryRegistry struct {
+	mu         sync.Mutex
+	maxQueries int
+	queries    map[string]*list.Element
+	order      *list.List
+}
+
+// registryEntry struct - a query document and its registration, kept in least recently used order
+type registryEntry struct {
+	query      string
+	registered *RegisteredQuery
+}
+
+// Queries is the registry used for every request
+var Queries = NewQueryRegistry()
+
+// NewQueryRegistry returns an empty registry
+func NewQueryRegistry() *QueryRegistry {
+	return &QueryRegistry{
+		maxQueries: queryRegistryMaxQueries,
+		queries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// Register func - return the minified text and hash of query, computing them on first use
+// The least recently used query is evicted once the registry is full, it is registered again on its next use
+func (r *QueryRegistry) Register(query string) *RegisteredQuery {
+	r.mu.Lock()
+	if element, ok := r.queries[query]; ok {
+		r.order.MoveToFront(element)
+		r.mu.Unlock()
+		return element.Value.(*registryEntry).registered
+	}
+	r.mu.Unlock()
+
+	text := MinifyQuery(query)
+	sum := sha256.Sum256([]byte(text))
+	registered := &RegisteredQuery{
+		Text: text,
+		Hash: hex.EncodeToString(sum[:]),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// another request may have registered the query meanwhile
+	if element, ok := r.queries[query]; ok {
+		r.order.MoveToFront(element)
+		return element.Value.(*registryEntry).registered
+	}
+	r.queries[query] = r.order.PushFront(&registryEntry{query: query, registered: registered})
+	for r.order.Len() > r.maxQueries {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.queries, oldest.Value.(*registryEntry).query)
+	}
+	return registered
+}
+
+// MinifyQuery func - remove the comments and insignificant whitespace and commas from a graphql document
+// Strings, including block strings, are kept verbatim
+func MinifyQuery(query string) string {
+	var out strings.Builder
+	out.Grow(len(query))
+
+	isNameChar := func(c byte) bool {
+		return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+	var last byte
+	separated := false
+	write := func(s string) {
+		// two names or numbers must stay separated
+		if separated && isNameChar(last) && isNameChar(s[0]) {
+			out.WriteByte(' ')
+		}
+		out.WriteString(s)
+		last = s[len(s)-1]
+		separated = false
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '#':
+			// comments run to the end of the line
+			for i < len(query) && query[i] != '\n' && query[i] != '\r' {
+				i++
+			}
+			separated = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+			separated = true
+		case strings.HasPrefix(query[i:], `"""`):
+			end := i + 3
+			for end < len(query) && !strings.HasPrefix(query[end:], `"""`) {
+				if strings.HasPrefix(query[end:], `\"""`) {
+					end += 4
+					continue
+				}
+				end++
+			}
+			end = min(end+3, len(query))
+			write(query[i:end])
+			i = end
+		case c == '"':
+			end := i + 1
+			for end < len(query) && query[end] != '"' && query[end] != '\n' {
+				if query[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(query))
+			write(query[i:end])
+			i = end
+		default:
+			write(query[i : i+1])
+			i++
+		}
+	}
+	return out.String()
+}
+
+// persistedQueryError func - return the persisted query error reported in a response body, if any
+func persistedQueryError(rbody []byte) string {
+	response := struct {
+		Errors GraphQLErrors `json:"errors"`
+	}{}
+	if err := json.Unmarshal(rbody, &response); err != nil {
+		return ""
+	}
+	for _, gqlError := range response.Errors {
+		switch {
+		case gqlError.Message == persistedQueryNotSupported || gqlError.HasCode(wiz.GraphQLErrorCodePersistedQueryNotSupported...):
+			return persistedQueryNotSupported
+		case gqlError.Message == persistedQueryNotFound || gqlError.HasCode(wiz.GraphQLErrorCodePersistedQueryNotFound...):
+			return persistedQueryNotFound
+		}
+	}
+	return ""
+}
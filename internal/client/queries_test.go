@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinifyQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name: "whitespace and commas",
+			query: `query things (
+  $first: Int, $after: String
+){
+  things(
+    first: $first
+    after: $after
+  ) {
+    nodes {
+      id
+      name
+    }
+  }
+}`,
+			expected: `query things($first:Int$after:String){things(first:$first after:$after){nodes{id name}}}`,
+		},
+		{
+			name: "comments",
+			query: `# leading comment
+query thing { # trailing comment
+  thing { id }
+}`,
+			expected: `query thing{thing{id}}`,
+		},
+		{
+			name:     "strings",
+			query:    `query { thing(name: "a, b  # c", escaped: "quote \" here") { id } }`,
+			expected: `query{thing(name:"a, b  # c"escaped:"quote \" here"){id}}`,
+		},
+		{
+			name:     "block strings",
+			query:    "query { thing(description: \"\"\"\n  line one,\n  \\\"\"\" line two\n\"\"\") { id } }",
+			expected: "query{thing(description:\"\"\"\n  line one,\n  \\\"\"\" line two\n\"\"\"){id}}",
+		},
+		{
+			name:     "fragments",
+			query:    `query { node { ... on Thing { id } } }`,
+			expected: `query{node{...on Thing{id}}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, MinifyQuery(test.query))
+		})
+	}
+}
+
+func TestQueryRegistry(t *testing.T) {
+	registry := NewQueryRegistry()
+
+	// formatting does not change the hash
+	first := registry.Register("query { thing { id } }")
+	second := registry.Register("query {\n  thing {\n    id\n  }\n}")
+	assert.Equal(t, "query{thing{id}}", first.Text)
+	assert.Equal(t, first.Hash, second.Hash)
+	assert.Len(t, first.Hash, 64)
+
+	// each document is registered once
+	assert.Same(t, first, registry.Register("query { thing { id } }"))
+	assert.NotEqual(t, first.Hash, registry.Register("query { thing { name } }").Hash)
+}
+
+func TestQueryRegistryBounded(t *testing.T) {
+	registry := NewQueryRegistry()
+	registry.maxQueries = 2
+
+	static := registry.Register("query { thing { id } }")
+	registry.Register("query { b0: thing { id } }")
+
+	// a query in use is kept while generated documents are evicted least recently used first
+	for i := 1; i < 10; i++ {
+		assert.Same(t, static, registry.Register("query { thing { id } }"))
+		registry.Register(fmt.Sprintf("query { b%d: thing { id } }", i))
+	}
+	assert.Equal(t, 2, registry.order.Len())
+	assert.Len(t, registry.queries, 2)
+	assert.Contains(t, registry.queries, "query { thing { id } }")
+	assert.Contains(t, registry.queries, "query { b9: thing { id } }")
+
+	// an evicted query is registered again with the same hash
+	evicted := registry.Register("query { b0: thing { id } }")
+	assert.Equal(t, "query{b0:thing{id}}", evicted.Text)
+	assert.Equal(t, 2, registry.order.Len())
+}
+
+// persistedQueryRequest struct - the parts of a request checked by the persisted query server
+type persistedQueryRequest struct {
+	Query      string                    `json:"query"`
+	Extensions *PersistedQueryExtensions `json:"extensions"`
+}
+
+// newPersistedQueryServer returns an api server storing persisted queries by hash, unless supported is false
+func newPersistedQueryServer(t *testing.T, supported bool) (*httptest.Server, *[]persistedQueryRequest) {
+	var requests []persistedQueryRequest
+	persisted := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request persistedQueryRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+
+		switch {
+		case request.Extensions != nil && !supported:
+			w.Write([]byte(`{"errors": [{"message": "PersistedQueryNotSupported", "extensions": {"code": "PERSISTED_QUERY_NOT_SUPPORTED"}}]}`))
+			return
+		case request.Extensions != nil && request.Query != "":
+			persisted[request.Extensions.PersistedQuery.Sha256Hash] = request.Query
+		case request.Extensions != nil:
+			if _, ok := persisted[request.Extensions.PersistedQuery.Sha256Hash]; !ok {
+				w.Write([]byte(`{"errors": [{"message": "PersistedQueryNotFound", "extensions": {"code": "PERSISTED_QUERY_NOT_FOUND"}}]}`))
+				return
+			}
+		}
+		w.Write([]byte(`{"data": {"field": "value"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestPersistedQueries(t *testing.T) {
+	server, requests := newPersistedQueryServer(t, true)
+	conf := newPagedProviderConf(server.URL)
+	conf.Settings.PersistedQueries = true
+
+	query := "query {\n  field\n}"
+	for i := 0; i < 2; i++ {
+		data := &struct {
+			Field string `json:"field"`
+		}{}
+		diags := ProcessRequest(context.Background(), conf, nil, data, query, "things", "read")
+		assert.Empty(t, diags)
+		assert.Equal(t, "value", data.Field)
+	}
+
+	// the unknown hash is registered by sending the minified text once, later requests only send the hash
+	hash := Queries.Register(query).Hash
+	if assert.Len(t, *requests, 3) {
+		assert.Empty(t, (*requests)[0].Query)
+		assert.Equal(t, hash, (*requests)[0].Extensions.PersistedQuery.Sha256Hash)
+		assert.Equal(t, "query{field}", (*requests)[1].Query)
+		assert.Equal(t, hash, (*requests)[1].Extensions.PersistedQuery.Sha256Hash)
+		assert.Empty(t, (*requests)[2].Query)
+	}
+}
+
+func TestPersistedQueriesNotSupported(t *testing.T) {
+	server, requests := newPersistedQueryServer(t, false)
+	conf := newPagedProviderConf(server.URL)
+	conf.Settings.PersistedQueries = true
+
+	for i := 0; i < 2; i++ {
+		diags := ProcessRequest(context.Background(), conf, nil, &struct{}{}, "query { field }", "things", "read")
+		assert.Empty(t, diags)
+	}
+
+	// once the api reports persisted queries as unsupported, only the full text is sent
+	assert.True(t, conf.PersistedQueriesUnsupported.Load())
+	if assert.Len(t, *requests, 3) {
+		assert.NotNil(t, (*requests)[0].Extensions)
+		for _, request := range (*requests)[1:] {
+			assert.Equal(t, "query{field}", request.Query)
+			assert.Nil(t, request.Extensions)
+		}
+	}
+}
+
+func TestPersistedQueriesDisabled(t *testing.T) {
+	server, requests := newPersistedQueryServer(t, true)
+
+	diags := ProcessRequest(context.Background(), newPagedProviderConf(server.URL), nil, &struct{}{}, "query { field }", "things", "read")
+	assert.Empty(t, diags)
+	if assert.Len(t, *requests, 1) {
+		assert.Equal(t, "query{field}", (*requests)[0].Query)
+		assert.Nil(t, (*requests)[0].Extensions)
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// RequestIDHeader is the header carrying the id generated for every api request, the id is logged with every line of the request
+// so logs can be correlated with Wiz support tickets
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the id of the request being traced
+type requestIDKey struct{}
+
+// requestSpan struct - tracks a single api request from sending the request until the response body is read
+type requestSpan struct {
+	start    time.Time
+	status   int
+	retries  *atomic.Int32
+	span     trace.Span
+	cacheHit bool
+}
+
+// startOperationSpan func - start the OpenTelemetry span of a graphql operation, which may consist of several requests
+func startOperationSpan(ctx context.Context, resourceType string, operation string) (context.Context, trace.Span) {
+	return config.Tracer().Start(ctx, fmt.Sprintf("wiz %s %s", operation, resourceType),
+		trace.WithAttributes(
+			config.AttributeResourceType.String(resourceType),
+			config.AttributeOperation.String(operation),
+		),
+	)
+}
+
+// startRequestSpan func - generate a request id and return a context that adds the request details to every log line
+func startRequestSpan(ctx context.Context, resourceType string, operation string) (context.Context, *requestSpan) {
+	id := uuid.NewString()
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	ctx = tflog.SetField(ctx, "request_id", id)
+	ctx = tflog.SetField(ctx, "resource_type", resourceType)
+	ctx = tflog.SetField(ctx, "operation", operation)
+	ctx, retries := config.WithRetryCounter(ctx)
+	ctx, span := config.Tracer().Start(ctx, "wiz api request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			config.AttributeResourceType.String(resourceType),
+			config.AttributeOperation.String(operation),
+			attribute.String("wiz.request_id", id),
+		),
+	)
+
+	tflog.Debug(ctx, "Wiz api request started")
+	return ctx, &requestSpan{
+		start:   time.Now(),
+		retries: retries,
+		span:    span,
+	}
+}
+
+// end func - log the outcome and timing of the request and end its span
+func (s *requestSpan) end(ctx context.Context, diags diag.Diagnostics) {
+	fields := map[string]interface{}{
+		"duration_ms":   time.Since(s.start).Milliseconds(),
+		"http_status":   s.status,
+		"retry_attempt": int(s.retries.Load()),
+		"success":       !diags.HasError(),
+		"cache_hit":     s.cacheHit,
+	}
+	tflog.Info(ctx, "Wiz api request completed", fields)
+
+	s.span.SetAttributes(
+		attribute.Int("http.response.status_code", s.status),
+		attribute.Int("http.request.resend_count", int(s.retries.Load())),
+		attribute.Bool("wiz.cache_hit", s.cacheHit),
+	)
+	config.EndSpan(ctx, s.span, diags)
+}
+
+// requestID func - return the id of the request traced by ctx, a new id is generated for requests that are not traced
+func requestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return uuid.NewString()
+}
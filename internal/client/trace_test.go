@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// completedRequests returns the fields of the request completed log entries
+func completedRequests(t *testing.T, output *bytes.Buffer) []map[string]interface{} {
+	entries, err := tflogtest.MultilineJSONDecode(output)
+	assert.NoError(t, err)
+
+	var completed []map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Wiz api request completed" {
+			completed = append(completed, entry)
+		}
+	}
+	return completed
+}
+
+func TestRequestTracing(t *testing.T) {
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(RequestIDHeader))
+		w.Write([]byte(`{"data": {"field": "value"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	data := &struct {
+		Field string `json:"field"`
+	}{}
+	for i := 0; i < 2; i++ {
+		diags := ProcessRequest(ctx, newPagedProviderConf(server.URL), nil, data, "query", "things", "read")
+		assert.Empty(t, diags)
+	}
+
+	// every request has its own id
+	assert.Len(t, requestIDs, 2)
+	assert.NotEmpty(t, requestIDs[0])
+	assert.NotEqual(t, requestIDs[0], requestIDs[1])
+
+	// the completed request is logged with structured fields
+	completed := completedRequests(t, &output)
+	assert.Len(t, completed, 2)
+	for i, entry := range completed {
+		assert.Equal(t, requestIDs[i], entry["request_id"])
+		assert.Equal(t, "things", entry["resource_type"])
+		assert.Equal(t, "read", entry["operation"])
+		assert.Equal(t, float64(http.StatusOK), entry["http_status"])
+		assert.Equal(t, float64(0), entry["retry_attempt"])
+		assert.Equal(t, true, entry["success"])
+		assert.Contains(t, entry, "duration_ms")
+	}
+}
+
+func TestRequestTracingPages(t *testing.T) {
+	server, _ := newPagedServer(t, 2)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	for _, diags := range Pages[testPage](ctx, newPagedProviderConf(server.URL), nil, "query", "things", PageOptions{}) {
+		assert.Empty(t, diags)
+	}
+
+	completed := completedRequests(t, &output)
+	assert.Len(t, completed, 2)
+	assert.Equal(t, float64(1), completed[0]["page"])
+	assert.Equal(t, float64(2), completed[1]["page"])
+}
+
+func TestRequestTracingHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	diags := ProcessRequest(ctx, newPagedProviderConf(server.URL), nil, &struct{}{}, "query", "things", "create")
+	assert.True(t, diags.HasError())
+
+	completed := completedRequests(t, &output)
+	assert.Len(t, completed, 1)
+	assert.Equal(t, float64(http.StatusBadRequest), completed[0]["http_status"])
+	assert.Equal(t, false, completed[0]["success"])
+}
+
+// newSpanRecorder installs a tracer provider recording the spans ended during the test
+func newSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// spanAttribute returns the value of a span attribute
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestRequestSpans(t *testing.T) {
+	recorder := newSpanRecorder(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"data": {"field": "value"}}`))
+	}))
+	defer server.Close()
+
+	diags := ProcessRequest(context.Background(), newPagedProviderConf(server.URL), nil, &struct{}{}, "query", "things", "update")
+	assert.Empty(t, diags)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		request, operation := spans[0], spans[1]
+		assert.Equal(t, "wiz update things", operation.Name())
+		assert.Equal(t, "things", spanAttribute(operation, config.AttributeResourceType).AsString())
+		assert.Equal(t, "update", spanAttribute(operation, config.AttributeOperation).AsString())
+		assert.Equal(t, config.OutcomeSuccess, spanAttribute(operation, config.AttributeOutcome).AsString())
+
+		// the request span is a child of the operation span and its context is sent to the api
+		assert.Equal(t, "wiz api request", request.Name())
+		assert.Equal(t, operation.SpanContext().SpanID(), request.Parent().SpanID())
+		assert.Equal(t, int64(http.StatusOK), spanAttribute(request, "http.response.status_code").AsInt64())
+		assert.Contains(t, traceparent, request.SpanContext().SpanID().String())
+	}
+}
+
+func TestPagedRequestSpans(t *testing.T) {
+	recorder := newSpanRecorder(t)
+	server, _ := newPagedServer(t, 3)
+
+	diags := Paginate(context.Background(), newPagedProviderConf(server.URL), nil, "query", "things", PageOptions{}, func(page *testPage) (bool, diag.Diagnostics) {
+		return true, nil
+	})
+	assert.Empty(t, diags)
+
+	// a single operation span covers the request span of every page
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 4) {
+		operation := spans[3]
+		assert.Equal(t, "wiz read things", operation.Name())
+		for _, request := range spans[:3] {
+			assert.Equal(t, operation.SpanContext().SpanID(), request.Parent().SpanID())
+		}
+	}
+}
+
+func TestRequestSpanError(t *testing.T) {
+	recorder := newSpanRecorder(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors": [{"message": "not found", "extensions": {"code": "NOT_FOUND"}}]}`))
+	}))
+	defer server.Close()
+
+	diags := ProcessRequest(context.Background(), newPagedProviderConf(server.URL), nil, &struct{}{}, "query", "things", "read")
+	assert.True(t, diags.HasError())
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		operation := spans[1]
+		assert.Equal(t, config.OutcomeError, spanAttribute(operation, config.AttributeOutcome).AsString())
+		assert.Equal(t, codes.Error, operation.Status().Code)
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// cacheMaxEntries bounds the memory held by the read cache, the entries closest to expiry are evicted first
+const cacheMaxEntries = 1000
+
+// ResponseCache holds the responses of read queries for the lifetime of a provider instance
+// Entries are keyed on the query text and canonicalised variables, and expire after the ttl
+// Every mutation invalidates the whole cache, as it may change the objects returned by the reads of any resource type,
+// e.g. a control association changes the reads of controls
+type ResponseCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	generation uint64
+	now        func() time.Time
+}
+
+// cacheEntry struct
+type cacheEntry struct {
+	generation uint64
+	body       []byte
+	expiry     time.Time
+}
+
+// NewResponseCache returns an empty cache whose entries live for ttl
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		ttl:        ttl,
+		maxEntries: cacheMaxEntries,
+		entries:    map[string]cacheEntry{},
+		now:        time.Now,
+	}
+}
+
+// CacheKey returns the key of an encoded graphql request, requests that differ only in the order of their variables share a key
+func CacheKey(request []byte) (string, error) {
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(request))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return "", err
+	}
+	// maps are encoded with sorted keys
+	canonical, err := json.Marshal(decoded)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Get returns a copy of the cached response for key, if it has not expired or been invalidated
+func (c *ResponseCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.live(entry, c.now()) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return bytes.Clone(entry.body), true
+}
+
+// Generation returns the current generation of the cache, to be passed to Put with the response of a read sent afterwards
+func (c *ResponseCache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Put stores the response of a read query sent at generation
+// A read sent before the last invalidation may return the objects as they were before a mutation, so it is not stored
+// Expired and invalidated entries are dropped on insert, and the entry closest to expiry is evicted when the cache is full
+func (c *ResponseCache) Put(key string, generation uint64, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	now := c.now()
+	for k, entry := range c.entries {
+		if !c.live(entry, now) {
+			delete(c.entries, k)
+		}
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.evictOldest()
+	}
+
+	c.entries[key] = cacheEntry{
+		generation: generation,
+		body:       bytes.Clone(body),
+		expiry:     now.Add(c.ttl),
+	}
+}
+
+// live reports whether an entry can be served, the caller must hold the lock
+func (c *ResponseCache) live(entry cacheEntry, now time.Time) bool {
+	return entry.generation == c.generation && now.Before(entry.expiry)
+}
+
+// evictOldest drops the entry closest to expiry, the caller must hold the lock
+func (c *ResponseCache) evictOldest() {
+	var oldest string
+	var oldestExpiry time.Time
+	for key, entry := range c.entries {
+		if oldest == "" || entry.expiry.Before(oldestExpiry) {
+			oldest = key
+			oldestExpiry = entry.expiry
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// Invalidate drops every cached response and starts a new generation, so reads in flight are not stored
+func (c *ResponseCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = map[string]cacheEntry{}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheKey(t *testing.T) {
+	a, err := CacheKey([]byte(`{"query": "query", "variables": {"first": 500, "filterBy": {"search": ["a"], "projectId": "p"}}}`))
+	assert.NoError(t, err)
+	b, err := CacheKey([]byte(`{"variables": {"filterBy": {"projectId": "p", "search": ["a"]}, "first": 500}, "query": "query"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := CacheKey([]byte(`{"query": "query", "variables": {"first": 501, "filterBy": {"search": ["a"], "projectId": "p"}}}`))
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	_, err = CacheKey([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestResponseCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewResponseCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("users-key", cache.Generation(), []byte("users"))
+	cache.Put("project-key", cache.Generation(), []byte("project"))
+
+	body, ok := cache.Get("users-key")
+	assert.True(t, ok)
+	assert.Equal(t, "users", string(body))
+
+	// the cached body cannot be modified by the caller
+	body[0] = 'x'
+	body, _ = cache.Get("users-key")
+	assert.Equal(t, "users", string(body))
+
+	// entries expire after the ttl
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("users-key")
+	assert.False(t, ok)
+}
+
+func TestResponseCacheInvalidate(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+
+	// a mutation drops the entries of every resource type
+	cache.Put("control-key", cache.Generation(), []byte("control"))
+	cache.Put("project-key", cache.Generation(), []byte("project"))
+	cache.Invalidate()
+	_, ok := cache.Get("control-key")
+	assert.False(t, ok)
+	_, ok = cache.Get("project-key")
+	assert.False(t, ok)
+
+	// a read sent before the mutation does not store the objects as they were before it
+	generation := cache.Generation()
+	cache.Invalidate()
+	cache.Put("control-key", generation, []byte("stale"))
+	_, ok = cache.Get("control-key")
+	assert.False(t, ok)
+
+	// a read sent afterwards does
+	cache.Put("control-key", cache.Generation(), []byte("control"))
+	body, ok := cache.Get("control-key")
+	assert.True(t, ok)
+	assert.Equal(t, "control", string(body))
+}
+
+func TestResponseCacheBounded(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewResponseCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.maxEntries = 2
+
+	// expired entries are dropped on insert, even if they are never read again
+	cache.Put("expired-key", 0, []byte("expired"))
+	now = now.Add(time.Minute)
+	cache.Put("first-key", 0, []byte("first"))
+	assert.Len(t, cache.entries, 1)
+	assert.NotContains(t, cache.entries, "expired-key")
+
+	// a full cache evicts the entry closest to expiry
+	now = now.Add(time.Second)
+	cache.Put("second-key", 0, []byte("second"))
+	now = now.Add(time.Second)
+	cache.Put("third-key", 0, []byte("third"))
+	assert.Len(t, cache.entries, 2)
+	_, ok := cache.Get("first-key")
+	assert.False(t, ok)
+	_, ok = cache.Get("second-key")
+	assert.True(t, ok)
+	_, ok = cache.Get("third-key")
+	assert.True(t, ok)
+
+	// replacing an entry does not evict another one
+	cache.Put("third-key", 0, []byte("third"))
+	assert.Len(t, cache.entries, 2)
+	_, ok = cache.Get("second-key")
+	assert.True(t, ok)
+}
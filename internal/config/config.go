@@ -3,46 +3,86 @@ package config
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Settings holds all the information necessary to configure the provider
 type Settings struct {
 	WizURL                 string
+	WizDataCenter          string
 	WizAuthURL             string
 	WizAuthGrantType       string
 	WizAuthClientID        string
 	WizAuthClientSecret    string
 	WizAuthAudience        string
+	WizAuthToken           string
+	WizAuthTokenFile       string
 	Proxy                  bool
 	ProxyServer            string
+	ProxyUsername          string
+	ProxyPassword          string
+	NoProxy                []string
 	CAChain                string
+	CAChainFile            string
+	CAChainMode            string
+	ClientCertificate      string
+	ClientKey              string
 	HTTPClientRetryMax     int
 	HTTPClientRetryWaitMin int
 	HTTPClientRetryWaitMax int
+	MaxRequestsPerSecond   float64
+	MaxConcurrentRequests  int
+	LogRedactKeys          []string
+	ReadCacheTTL           int
+	PersistedQueries       bool
+	HTTPClient             HTTPClientSettings
+
+	// Warnings found while reading the settings, reported when the provider is configured
+	Warnings diag.Diagnostics
 }
 
 // ProviderConf holds structures that are useful to the provider at runtime
+// Token and TokenType are only used when TokenSource is nil
 type ProviderConf struct {
-	Settings   *Settings
-	TokenType  string
-	Token      string
-	HTTPClient *http.Client
-	UserAgent  string
+	Settings    *Settings
+	TokenType   string
+	Token       string
+	TokenSource *TokenSource
+	HTTPClient  *http.Client
+	UserAgent   string
+	Redactor    *Redactor
+	Cache       *ResponseCache
+
+	// Scopes are the scopes granted to the session token when the provider was configured, nil when they are not known
+	Scopes []string
+
+	// PersistedQueriesUnsupported is set once the api reports that it does not support persisted queries
+	PersistedQueriesUnsupported atomic.Bool
+}
+
+// GetToken returns the token type and session token to use for a request
+func (p *ProviderConf) GetToken(ctx context.Context) (string, string, diag.Diagnostics) {
+	if p.TokenSource == nil {
+		return p.TokenType, p.Token, nil
+	}
+	return p.TokenSource.Token(ctx)
 }
 
 // AuthorizationResponse contains the reponse from the authorization api
@@ -58,32 +98,66 @@ func GetHTTPClient(ctx context.Context, settings *Settings) *http.Client {
 	tflog.Info(ctx, "GetHTTPClient called...")
 
 	// load trusted certificate authorities in a certpool
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM([]byte(settings.CAChain))
+	caCertPool, err := CertPool(settings)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Unable to load the trusted certificate authorities: %s", err))
+	}
 
 	// configure the transport with trusted certificate authorities
 	tlsConfig := &tls.Config{
 		RootCAs: caCertPool,
 	}
-	transport := &http.Transport{
-		TLSClientConfig:   tlsConfig,
-		MaxConnsPerHost:   10,
-		DisableKeepAlives: false,
+
+	// present the client certificate, a configuration that became invalid since configure fails the handshake
+	cert, certErr := ClientCertificate(settings)
+	switch {
+	case certErr != nil:
+		tflog.Error(ctx, fmt.Sprintf("Unable to load the client certificate: %s", certErr))
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return nil, certErr
+		}
+	case cert != nil:
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
+	// configure the connection pool and timeouts
+	tuning := settings.HTTPClient
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: seconds(tuning.KeepAliveInterval),
 	}
-	if settings.Proxy {
-		proxyURL, _ := url.Parse(settings.ProxyServer)
-		transport.Proxy = http.ProxyURL(proxyURL)
+	transport := &http.Transport{
+		TLSClientConfig:       tlsConfig,
+		DialContext:           dialer.DialContext,
+		MaxConnsPerHost:       tuning.MaxConnsPerHost,
+		MaxIdleConns:          tuning.MaxIdleConns,
+		MaxIdleConnsPerHost:   tuning.MaxIdleConnsPerHost,
+		IdleConnTimeout:       seconds(tuning.IdleConnTimeout),
+		TLSHandshakeTimeout:   seconds(tuning.TLSHandshakeTimeout),
+		ResponseHeaderTimeout: seconds(tuning.ResponseHeaderTimeout),
+		DisableKeepAlives:     tuning.DisableKeepAlives,
+		ForceAttemptHTTP2:     tuning.HTTP2,
 	}
+	transport.Proxy = ProxyFunc(settings)
 
 	// configure the client
 	client := retryablehttp.NewClient()
 
-	// override with the trusted certificate authorities
+	// override with the trusted certificate authorities, limiting the rate of requests when configured
 	client.HTTPClient.Transport = transport
+	if settings.MaxRequestsPerSecond > 0 || settings.MaxConcurrentRequests > 0 {
+		client.HTTPClient.Transport = NewRateLimitedTransport(transport, settings.MaxRequestsPerSecond, settings.MaxConcurrentRequests)
+	}
+	client.HTTPClient.Timeout = seconds(tuning.Timeout)
 	client.RetryWaitMin = time.Duration(settings.HTTPClientRetryWaitMin) * 1000000000
 	client.RetryWaitMax = time.Duration(settings.HTTPClientRetryWaitMax) * 1000000000
 	client.RetryMax = settings.HTTPClientRetryMax
 
+	// retry throttled requests, including rate limit errors reported inside a GraphQL response, with jittered backoff
+	client.CheckRetry = RetryPolicy
+	client.Backoff = Backoff
+	client.ErrorHandler = retryErrorHandler
+	client.RequestLogHook = requestLogHook(settings.HTTPClientRetryMax)
+
 	return client.StandardClient()
 }
 
@@ -91,14 +165,47 @@ func GetHTTPClient(ctx context.Context, settings *Settings) *http.Client {
 func NewProviderConf(ctx context.Context, settings *Settings, userAgent string) (*ProviderConf, diag.Diagnostics) {
 	tflog.Info(ctx, "NewProviderConf called...")
 
-	tokenType, token, diags := GetSessionToken(ctx, settings)
+	// warn about settings resolved with a fallback, transport settings exceeding the recommended values and certificate authorities about to expire
+	diags := append(diag.Diagnostics{}, settings.Warnings...)
+	diags = append(diags, settings.HTTPClient.Validate()...)
+	diags = append(diags, CAChainWarnings(settings, time.Now())...)
+
+	tflog.Debug(ctx, fmt.Sprintf("Authentication method: %s", AuthMethod(settings)))
+
+	// fetch the initial session token, the token source refreshes it as required
+	tokenSource := NewTokenSource(settings)
+	tokenType, token, tokenDiags := tokenSource.Token(ctx)
+	diags = append(diags, tokenDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// check the scopes granted to the token, resources needing missing scopes are reported at plan time
+	scopes := tokenSource.Scopes()
+	if scopes == nil {
+		tflog.Debug(ctx, "The scopes of the session token are not known")
+	}
+	diags = append(diags, ScopeDiagnostics(scopes)...)
+
+	// retry requests rejected with an expired or revoked token once with a new token
+	httpClient := GetHTTPClient(ctx, settings)
+	httpClient.Transport = &AuthTransport{
+		Base:   httpClient.Transport,
+		Source: tokenSource,
+	}
 
 	pcfg := &ProviderConf{
-		Settings:   settings,
-		Token:      token,
-		TokenType:  tokenType,
-		HTTPClient: GetHTTPClient(ctx, settings),
-		UserAgent:  userAgent,
+		Settings:    settings,
+		Token:       token,
+		TokenType:   tokenType,
+		TokenSource: tokenSource,
+		HTTPClient:  httpClient,
+		UserAgent:   userAgent,
+		Redactor:    NewRedactor(settings.LogRedactKeys),
+		Scopes:      scopes,
+	}
+	if settings.ReadCacheTTL > 0 {
+		pcfg.Cache = NewResponseCache(time.Duration(settings.ReadCacheTTL) * time.Second)
 	}
 	return pcfg, diags
 }
@@ -107,17 +214,82 @@ func NewProviderConf(ctx context.Context, settings *Settings, userAgent string)
 func NewConfig(d *schema.ResourceData) (*Settings, error) {
 	cfg := &Settings{
 		WizURL:                 d.Get("wiz_url").(string),
+		WizDataCenter:          d.Get("wiz_data_center").(string),
 		WizAuthURL:             d.Get("wiz_auth_url").(string),
 		WizAuthGrantType:       d.Get("wiz_auth_grant_type").(string),
 		WizAuthClientID:        d.Get("wiz_auth_client_id").(string),
 		WizAuthClientSecret:    d.Get("wiz_auth_client_secret").(string),
 		WizAuthAudience:        d.Get("wiz_auth_audience").(string),
+		WizAuthToken:           d.Get("wiz_auth_token").(string),
+		WizAuthTokenFile:       d.Get("wiz_auth_token_file").(string),
 		Proxy:                  d.Get("proxy").(bool),
 		ProxyServer:            d.Get("proxy_server").(string),
+		ProxyUsername:          d.Get("proxy_username").(string),
+		ProxyPassword:          d.Get("proxy_password").(string),
 		CAChain:                d.Get("ca_chain").(string),
+		CAChainFile:            d.Get("ca_chain_file").(string),
+		CAChainMode:            d.Get("ca_chain_mode").(string),
+		ClientCertificate:      d.Get("client_certificate").(string),
+		ClientKey:              d.Get("client_key").(string),
 		HTTPClientRetryMax:     d.Get("http_client_retry_max").(int),
 		HTTPClientRetryWaitMin: d.Get("http_client_retry_wait_min").(int),
 		HTTPClientRetryWaitMax: d.Get("http_client_retry_wait_max").(int),
+		MaxRequestsPerSecond:   d.Get("max_requests_per_second").(float64),
+		MaxConcurrentRequests:  d.Get("max_concurrent_requests").(int),
+		ReadCacheTTL:           d.Get("read_cache_ttl").(int),
+		PersistedQueries:       d.Get("persisted_queries").(bool),
+		HTTPClient:             DefaultHTTPClientSettings(),
+	}
+	for _, key := range d.Get("log_redact_keys").([]interface{}) {
+		cfg.LogRedactKeys = append(cfg.LogRedactKeys, key.(string))
+	}
+	for _, host := range d.Get("no_proxy").([]interface{}) {
+		cfg.NoProxy = append(cfg.NoProxy, host.(string))
+	}
+	for _, block := range d.Get("http_client").([]interface{}) {
+		if block == nil {
+			continue
+		}
+		tuning := block.(map[string]interface{})
+		cfg.HTTPClient = HTTPClientSettings{
+			MaxConnsPerHost:       tuning["max_conns_per_host"].(int),
+			MaxIdleConns:          tuning["max_idle_conns"].(int),
+			MaxIdleConnsPerHost:   tuning["max_idle_conns_per_host"].(int),
+			IdleConnTimeout:       tuning["idle_conn_timeout"].(int),
+			TLSHandshakeTimeout:   tuning["tls_handshake_timeout"].(int),
+			ResponseHeaderTimeout: tuning["response_header_timeout"].(int),
+			Timeout:               tuning["timeout"].(int),
+			HTTP2:                 tuning["http2"].(bool),
+			DisableKeepAlives:     !tuning["keep_alive"].(bool),
+			KeepAliveInterval:     tuning["keep_alive_interval"].(int),
+		}
+	}
+
+	// derive the endpoints and audience that are not set
+	warnings, err := ResolveEndpoints(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Warnings = append(cfg.Warnings, warnings...)
+
+	// client credentials are only required without a pre-issued token
+	if AuthMethod(cfg) == AuthMethodClientCredentials && (cfg.WizAuthClientID == "" || cfg.WizAuthClientSecret == "") {
+		return nil, fmt.Errorf("wiz_auth_client_id and wiz_auth_client_secret are required unless wiz_auth_token or wiz_auth_token_file is set")
+	}
+
+	// report malformed proxy urls before any request is sent
+	if err := ValidateProxy(cfg); err != nil {
+		return nil, err
+	}
+
+	// report an unreadable ca chain file or system trust store
+	if _, err := CertPool(cfg); err != nil {
+		return nil, err
+	}
+
+	// report an unreadable or mismatched client certificate and key
+	if _, err := ClientCertificate(cfg); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -127,7 +299,27 @@ func NewConfig(d *schema.ResourceData) (*Settings, error) {
 func GetSessionToken(ctx context.Context, settings *Settings) (string, string, diag.Diagnostics) {
 	tflog.Info(ctx, "GetSessionToken called...")
 
-	var diags diag.Diagnostics
+	responseBody, diags := issueSessionToken(ctx, settings, time.Now())
+	if responseBody == nil {
+		return "", "", diags
+	}
+
+	return responseBody.TokenType, responseBody.AccessToken, diags
+}
+
+// requestSessionToken calls the authentication api and returns the full response, including the token lifetime
+func requestSessionToken(ctx context.Context, settings *Settings) (authResponse *AuthorizationResponse, diags diag.Diagnostics) {
+	ctx, span := Tracer().Start(ctx, "wiz token", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		AttributeResourceType.String("session_token"),
+		AttributeOperation.String("token"),
+	))
+	defer func() {
+		spanDiags := diags
+		if authResponse == nil && !diags.HasError() {
+			spanDiags = append(spanDiags, diag.Errorf("no session token returned")...)
+		}
+		EndSpan(ctx, span, spanDiags)
+	}()
 
 	// get an http client
 	httpclient := GetHTTPClient(ctx, settings)
@@ -142,48 +334,60 @@ func GetSessionToken(ctx context.Context, settings *Settings) (string, string, d
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	request.Header.Add("Content-Length", strconv.Itoa(len(data.Encode())))
 	if err != nil {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.FromErr(err)...)
 	}
 
-	// log the request
+	// log the request, masking the client secret
+	redactor := NewRedactor(settings.LogRedactKeys)
 	reqDump, err := httputil.DumpRequestOut(request, true)
 	if err != nil {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.FromErr(err)...)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("authentication request: %s", reqDump))
+	tflog.Debug(ctx, fmt.Sprintf("authentication request: %s", redactor.RedactDump(reqDump)))
 
 	// call the api
 	resp, err := httpclient.Do(request)
 	if err != nil {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.FromErr(err)...)
 	}
 
 	// log the response
 	respDump, err := httputil.DumpResponse(resp, true)
 	if err != nil {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.FromErr(err)...)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("auth response: %s", respDump))
+	tflog.Debug(ctx, fmt.Sprintf("auth response: %s", redactor.RedactDump(respDump)))
 
 	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
 	// parse the response
 	rbody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.FromErr(err)...)
 	}
 
 	// validate successful response
 	responseBody := &AuthorizationResponse{}
 	if resp.StatusCode != http.StatusOK {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to authenticate with Wiz",
+			Detail: fmt.Sprintf(
+				"The authentication endpoint %s returned HTTP %d: %s. Check wiz_auth_client_id, wiz_auth_client_secret, wiz_auth_url and wiz_auth_audience.",
+				settings.WizAuthURL, resp.StatusCode, redactor.Redact(strings.TrimSpace(string(rbody))),
+			),
+		})
 	}
 	err = json.Unmarshal(rbody, &responseBody)
 	if err != nil {
-		return "", "", append(diags, diag.FromErr(err)...)
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+	if responseBody.AccessToken == "" {
+		return nil, append(diags, diag.Errorf("the authentication endpoint %s did not return an access token", settings.WizAuthURL)...)
 	}
 
 	// return
-	return responseBody.TokenType, responseBody.AccessToken, diags
+	return responseBody, diags
 }
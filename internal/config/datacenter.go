@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// authentication providers used by Wiz tenants
+const (
+	AuthProviderCognito = "cognito"
+	AuthProviderAuth0   = "auth0"
+)
+
+// AuthProvider struct - the token endpoint and audience of an authentication provider
+type AuthProvider struct {
+	URL      string
+	Audience string
+}
+
+// AuthProviders holds the authentication providers by name, Cognito is used unless the endpoint or audience says otherwise
+var AuthProviders = map[string]AuthProvider{
+	AuthProviderCognito: {
+		URL:      "https://auth.app.wiz.io/oauth/token",
+		Audience: "wiz-api",
+	},
+	AuthProviderAuth0: {
+		URL:      "https://auth.wiz.io/oauth/token",
+		Audience: "beyond-api",
+	},
+}
+
+var (
+	// DataCenterPattern matches the name of a Wiz data center, e.g. us17 or eu1
+	DataCenterPattern = regexp.MustCompile(`^[a-z]{2,4}[0-9]{1,3}$`)
+	// dataCenterHost matches the host of a data center api endpoint
+	dataCenterHost = regexp.MustCompile(`^api\.([a-z0-9-]+)\.app\.wiz\.io$`)
+)
+
+// DataCenterURL returns the api endpoint of a data center
+func DataCenterURL(dataCenter string) string {
+	return fmt.Sprintf("https://api.%s.app.wiz.io/graphql", dataCenter)
+}
+
+// detectAuthProvider func - return the provider of an authentication endpoint, empty for endpoints that are not known
+func detectAuthProvider(authURL string) string {
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		return ""
+	}
+	for name, provider := range AuthProviders {
+		known, _ := url.Parse(provider.URL)
+		if strings.EqualFold(parsed.Hostname(), known.Hostname()) {
+			return name
+		}
+	}
+	return ""
+}
+
+// audienceProvider func - return the provider of an audience, empty for audiences that are not known
+func audienceProvider(audience string) string {
+	for name, provider := range AuthProviders {
+		if provider.Audience == audience {
+			return name
+		}
+	}
+	return ""
+}
+
+// ResolveEndpoints fills in the api endpoint from the data center, and the authentication endpoint and audience from each other
+// An endpoint or audience that is not known falls back to Cognito, as before the audience was derived, with a warning
+// Settings that contradict each other, such as a Cognito endpoint with the Auth0 audience, are reported as errors
+func ResolveEndpoints(settings *Settings) (diag.Diagnostics, error) {
+	var warnings diag.Diagnostics
+	if settings.WizDataCenter != "" {
+		if !DataCenterPattern.MatchString(settings.WizDataCenter) {
+			return nil, fmt.Errorf("wiz_data_center %q is not a valid data center, expected a name such as us17 or eu1", settings.WizDataCenter)
+		}
+		if settings.WizURL == "" {
+			settings.WizURL = DataCenterURL(settings.WizDataCenter)
+		} else if parsed, err := url.Parse(settings.WizURL); err == nil {
+			match := dataCenterHost.FindStringSubmatch(strings.ToLower(parsed.Hostname()))
+			if match != nil && match[1] != settings.WizDataCenter {
+				return nil, fmt.Errorf("wiz_url %s belongs to data center %s, not to wiz_data_center %s, remove one of them", settings.WizURL, match[1], settings.WizDataCenter)
+			}
+		}
+	}
+	if settings.WizURL == "" {
+		return nil, fmt.Errorf("either wiz_url or wiz_data_center must be set")
+	}
+
+	cognito := AuthProviders[AuthProviderCognito]
+	switch {
+	case settings.WizAuthURL == "" && settings.WizAuthAudience == "":
+		settings.WizAuthURL = cognito.URL
+		settings.WizAuthAudience = cognito.Audience
+	case settings.WizAuthAudience == "":
+		provider := detectAuthProvider(settings.WizAuthURL)
+		if provider == "" {
+			provider = AuthProviderCognito
+			warnings = append(warnings, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "wiz_auth_audience is not set",
+				Detail:   fmt.Sprintf("The authentication provider of wiz_auth_url %s is not known, the Cognito audience '%s' is used. Set wiz_auth_audience to 'beyond-api' if it is Auth0.", settings.WizAuthURL, cognito.Audience),
+			})
+		}
+		settings.WizAuthAudience = AuthProviders[provider].Audience
+	case settings.WizAuthURL == "":
+		provider := audienceProvider(settings.WizAuthAudience)
+		if provider == "" {
+			provider = AuthProviderCognito
+			warnings = append(warnings, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "wiz_auth_url is not set",
+				Detail:   fmt.Sprintf("wiz_auth_audience %q is not known, the Cognito endpoint %s is used. Set wiz_auth_url to the authentication endpoint.", settings.WizAuthAudience, cognito.URL),
+			})
+		}
+		settings.WizAuthURL = AuthProviders[provider].URL
+	default:
+		provider := detectAuthProvider(settings.WizAuthURL)
+		if provider != "" && AuthProviders[provider].Audience != settings.WizAuthAudience {
+			return nil, fmt.Errorf(
+				"wiz_auth_url %s belongs to %s, which requires the wiz_auth_audience %q, but %q is set. Remove wiz_auth_audience to derive it from the endpoint",
+				settings.WizAuthURL, provider, AuthProviders[provider].Audience, settings.WizAuthAudience,
+			)
+		}
+	}
+	return warnings, nil
+}
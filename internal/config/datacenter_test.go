@@ -0,0 +1,111 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		expected Settings
+		warning  string
+		err      string
+	}{
+		{
+			name:     "data center",
+			settings: Settings{WizDataCenter: "us17"},
+			expected: Settings{WizDataCenter: "us17", WizURL: "https://api.us17.app.wiz.io/graphql", WizAuthURL: "https://auth.app.wiz.io/oauth/token", WizAuthAudience: "wiz-api"},
+		},
+		{
+			name:     "url and matching data center",
+			settings: Settings{WizDataCenter: "eu1", WizURL: "https://api.eu1.app.wiz.io/graphql"},
+			expected: Settings{WizDataCenter: "eu1", WizURL: "https://api.eu1.app.wiz.io/graphql", WizAuthURL: "https://auth.app.wiz.io/oauth/token", WizAuthAudience: "wiz-api"},
+		},
+		{
+			name:     "auth0 endpoint",
+			settings: Settings{WizURL: "https://api.us1.app.wiz.io/graphql", WizAuthURL: "https://auth.wiz.io/oauth/token"},
+			expected: Settings{WizURL: "https://api.us1.app.wiz.io/graphql", WizAuthURL: "https://auth.wiz.io/oauth/token", WizAuthAudience: "beyond-api"},
+		},
+		{
+			name:     "auth0 audience",
+			settings: Settings{WizURL: "https://api.us1.app.wiz.io/graphql", WizAuthAudience: "beyond-api"},
+			expected: Settings{WizURL: "https://api.us1.app.wiz.io/graphql", WizAuthURL: "https://auth.wiz.io/oauth/token", WizAuthAudience: "beyond-api"},
+		},
+		{
+			name:     "custom endpoint and audience",
+			settings: Settings{WizURL: "https://wiz.example.com/graphql", WizAuthURL: "https://auth.example.com/oauth/token", WizAuthAudience: "custom"},
+			expected: Settings{WizURL: "https://wiz.example.com/graphql", WizAuthURL: "https://auth.example.com/oauth/token", WizAuthAudience: "custom"},
+		},
+		{
+			name:     "no endpoint",
+			settings: Settings{},
+			err:      "either wiz_url or wiz_data_center must be set",
+		},
+		{
+			name:     "invalid data center",
+			settings: Settings{WizDataCenter: "https://api.us17.app.wiz.io"},
+			err:      "is not a valid data center",
+		},
+		{
+			name:     "url of another data center",
+			settings: Settings{WizDataCenter: "us17", WizURL: "https://api.eu1.app.wiz.io/graphql"},
+			err:      "belongs to data center eu1",
+		},
+		{
+			name:     "cognito endpoint with the auth0 audience",
+			settings: Settings{WizDataCenter: "us17", WizAuthURL: "https://auth.app.wiz.io/oauth/token", WizAuthAudience: "beyond-api"},
+			err:      `belongs to cognito, which requires the wiz_auth_audience "wiz-api"`,
+		},
+		{
+			name:     "auth0 endpoint with the cognito audience",
+			settings: Settings{WizDataCenter: "us17", WizAuthURL: "https://auth.wiz.io/oauth/token", WizAuthAudience: "wiz-api"},
+			err:      `belongs to auth0, which requires the wiz_auth_audience "beyond-api"`,
+		},
+		{
+			// the audience defaulted to wiz-api before it was derived from the endpoint
+			name:     "custom endpoint without audience",
+			settings: Settings{WizDataCenter: "us17", WizAuthURL: "https://auth.example.com/oauth/token"},
+			expected: Settings{WizDataCenter: "us17", WizURL: "https://api.us17.app.wiz.io/graphql", WizAuthURL: "https://auth.example.com/oauth/token", WizAuthAudience: "wiz-api"},
+			warning:  "wiz_auth_audience is not set",
+		},
+		{
+			name:     "gov endpoint without audience",
+			settings: Settings{WizURL: "https://api.us1.app.wiz.us/graphql", WizAuthURL: "https://auth.app.wiz.us/oauth/token"},
+			expected: Settings{WizURL: "https://api.us1.app.wiz.us/graphql", WizAuthURL: "https://auth.app.wiz.us/oauth/token", WizAuthAudience: "wiz-api"},
+			warning:  "wiz_auth_audience is not set",
+		},
+		{
+			// the endpoint defaulted to cognito before it was derived from the audience
+			name:     "custom audience without endpoint",
+			settings: Settings{WizURL: "https://api.us1.app.wiz.io/graphql", WizAuthAudience: "custom"},
+			expected: Settings{WizURL: "https://api.us1.app.wiz.io/graphql", WizAuthURL: "https://auth.app.wiz.io/oauth/token", WizAuthAudience: "custom"},
+			warning:  "wiz_auth_url is not set",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := test.settings
+			warnings, err := ResolveEndpoints(&settings)
+			if test.err != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, settings)
+			if test.warning == "" {
+				assert.Empty(t, warnings)
+				return
+			}
+			if assert.Len(t, warnings, 1) {
+				assert.Equal(t, diag.Warning, warnings[0].Severity)
+				assert.Equal(t, test.warning, warnings[0].Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// conservative provider defaults, not documented Wiz api limits; larger values are accepted but warned about during configure
+const (
+	// RecommendedMaxConnsPerHost is the number of connections to the api the provider recommends not to exceed
+	RecommendedMaxConnsPerHost = 10
+	// RecommendedIdleConnTimeout is the idle time, in seconds, after which a connection may be closed by a load balancer in front of the api
+	RecommendedIdleConnTimeout = 60
+	// RecommendedResponseHeaderTimeout is the time, in seconds, the provider recommends waiting for the response headers at most
+	// It is not applied by default, a timed out query would be sent again by the retrying client
+	RecommendedResponseHeaderTimeout = 120
+	// RecommendedRequestTimeout is the time, in seconds, the provider recommends waiting for a single response at most
+	RecommendedRequestTimeout = 300
+)
+
+// HTTPClientSettings holds the tuning options of the http transport
+// Timeouts are in seconds, 0 means no timeout. The zero value matches the transport used before the options were exposed
+type HTTPClientSettings struct {
+	MaxConnsPerHost       int
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	IdleConnTimeout       int
+	TLSHandshakeTimeout   int
+	ResponseHeaderTimeout int
+	Timeout               int
+	HTTP2                 bool
+	DisableKeepAlives     bool
+	KeepAliveInterval     int
+}
+
+// DefaultHTTPClientSettings returns the settings used when the http_client block is not configured
+func DefaultHTTPClientSettings() HTTPClientSettings {
+	return HTTPClientSettings{
+		MaxConnsPerHost:       10,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       55,
+		TLSHandshakeTimeout:   10,
+		ResponseHeaderTimeout: 0,
+		Timeout:               0,
+		HTTP2:                 false,
+		DisableKeepAlives:     false,
+		KeepAliveInterval:     30,
+	}
+}
+
+// seconds func - convert a number of seconds to a duration
+func seconds(s int) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// Validate returns warnings for the settings exceeding the recommended values
+func (s HTTPClientSettings) Validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	warn := func(summary string, detail string) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   detail,
+		})
+	}
+
+	if s.MaxConnsPerHost == 0 || s.MaxConnsPerHost > RecommendedMaxConnsPerHost {
+		warn(
+			"http_client.max_conns_per_host exceeds the recommended value",
+			fmt.Sprintf("More than %d connections to the Wiz api may be throttled, throttled requests are retried and slow down the run.", RecommendedMaxConnsPerHost),
+		)
+	}
+	if s.MaxIdleConnsPerHost > RecommendedMaxConnsPerHost {
+		warn(
+			"http_client.max_idle_conns_per_host exceeds the recommended value",
+			fmt.Sprintf("The provider recommends at most %d connections to the Wiz api, additional idle connections are unlikely to be reused.", RecommendedMaxConnsPerHost),
+		)
+	}
+	if !s.DisableKeepAlives && (s.IdleConnTimeout == 0 || s.IdleConnTimeout >= RecommendedIdleConnTimeout) {
+		warn(
+			"http_client.idle_conn_timeout exceeds the recommended value",
+			fmt.Sprintf("Connections idle for %d seconds may be closed on the server side, requests sent on a closed connection fail and are retried. Use a lower value.", RecommendedIdleConnTimeout),
+		)
+	}
+	if !s.DisableKeepAlives && s.KeepAliveInterval >= RecommendedIdleConnTimeout {
+		warn(
+			"http_client.keep_alive_interval exceeds the recommended value",
+			fmt.Sprintf("TCP keep-alive probes sent every %d seconds or more may not keep connections to the Wiz api open.", RecommendedIdleConnTimeout),
+		)
+	}
+	if s.ResponseHeaderTimeout > RecommendedResponseHeaderTimeout {
+		warn(
+			"http_client.response_header_timeout exceeds the recommended value",
+			fmt.Sprintf("The provider recommends waiting at most %d seconds for the response headers, longer timeouts leave a stalled request hanging.", RecommendedResponseHeaderTimeout),
+		)
+	}
+	if s.Timeout > RecommendedRequestTimeout {
+		warn(
+			"http_client.timeout exceeds the recommended value",
+			fmt.Sprintf("The provider recommends timeouts of at most %d seconds, longer timeouts leave a stalled request hanging.", RecommendedRequestTimeout),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPClientSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(s *HTTPClientSettings)
+		warnings int
+	}{
+		{
+			name:   "defaults",
+			update: func(s *HTTPClientSettings) {},
+		},
+		{
+			name:     "too many connections",
+			update:   func(s *HTTPClientSettings) { s.MaxConnsPerHost = 50 },
+			warnings: 1,
+		},
+		{
+			name:     "unlimited connections",
+			update:   func(s *HTTPClientSettings) { s.MaxConnsPerHost = 0 },
+			warnings: 1,
+		},
+		{
+			name:     "too many idle connections",
+			update:   func(s *HTTPClientSettings) { s.MaxIdleConnsPerHost = 20 },
+			warnings: 1,
+		},
+		{
+			name:     "idle connections kept open too long",
+			update:   func(s *HTTPClientSettings) { s.IdleConnTimeout = 90 },
+			warnings: 1,
+		},
+		{
+			name: "idle timeout without keep-alive",
+			update: func(s *HTTPClientSettings) {
+				s.IdleConnTimeout = 90
+				s.DisableKeepAlives = true
+			},
+		},
+		{
+			name:     "keep-alive probes too far apart",
+			update:   func(s *HTTPClientSettings) { s.KeepAliveInterval = 120 },
+			warnings: 1,
+		},
+		{
+			name:     "response header timeout longer than recommended",
+			update:   func(s *HTTPClientSettings) { s.ResponseHeaderTimeout = 180 },
+			warnings: 1,
+		},
+		{
+			name:     "timeout longer than the api",
+			update:   func(s *HTTPClientSettings) { s.Timeout = 600 },
+			warnings: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := DefaultHTTPClientSettings()
+			test.update(&settings)
+			diags := settings.Validate()
+			assert.Len(t, diags, test.warnings)
+			assert.False(t, diags.HasError())
+		})
+	}
+}
+
+func TestGetHTTPClientTuning(t *testing.T) {
+	settings := &Settings{HTTPClient: DefaultHTTPClientSettings()}
+	settings.HTTPClient.MaxConnsPerHost = 4
+	settings.HTTPClient.IdleConnTimeout = 30
+	settings.HTTPClient.Timeout = 45
+	settings.HTTPClient.HTTP2 = true
+
+	client := GetHTTPClient(context.Background(), settings)
+	retrying := client.Transport.(*retryablehttp.RoundTripper).Client
+	assert.Equal(t, 45*time.Second, retrying.HTTPClient.Timeout)
+
+	transport := retrying.HTTPClient.Transport.(*http.Transport)
+	assert.Equal(t, 4, transport.MaxConnsPerHost)
+	assert.Equal(t, 30*time.Second, transport.IdleConnTimeout)
+	// the response headers are awaited without a timeout by default, as before the setting was exposed
+	assert.Zero(t, transport.ResponseHeaderTimeout)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.False(t, transport.DisableKeepAlives)
+}
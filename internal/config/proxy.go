@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyConfig func - return the proxy configuration of the provider
+// With the proxy setting enabled the proxy server is used for every request, otherwise the proxy is read from the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. The no_proxy list is added to either configuration
+func proxyConfig(settings *Settings) (*httpproxy.Config, error) {
+	cfg := httpproxy.FromEnvironment()
+	if settings.Proxy {
+		if settings.ProxyServer == "" {
+			return nil, fmt.Errorf("proxy is enabled but no proxy_server is set")
+		}
+		cfg = &httpproxy.Config{
+			HTTPProxy:  settings.ProxyServer,
+			HTTPSProxy: settings.ProxyServer,
+		}
+	}
+	if len(settings.NoProxy) > 0 {
+		noProxy := settings.NoProxy
+		if cfg.NoProxy != "" {
+			noProxy = append([]string{cfg.NoProxy}, noProxy...)
+		}
+		cfg.NoProxy = strings.Join(noProxy, ",")
+	}
+
+	// the proxy urls are only parsed when a request is sent, validate them now to report malformed urls during configure
+	for _, proxy := range []string{cfg.HTTPProxy, cfg.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		if _, err := parseProxyURL(proxy); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// parseProxyURL func - parse a proxy url, addresses without a scheme are http proxies
+func parseProxyURL(proxy string) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy)
+	if (err != nil || proxyURL.Scheme == "" || proxyURL.Host == "") && !strings.Contains(proxy, "://") {
+		// addresses such as proxy.example.com:3128 have no scheme
+		proxyURL, err = url.Parse("http://" + proxy)
+	}
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url %q, expected http[s]://[host]:[port]", redactProxyURL(proxy))
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy url %q, unsupported scheme %q", redactProxyURL(proxy), proxyURL.Scheme)
+	}
+	return proxyURL, nil
+}
+
+// redactProxyURL func - mask the password of a proxy url reported in an error
+func redactProxyURL(proxy string) string {
+	if proxyURL, err := url.Parse(proxy); err == nil {
+		return proxyURL.Redacted()
+	}
+	return proxy
+}
+
+// ValidateProxy returns an error if the proxy configuration of the provider is invalid
+func ValidateProxy(settings *Settings) error {
+	_, err := proxyConfig(settings)
+	return err
+}
+
+// ProxyFunc returns the function selecting the proxy of a request, for use as http.Transport.Proxy
+// The proxy username and password are sent to the proxy using basic authentication
+// An invalid configuration fails every request instead of silently connecting without the proxy
+func ProxyFunc(settings *Settings) func(*http.Request) (*url.URL, error) {
+	cfg, err := proxyConfig(settings)
+	if err != nil {
+		return func(*http.Request) (*url.URL, error) {
+			return nil, err
+		}
+	}
+
+	proxyForURL := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := proxyForURL(req.URL)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+		if settings.ProxyUsername == "" {
+			return proxyURL, nil
+		}
+		// the proxy url is shared between requests, set the credentials on a copy
+		authenticated := *proxyURL
+		authenticated.User = url.UserPassword(settings.ProxyUsername, settings.ProxyPassword)
+		return &authenticated, nil
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedTransport limits the rate and concurrency of requests sent through the wrapped transport
+// A single instance is shared by every resource, so the limits apply to the provider as a whole
+// Each attempt made by the retrying client passes through the limiter, including retries
+type RateLimitedTransport struct {
+	Base    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// NewRateLimitedTransport wraps base with a token bucket allowing requestsPerSecond and at most maxConcurrent requests in flight
+// A value of 0 disables the corresponding limit
+func NewRateLimitedTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *RateLimitedTransport {
+	t := &RateLimitedTransport{Base: base}
+	if requestsPerSecond > 0 {
+		burst := int(math.Ceil(requestsPerSecond))
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// wait for a concurrency slot, it is released once the response body is closed
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-t.slots }) }
+	}
+
+	// wait for a token from the bucket
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnCloseBody frees the concurrency slot held by a request when its response body is closed
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces every masked value
+const redactedValue = "***"
+
+// DefaultRedactKeys are the json keys and form fields whose values are always masked in debug logs
+// Keys are matched case insensitively against the complete key name
+var DefaultRedactKeys = []string{
+	"access_token",
+	"accessToken",
+	"apiKey",
+	"api_key",
+	"clientSecret",
+	"client_secret",
+	"id_token",
+	"password",
+	"privateKey",
+	"private_key",
+	"refresh_token",
+	"refreshToken",
+	"secret",
+	"token",
+}
+
+// redactHeaders matches the value of headers carrying credentials, keeping the authorization scheme when present
+var redactHeaders = regexp.MustCompile(`(?im)^((?:authorization|proxy-authorization):[ \t]*(?:(?:basic|bearer|digest)[ \t]+)?|(?:cookie|set-cookie|x-api-key):[ \t]*)[^\r\n]+`)
+
+// defaultRedactor is used when the provider configuration has no redactor, e.g. when called before configuration
+var defaultRedactor = NewRedactor(nil)
+
+// Redactor masks credentials in http dumps and json documents before they are logged
+type Redactor struct {
+	json *regexp.Regexp
+	form *regexp.Regexp
+}
+
+// NewRedactor returns a redactor masking the default keys and any additional keys
+func NewRedactor(additionalKeys []string) *Redactor {
+	keys := make([]string, 0, len(DefaultRedactKeys)+len(additionalKeys))
+	for _, key := range append(append([]string{}, DefaultRedactKeys...), additionalKeys...) {
+		key = strings.TrimSpace(key)
+		if key != "" {
+			keys = append(keys, regexp.QuoteMeta(key))
+		}
+	}
+	pattern := strings.Join(keys, "|")
+
+	return &Redactor{
+		// "key": "value", including escaped quotes within the value
+		json: regexp.MustCompile(`(?i)("(?:` + pattern + `)"\s*:\s*")(?:[^"\\]|\\.)*(")`),
+		// key=value in a form encoded body
+		form: regexp.MustCompile(`(?i)((?:^|[?&\s])(?:` + pattern + `)=)[^&\s]*`),
+	}
+}
+
+// Redact returns s with credential headers, form fields and json values masked
+func (r *Redactor) Redact(s string) string {
+	if r == nil {
+		r = defaultRedactor
+	}
+	s = redactHeaders.ReplaceAllString(s, "${1}"+redactedValue)
+	s = r.json.ReplaceAllString(s, "${1}"+redactedValue+"${2}")
+	return r.form.ReplaceAllString(s, "${1}"+redactedValue)
+}
+
+// RedactDump returns an http request or response dump with credentials masked
+func (r *Redactor) RedactDump(dump []byte) string {
+	return r.Redact(string(dump))
+}
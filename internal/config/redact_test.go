@@ -0,0 +1,55 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactDumpRequest(t *testing.T) {
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	data.Set("client_id", "clientid")
+	data.Set("client_secret", "supersecret")
+	request, _ := http.NewRequest("POST", "https://auth.app.wiz.io/oauth/token?token=querysecret", strings.NewReader(data.Encode()))
+	request.Header.Set("Authorization", "Bearer tokenvalue")
+	request.Header.Set("Proxy-Authorization", "Basic cHJveHk6cGFzcw==")
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	dump, err := httputil.DumpRequestOut(request, true)
+	assert.NoError(t, err)
+	redacted := NewRedactor(nil).RedactDump(dump)
+
+	for _, secret := range []string{"supersecret", "tokenvalue", "cHJveHk6cGFzcw==", "querysecret"} {
+		assert.NotContains(t, redacted, secret)
+	}
+	assert.Contains(t, redacted, "Authorization: Bearer ***")
+	assert.Contains(t, redacted, "client_secret=***")
+	assert.Contains(t, redacted, "client_id=clientid")
+	assert.Contains(t, redacted, "grant_type=client_credentials")
+}
+
+func TestRedactJSON(t *testing.T) {
+	body := `{"data": {"createServiceAccount": {"serviceAccount": {"clientId": "id", "clientSecret": "sec\"ret", "name": "sa"}}}, "jiraPassword": "jira", "Password" : "pass"}`
+
+	// default keys are masked, including escaped quotes within the value
+	redacted := NewRedactor(nil).Redact(body)
+	assert.Contains(t, redacted, `"clientSecret": "***"`)
+	assert.Contains(t, redacted, `"Password" : "***"`)
+	assert.Contains(t, redacted, `"clientId": "id"`)
+	assert.Contains(t, redacted, `"name": "sa"`)
+	assert.Contains(t, redacted, `"jiraPassword": "jira"`)
+
+	// additional keys are masked alongside the defaults
+	redacted = NewRedactor([]string{"jirapassword", " "}).Redact(body)
+	assert.Contains(t, redacted, `"jiraPassword": "***"`)
+	assert.Contains(t, redacted, `"clientSecret": "***"`)
+
+	// a nil redactor masks the default keys
+	var r *Redactor
+	assert.Contains(t, r.Redact(body), `"Password" : "***"`)
+}
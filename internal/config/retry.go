@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
+)
+
+// maxInspectedResponseSize limits how much of a successful response is buffered when looking for throttling errors
+const maxInspectedResponseSize = 10 << 20
+
+// graphQLErrorCodes is the minimal shape of a GraphQL response needed to read the error codes
+type graphQLErrorCodes struct {
+	Errors []struct {
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// RetryPolicy decides whether a request is retried
+// In addition to the retryablehttp default policy (connection errors, HTTP 429 and 5xx), it retries HTTP 200 responses
+// whose GraphQL errors list reports that the request was throttled
+func RetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	// do not retry once the operation is cancelled
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err == nil && resp != nil && resp.StatusCode == http.StatusOK {
+		rateLimited, inspectErr := isGraphQLRateLimited(resp)
+		if inspectErr != nil {
+			return false, inspectErr
+		}
+		if rateLimited {
+			tflog.Debug(ctx, "GraphQL response reports the request was rate limited, retrying")
+			return true, nil
+		}
+		return false, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// isGraphQLRateLimited reports whether the errors list of a GraphQL response contains a rate limit error code
+// The body is buffered and restored so the caller can still read it
+func isGraphQLRateLimited(resp *http.Response) (bool, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return false, nil
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "json") {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxInspectedResponseSize))
+	if err != nil {
+		return false, err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+
+	// skip the decode entirely when no errors are reported
+	if !bytes.Contains(body, []byte(`"errors"`)) {
+		return false, nil
+	}
+
+	payload := &graphQLErrorCodes{}
+	if json.Unmarshal(body, payload) != nil {
+		return false, nil
+	}
+	for _, gqlError := range payload.Errors {
+		for _, code := range wiz.GraphQLErrorCodeRateLimited {
+			if strings.EqualFold(gqlError.Extensions.Code, code) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Backoff computes how long to wait before the next attempt
+// A Retry-After header is honored when present, up to max so a far away date does not stall the run,
+// otherwise the wait grows exponentially from min to max with jitter so that concurrent resources do not retry in lockstep
+func Backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if sleep, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if sleep > max {
+				return max
+			}
+			return sleep
+		}
+	}
+
+	mult := math.Pow(2, float64(attemptNum)) * float64(min)
+	sleep := time.Duration(mult)
+	if float64(sleep) != mult || sleep > max {
+		sleep = max
+	}
+	if sleep <= 0 {
+		return 0
+	}
+
+	// equal jitter, wait between half and all of the exponential value
+	half := sleep / 2
+	return half + time.Duration(rand.Int63n(int64(sleep-half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		// the largest wait that does not overflow a duration
+		if seconds > math.MaxInt64/int64(time.Second) {
+			return time.Duration(math.MaxInt64), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		sleep := date.Sub(now)
+		if sleep < 0 {
+			sleep = 0
+		}
+		return sleep, true
+	}
+	return 0, false
+}
+
+// requestLogHook logs every attempt after the first along with the retry count
+func requestLogHook(retryMax int) retryablehttp.RequestLogHook {
+	return func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+		if attempt == 0 {
+			return
+		}
+		if counter, ok := req.Context().Value(retryCounterKey{}).(*atomic.Int32); ok {
+			counter.Store(int32(attempt))
+		}
+		tflog.Debug(req.Context(), fmt.Sprintf("Retrying %s %s (retry %d of %d)", req.Method, req.URL.Redacted(), attempt, retryMax), map[string]interface{}{
+			"retry_attempt": attempt,
+		})
+	}
+}
+
+// retryCounterKey is the context key of the retry counter of a request
+type retryCounterKey struct{}
+
+// WithRetryCounter returns a context that records the number of retries made by the http client for a request sent with it
+func WithRetryCounter(ctx context.Context) (context.Context, *atomic.Int32) {
+	counter := &atomic.Int32{}
+	return context.WithValue(ctx, retryCounterKey{}, counter), counter
+}
+
+// retryErrorHandler returns the last response once the retries are exhausted, so the caller can report the api error
+// instead of a generic "giving up" message
+func retryErrorHandler(resp *http.Response, err error, numTries int) (*http.Response, error) {
+	if resp == nil {
+		return nil, err
+	}
+	// a round tripper must not return both a response and an error
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if resp.Request != nil {
+		tflog.Debug(resp.Request.Context(), fmt.Sprintf("Giving up after %d attempt(s), last response: HTTP %d", numTries, resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyGraphQLRateLimit(t *testing.T) {
+	newResponse := func(body string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+
+	// throttling reported in the GraphQL errors list is retried and the body remains readable
+	throttled := `{"data": null, "errors": [{"message": "slow down", "extensions": {"code": "RATE_LIMIT_EXCEEDED"}}]}`
+	resp := newResponse(throttled)
+	retry, err := RetryPolicy(context.Background(), resp, nil)
+	assert.NoError(t, err)
+	assert.True(t, retry)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, throttled, string(body))
+
+	// other GraphQL errors are final
+	retry, err = RetryPolicy(context.Background(), newResponse(`{"errors": [{"extensions": {"code": "NOT_FOUND"}}]}`), nil)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	// successful responses are final
+	retry, err = RetryPolicy(context.Background(), newResponse(`{"data": {"field": "value"}}`), nil)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	// http 429 is retried
+	retry, _ = RetryPolicy(context.Background(), &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}, nil)
+	assert.True(t, retry)
+
+	// nothing is retried once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	retry, err = RetryPolicy(ctx, newResponse(throttled), nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, retry)
+}
+
+func TestBackoff(t *testing.T) {
+	// Retry-After in seconds is honored
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"7"}}}
+	assert.Equal(t, 7*time.Second, Backoff(time.Second, 30*time.Second, 0, resp))
+
+	// Retry-After as an HTTP date is honored
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sleep, ok := parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, sleep)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+
+	// an oversized Retry-After is capped at max
+	resp = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"86400"}}}
+	assert.Equal(t, 30*time.Second, Backoff(time.Second, 30*time.Second, 0, resp))
+	resp.Header.Set("Retry-After", time.Now().Add(6*time.Hour).Format(http.TimeFormat))
+	assert.Equal(t, 30*time.Second, Backoff(time.Second, 30*time.Second, 0, resp))
+	resp.Header.Set("Retry-After", "99999999999999999")
+	assert.Equal(t, 30*time.Second, Backoff(time.Second, 30*time.Second, 0, resp))
+
+	// without Retry-After the wait is jittered exponential and capped at max
+	for attempt := 0; attempt < 10; attempt++ {
+		expected := time.Second << attempt
+		if expected > 30*time.Second {
+			expected = 30 * time.Second
+		}
+		sleep := Backoff(time.Second, 30*time.Second, attempt, nil)
+		assert.GreaterOrEqual(t, sleep, expected/2)
+		assert.LessOrEqual(t, sleep, expected)
+	}
+}
+
+func TestGetHTTPClientRetriesGraphQLRateLimit(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "0")
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Write([]byte(`{"data": null, "errors": [{"extensions": {"code": "RATE_LIMIT_EXCEEDED"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"field": "value"}}`))
+	}))
+	defer server.Close()
+
+	client := GetHTTPClient(context.Background(), &Settings{HTTPClientRetryMax: 5})
+	ctx, retries := WithRetryCounter(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "POST", server.URL, bytes.NewBufferString(`{"query": "query"}`))
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, `{"data": {"field": "value"}}`, string(body))
+
+	// the retries are recorded on the request context
+	assert.Equal(t, int32(2), retries.Load())
+}
+
+func TestGetHTTPClientReturnsLastResponseWhenRetriesExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := GetHTTPClient(context.Background(), &Settings{HTTPClientRetryMax: 1})
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
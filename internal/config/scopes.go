@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal"
+)
+
+// TokenScopes returns the scopes granted to a session token, read from the authorization response or the claims of the token
+// nil means the scopes are not known
+func TokenScopes(authResponse *AuthorizationResponse) []string {
+	if authResponse.Scope != "" {
+		return strings.Fields(authResponse.Scope)
+	}
+	claims, err := DecodeTokenClaims(authResponse.AccessToken)
+	if err != nil {
+		return nil
+	}
+	switch {
+	case claims.Scope != "":
+		return strings.Fields(claims.Scope)
+	case claims.Permissions != nil:
+		return claims.Permissions
+	}
+	return nil
+}
+
+// HasScope reports whether the granted scopes allow scope, e.g. create:controls is allowed by create:all, write:controls and write:all
+func HasScope(granted []string, scope string) bool {
+	action, area, found := strings.Cut(scope, ":")
+	if !found {
+		return slices.Contains(granted, scope)
+	}
+	allowedBy := []string{scope, action + ":all"}
+	switch action {
+	case "create", "update", "delete":
+		allowedBy = append(allowedBy, "write:"+area, "write:all")
+	}
+	for _, allowed := range allowedBy {
+		if slices.Contains(granted, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingScopes returns the required scopes that are not granted, none when the granted scopes are not known
+func MissingScopes(granted []string, required []string) []string {
+	if granted == nil {
+		return nil
+	}
+	var missing []string
+	for _, scope := range required {
+		if !HasScope(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// ScopeDiagnostics returns warnings for the granted scopes that are not service account scopes known to the provider
+// The provider relies on the scopes to warn about resources the token cannot manage
+func ScopeDiagnostics(granted []string) diag.Diagnostics {
+	if granted == nil {
+		return nil
+	}
+	if len(granted) == 0 {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Session token has no scopes",
+				Detail:   "The service account used by the provider was not granted any scope, every api request will be rejected.",
+			},
+		}
+	}
+
+	var unknown []string
+	for _, scope := range granted {
+		if !slices.Contains(internal.ServiceAccountScopes, scope) {
+			unknown = append(unknown, scope)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Session token has unknown scopes",
+			Detail:   fmt.Sprintf("The session token was granted scopes that are not known to the provider: %s.", strings.Join(unknown, ", ")),
+		},
+	}
+}
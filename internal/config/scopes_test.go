@@ -0,0 +1,58 @@
+package config
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenScopes(t *testing.T) {
+	claims := func(payload string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	tests := []struct {
+		name     string
+		response AuthorizationResponse
+		expected []string
+	}{
+		{name: "response", response: AuthorizationResponse{Scope: "read:all create:controls", AccessToken: claims(`{"scope": "read:users"}`)}, expected: []string{"read:all", "create:controls"}},
+		{name: "scope claim", response: AuthorizationResponse{AccessToken: claims(`{"scope": "read:users read:projects"}`)}, expected: []string{"read:users", "read:projects"}},
+		{name: "permissions claim", response: AuthorizationResponse{AccessToken: claims(`{"permissions": ["read:users"]}`)}, expected: []string{"read:users"}},
+		{name: "no scopes granted", response: AuthorizationResponse{AccessToken: claims(`{"permissions": []}`)}, expected: []string{}},
+		{name: "unknown", response: AuthorizationResponse{AccessToken: "opaque"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TokenScopes(&test.response))
+		})
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	granted := []string{"read:all", "write:controls", "create:all", "admin:projects"}
+
+	assert.True(t, HasScope(granted, "read:users"))
+	assert.True(t, HasScope(granted, "update:controls"))
+	assert.True(t, HasScope(granted, "create:automation_rules"))
+	assert.True(t, HasScope(granted, "admin:projects"))
+	assert.False(t, HasScope(granted, "delete:automation_rules"))
+	assert.False(t, HasScope(granted, "admin:users"))
+
+	assert.Equal(t, []string{"delete:automation_rules"}, MissingScopes(granted, []string{"read:automation_rules", "delete:automation_rules"}))
+	assert.Empty(t, MissingScopes(nil, []string{"delete:automation_rules"}))
+}
+
+func TestScopeDiagnostics(t *testing.T) {
+	assert.Empty(t, ScopeDiagnostics(nil))
+	assert.Empty(t, ScopeDiagnostics([]string{"read:all", "create:controls"}))
+
+	diags := ScopeDiagnostics([]string{"read:all", "write:everything"})
+	if assert.Len(t, diags, 1) {
+		assert.False(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail, "write:everything")
+	}
+
+	assert.Len(t, ScopeDiagnostics([]string{}), 1)
+}
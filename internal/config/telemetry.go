@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope of the spans emitted by the provider
+const TracerName = "wiz.io/hashicorp/terraform-provider-wiz"
+
+// Span attribute keys
+const (
+	AttributeResourceType = attribute.Key("wiz.resource_type")
+	AttributeOperation    = attribute.Key("wiz.operation")
+	AttributeOutcome      = attribute.Key("wiz.outcome")
+)
+
+// Span outcomes
+const (
+	OutcomeSuccess   = "success"
+	OutcomeError     = "error"
+	OutcomeCancelled = "cancelled"
+)
+
+// Tracer returns the tracer used for provider spans, spans are dropped unless tracing has been set up with InitTelemetry
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// TelemetryEnabled reports whether an OTLP endpoint has been configured with the standard OTEL_* environment variables
+func TelemetryEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	if exporter := os.Getenv("OTEL_TRACES_EXPORTER"); exporter != "" && exporter != "otlp" {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// InitTelemetry sets up the global tracer provider to export spans over OTLP/HTTP when TelemetryEnabled
+// The exporter reads the remaining OTEL_* environment variables, e.g. headers, timeout and TLS settings
+// The returned function flushes pending spans and must be called before the provider exits
+func InitTelemetry(ctx context.Context, version string) (func(context.Context) error, error) {
+	if !TelemetryEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName("terraform-provider-wiz"),
+			semconv.ServiceVersion(version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// EndSpan records the outcome of the operation traced by span and ends it
+func EndSpan(ctx context.Context, span trace.Span, diags diag.Diagnostics) {
+	switch {
+	case ctx.Err() != nil:
+		span.SetAttributes(AttributeOutcome.String(OutcomeCancelled))
+		span.SetStatus(codes.Error, ctx.Err().Error())
+	case diags.HasError():
+		span.SetAttributes(AttributeOutcome.String(OutcomeError))
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				span.SetStatus(codes.Error, d.Summary)
+				break
+			}
+		}
+	default:
+		span.SetAttributes(AttributeOutcome.String(OutcomeSuccess))
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestCollector returns an OTLP/HTTP collector stand-in that records the spans it receives
+func newTestCollector(t *testing.T) (*httptest.Server, func() []*tracepb.Span) {
+	var mu sync.Mutex
+	var spans []*tracepb.Span
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		request := &collectortrace.ExportTraceServiceRequest{}
+		assert.NoError(t, proto.Unmarshal(body, request))
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				spans = append(spans, scopeSpans.Spans...)
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []*tracepb.Span {
+		mu.Lock()
+		defer mu.Unlock()
+		return spans
+	}
+}
+
+// spanAttributes returns the string attributes of a span
+func spanAttributes(span *tracepb.Span) map[string]string {
+	attributes := map[string]string{}
+	for _, kv := range span.Attributes {
+		attributes[kv.Key] = kv.Value.GetStringValue()
+	}
+	return attributes
+}
+
+func TestTelemetryEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_SDK_DISABLED", "")
+	assert.False(t, TelemetryEnabled())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://localhost:4318/v1/traces")
+	assert.True(t, TelemetryEnabled())
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	assert.False(t, TelemetryEnabled())
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	assert.False(t, TelemetryEnabled())
+}
+
+func TestInitTelemetryExportsTokenSpans(t *testing.T) {
+	collector, spans := newTestCollector(t)
+	authServer, _ := newTestAuthServer(t, 3600)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_SDK_DISABLED", "")
+
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx := context.Background()
+	shutdown, err := InitTelemetry(ctx, "test")
+	assert.NoError(t, err)
+
+	_, token, diags := GetSessionToken(ctx, &Settings{WizAuthURL: authServer.URL})
+	assert.Empty(t, diags)
+	assert.Equal(t, "token-1", token)
+
+	// shutting down flushes the pending spans
+	assert.NoError(t, shutdown(ctx))
+
+	exported := spans()
+	if assert.Len(t, exported, 1) {
+		assert.Equal(t, "wiz token", exported[0].Name)
+		assert.Equal(t, tracepb.Status_STATUS_CODE_OK, exported[0].Status.Code)
+		attributes := spanAttributes(exported[0])
+		assert.Equal(t, "session_token", attributes[string(AttributeResourceType)])
+		assert.Equal(t, "token", attributes[string(AttributeOperation)])
+		assert.Equal(t, OutcomeSuccess, attributes[string(AttributeOutcome)])
+	}
+}
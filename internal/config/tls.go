@@ -0,0 +1,158 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// ca chain modes
+const (
+	// CAChainModeReplace trusts only the configured certificate authorities
+	CAChainModeReplace = "replace"
+	// CAChainModeAppend trusts the system certificate authorities and the configured ones
+	CAChainModeAppend = "append"
+)
+
+// caExpiryWarning is how long before their expiry trusted certificate authorities are reported
+const caExpiryWarning = 30 * 24 * time.Hour
+
+// readPEM func - return a PEM value given inline or as the path of a file holding it
+func readPEM(name string, value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	content, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a PEM value nor a readable file: %w", name, err)
+	}
+	return content, nil
+}
+
+// ClientCertificate returns the client certificate presented to the api and the authentication endpoint, nil when none is configured
+// The certificate and key are given as PEM values or file paths, files are read on every call
+func ClientCertificate(settings *Settings) (*tls.Certificate, error) {
+	if settings.ClientCertificate == "" && settings.ClientKey == "" {
+		return nil, nil
+	}
+	if settings.ClientCertificate == "" || settings.ClientKey == "" {
+		return nil, fmt.Errorf("client_certificate and client_key must be set together")
+	}
+
+	certPEM, err := readPEM("client_certificate", settings.ClientCertificate)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := readPEM("client_key", settings.ClientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// check each value on its own first, so a mismatch is not confused with a malformed value
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("client_certificate does not contain a PEM encoded certificate")
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return nil, fmt.Errorf("client_certificate is not a valid certificate: %w", err)
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return nil, fmt.Errorf("client_key does not contain a PEM encoded private key")
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil && strings.Contains(err.Error(), "does not match") {
+		return nil, fmt.Errorf("client_key does not match client_certificate: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("client_key is not a valid private key: %w", err)
+	}
+	return &cert, nil
+}
+
+// caChain func - return the PEM of the configured certificate authorities, from ca_chain and ca_chain_file
+func caChain(settings *Settings) ([]byte, error) {
+	chain := []byte(settings.CAChain)
+	if settings.CAChainFile != "" {
+		content, err := os.ReadFile(settings.CAChainFile)
+		if err != nil {
+			return chain, fmt.Errorf("unable to read ca_chain_file: %w", err)
+		}
+		chain = append(append(chain, '\n'), content...)
+	}
+	return chain, nil
+}
+
+// CertPool returns the certificate authorities trusted when connecting to the api and the authentication endpoint
+// In append mode the configured certificate authorities are added to the system trust store
+// The pool is returned with the certificate authorities that could be loaded, along with any error
+func CertPool(settings *Settings) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if settings.CAChainMode == CAChainModeAppend {
+		system, err := x509.SystemCertPool()
+		if err != nil {
+			return pool, fmt.Errorf("unable to load the system certificate authorities: %w", err)
+		}
+		pool = system
+	}
+
+	chain, err := caChain(settings)
+	pool.AppendCertsFromPEM(chain)
+	return pool, err
+}
+
+// CAChainWarnings returns a warning listing the configured certificate authorities that expired or expire soon
+func CAChainWarnings(settings *Settings, now time.Time) diag.Diagnostics {
+	chain, err := caChain(settings)
+	if err != nil {
+		return nil
+	}
+
+	var expired, expiring []string
+	for block, rest := pem.Decode(chain); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		description := fmt.Sprintf("%s (expiry: %s)", cert.Subject, cert.NotAfter.Format(time.DateOnly))
+		switch {
+		case now.After(cert.NotAfter):
+			expired = append(expired, description)
+		case now.Add(caExpiryWarning).After(cert.NotAfter):
+			expiring = append(expiring, description)
+		}
+	}
+	if len(expired) == 0 && len(expiring) == 0 {
+		return nil
+	}
+
+	var detail strings.Builder
+	if len(expired) > 0 {
+		fmt.Fprintf(&detail, "Expired: %s. ", strings.Join(expired, ", "))
+	}
+	if len(expiring) > 0 {
+		fmt.Fprintf(&detail, "Expiring within %d days: %s. ", int(caExpiryWarning.Hours()/24), strings.Join(expiring, ", "))
+	}
+	if settings.CAChainMode == CAChainModeAppend {
+		detail.WriteString("The system certificate authorities are trusted as well.")
+	} else {
+		detail.WriteString("Connections to Wiz fail once the certificate authority used by Wiz is no longer trusted. Update ca_chain or set ca_chain_mode to append to trust the system certificate authorities as well.")
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Trusted certificate authorities expired or expire soon",
+			Detail:   detail.String(),
+		},
+	}
+}
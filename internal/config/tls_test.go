@@ -0,0 +1,195 @@
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCertificate returns a self-signed PEM certificate and its private key
+func newTestCertificate(t *testing.T, name string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestClientCertificate(t *testing.T) {
+	certPEM, keyPEM := newTestCertificate(t, "client", time.Now().Add(time.Hour))
+	_, otherKeyPEM := newTestCertificate(t, "other", time.Now().Add(time.Hour))
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(certFile, []byte(certPEM), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, []byte(keyPEM), 0600))
+
+	tests := []struct {
+		name        string
+		certificate string
+		key         string
+		err         string
+	}{
+		{name: "none"},
+		{name: "pem", certificate: certPEM, key: keyPEM},
+		{name: "files", certificate: certFile, key: keyFile},
+		{name: "mixed", certificate: certFile, key: keyPEM},
+		{name: "key missing", certificate: certPEM, err: "must be set together"},
+		{name: "file missing", certificate: filepath.Join(dir, "missing.crt"), key: keyPEM, err: "neither a PEM value nor a readable file"},
+		{name: "swapped", certificate: keyPEM, key: certPEM, err: "client_certificate does not contain a PEM encoded certificate"},
+		{name: "mismatch", certificate: certPEM, key: otherKeyPEM, err: "client_key does not match client_certificate"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cert, err := ClientCertificate(&Settings{ClientCertificate: test.certificate, ClientKey: test.key})
+			if test.err != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			if test.certificate == "" {
+				assert.Nil(t, cert)
+			} else {
+				assert.NotNil(t, cert)
+			}
+		})
+	}
+}
+
+// newMutualTLSServer returns a server requiring a client certificate signed by clientCA
+func newMutualTLSServer(t *testing.T, clientCA string, handler http.HandlerFunc) *httptest.Server {
+	pool := x509.NewCertPool()
+	assert.True(t, pool.AppendCertsFromPEM([]byte(clientCA)))
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMutualTLS(t *testing.T) {
+	certPEM, keyPEM := newTestCertificate(t, "client", time.Now().Add(time.Hour))
+	server := newMutualTLSServer(t, certPEM, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.Write([]byte(`{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`))
+	})
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	settings := &Settings{
+		WizURL:            server.URL,
+		WizAuthURL:        server.URL,
+		CAChain:           serverCA,
+		ClientCertificate: certPEM,
+		ClientKey:         keyPEM,
+	}
+
+	// the certificate is presented to the authentication endpoint
+	_, token, diags := GetSessionToken(context.Background(), settings)
+	assert.Empty(t, diags)
+	assert.Equal(t, "token", token)
+
+	// and to the api
+	resp, err := GetHTTPClient(context.Background(), settings).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// without it the handshake fails
+	settings.ClientCertificate, settings.ClientKey = "", ""
+	_, err = GetHTTPClient(context.Background(), settings).Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestCertPool(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte(serverCA), 0600))
+
+	tests := []struct {
+		name     string
+		settings *Settings
+		trusted  bool
+	}{
+		{name: "replace", settings: &Settings{CAChain: serverCA}, trusted: true},
+		{name: "replace without the ca", settings: &Settings{}, trusted: false},
+		{name: "append", settings: &Settings{CAChain: serverCA, CAChainMode: CAChainModeAppend}, trusted: true},
+		{name: "file", settings: &Settings{CAChainFile: caFile}, trusted: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := GetHTTPClient(context.Background(), test.settings).Get(server.URL)
+			if !test.trusted {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		})
+	}
+
+	_, err := CertPool(&Settings{CAChainFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+}
+
+func TestCAChainWarnings(t *testing.T) {
+	now := time.Now()
+	valid, _ := newTestCertificate(t, "valid", now.Add(365*24*time.Hour))
+	expiring, _ := newTestCertificate(t, "expiring", now.Add(7*24*time.Hour))
+	expired, _ := newTestCertificate(t, "expired", now.Add(-24*time.Hour))
+
+	assert.Empty(t, CAChainWarnings(&Settings{CAChain: valid}, now))
+
+	diags := CAChainWarnings(&Settings{CAChain: valid + expiring + expired}, now)
+	if assert.Len(t, diags, 1) {
+		assert.False(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail, "Expired: CN=expired")
+		assert.Contains(t, diags[0].Detail, "Expiring within 30 days: CN=expiring")
+		assert.NotContains(t, diags[0].Detail, "CN=valid")
+	}
+
+	// the certificate authorities of the ca chain file are checked as well
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte(expired), 0600))
+	assert.Len(t, CAChainWarnings(&Settings{CAChain: valid, CAChainFile: caFile}, now), 1)
+}
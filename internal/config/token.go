@@ -0,0 +1,267 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// tokenExpiryDelta is how long before its reported expiry a session token is considered stale and refreshed
+const tokenExpiryDelta = 60 * time.Second
+
+// authentication methods, in order of precedence
+const (
+	// AuthMethodToken uses the pre-issued token of wiz_auth_token
+	AuthMethodToken = "token"
+	// AuthMethodTokenFile uses the pre-issued token read from wiz_auth_token_file, the file is read again on every refresh
+	AuthMethodTokenFile = "token_file"
+	// AuthMethodClientCredentials requests session tokens from the authentication endpoint with the client id and secret
+	AuthMethodClientCredentials = "client_credentials"
+)
+
+// AuthMethod returns the authentication method of the provider
+// A token takes precedence over a token file, which takes precedence over client credentials
+func AuthMethod(settings *Settings) string {
+	switch {
+	case settings.WizAuthToken != "":
+		return AuthMethodToken
+	case settings.WizAuthTokenFile != "":
+		return AuthMethodTokenFile
+	default:
+		return AuthMethodClientCredentials
+	}
+}
+
+// TokenClaims struct - the claims of a session token read by the provider
+type TokenClaims struct {
+	Expiry      int64    `json:"exp"`
+	Scope       string   `json:"scope"`
+	Permissions []string `json:"permissions"`
+}
+
+// DecodeTokenClaims returns the claims of a JWT session token, the signature is not verified
+func DecodeTokenClaims(token string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("session token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the session token claims: %w", err)
+	}
+	claims := &TokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("unable to decode the session token claims: %w", err)
+	}
+	return claims, nil
+}
+
+// issueSessionToken func - return a session token obtained with the authentication method of the provider
+func issueSessionToken(ctx context.Context, settings *Settings, now time.Time) (*AuthorizationResponse, diag.Diagnostics) {
+	switch AuthMethod(settings) {
+	case AuthMethodToken:
+		return preIssuedToken(settings.WizAuthToken, "wiz_auth_token", now)
+	case AuthMethodTokenFile:
+		tflog.Debug(ctx, fmt.Sprintf("Reading the session token from %s", settings.WizAuthTokenFile))
+		content, err := os.ReadFile(settings.WizAuthTokenFile)
+		if err != nil {
+			return nil, diag.Errorf("unable to read wiz_auth_token_file: %s", err)
+		}
+		return preIssuedToken(strings.TrimSpace(string(content)), "wiz_auth_token_file", now)
+	default:
+		return requestSessionToken(ctx, settings)
+	}
+}
+
+// preIssuedToken func - wrap a token issued outside the provider
+// The lifetime of a JWT is read from its exp claim, other tokens are used until the api rejects them
+func preIssuedToken(token string, source string, now time.Time) (*AuthorizationResponse, diag.Diagnostics) {
+	if token == "" {
+		return nil, diag.Errorf("%s does not contain a session token", source)
+	}
+
+	authResponse := &AuthorizationResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+	}
+	claims, err := DecodeTokenClaims(token)
+	if err != nil || claims.Expiry == 0 {
+		return authResponse, nil
+	}
+	expiry := time.Unix(claims.Expiry, 0)
+	if !now.Before(expiry) {
+		return nil, diag.Errorf("the session token of %s expired at %s", source, expiry.UTC().Format(time.RFC3339))
+	}
+	authResponse.ExpiresIn = int(expiry.Sub(now).Seconds())
+	return authResponse, nil
+}
+
+// TokenSource holds the session token and refreshes it before it expires
+// It is safe for concurrent use; concurrent callers that find a stale token wait for a single refresh
+type TokenSource struct {
+	settings  *Settings
+	mu        sync.Mutex
+	tokenType string
+	token     string
+	scopes    []string
+	expiry    time.Time
+	now       func() time.Time
+}
+
+// NewTokenSource creates a token source that fetches session tokens using the provider settings
+func NewTokenSource(settings *Settings) *TokenSource {
+	return &TokenSource{
+		settings: settings,
+		now:      time.Now,
+	}
+}
+
+// Token returns the token type and a valid session token, fetching a new one if the current token is missing or about to expire
+func (ts *TokenSource) Token(ctx context.Context) (string, string, diag.Diagnostics) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.valid() {
+		return ts.tokenType, ts.token, nil
+	}
+
+	tflog.Debug(ctx, "Session token is missing or about to expire, requesting a new one")
+	authResponse, diags := issueSessionToken(ctx, ts.settings, ts.now())
+	if authResponse == nil || diags.HasError() {
+		return "", "", diags
+	}
+
+	ts.tokenType = authResponse.TokenType
+	ts.token = authResponse.AccessToken
+	ts.scopes = TokenScopes(authResponse)
+	// an expires_in of 0 means the lifetime is unknown, so the token is kept until the api rejects it
+	if authResponse.ExpiresIn > 0 {
+		ts.expiry = ts.now().Add(time.Duration(authResponse.ExpiresIn) * time.Second)
+	} else {
+		ts.expiry = time.Time{}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Session token expires in %d seconds", authResponse.ExpiresIn))
+
+	return ts.tokenType, ts.token, diags
+}
+
+// Scopes returns the scopes granted to the current token, nil when they are not known
+func (ts *TokenSource) Scopes() []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.scopes
+}
+
+// Invalidate discards the given token so the next call to Token fetches a new one
+// Tokens other than the current one are ignored, so a token rejected by several concurrent requests is only refreshed once
+func (ts *TokenSource) Invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == token {
+		ts.token = ""
+		ts.expiry = time.Time{}
+	}
+}
+
+// Refreshable reports whether a rejected token can be replaced, the token of wiz_auth_token is static
+func (ts *TokenSource) Refreshable() bool {
+	return AuthMethod(ts.settings) != AuthMethodToken
+}
+
+// RejectedTokenDiagnostics returns the error reported when the api rejects a pre-issued session token with http 401
+// It returns nil for client credentials, whose session tokens are requested by the provider
+func RejectedTokenDiagnostics(settings *Settings) diag.Diagnostics {
+	var source string
+	switch AuthMethod(settings) {
+	case AuthMethodToken:
+		source = "wiz_auth_token"
+	case AuthMethodTokenFile:
+		source = "wiz_auth_token_file"
+	default:
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "Session token rejected",
+		Detail:   fmt.Sprintf("The Wiz api rejected the session token of %s (HTTP 401). The provider cannot refresh a pre-issued token, provide a valid token or use client credentials.", source),
+	}}
+}
+
+// valid reports whether the current token can be used, the caller must hold the lock
+func (ts *TokenSource) valid() bool {
+	if ts.token == "" {
+		return false
+	}
+	if ts.expiry.IsZero() {
+		return true
+	}
+	return ts.now().Before(ts.expiry.Add(-tokenExpiryDelta))
+}
+
+// AuthTransport retries a request once with a new session token when the api rejects the current one with http 401
+// A static wiz_auth_token, or a token file that was not rotated, is not retried
+type AuthTransport struct {
+	Base   http.RoundTripper
+	Source *TokenSource
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.Source == nil {
+		return resp, err
+	}
+	// the request body can only be replayed if it can be recreated
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, err
+	}
+
+	ctx := req.Context()
+	// retrying with the same static token would be rejected again
+	if !t.Source.Refreshable() {
+		tflog.Debug(ctx, "Session token of wiz_auth_token was rejected (HTTP 401), it cannot be refreshed")
+		return resp, nil
+	}
+	tflog.Debug(ctx, "Session token was rejected (HTTP 401), refreshing the token and retrying the request once")
+
+	// invalidate the rejected token and get a new one
+	_, rejected, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+	t.Source.Invalidate(rejected)
+	tokenType, token, diags := t.Source.Token(ctx)
+	if diags.HasError() {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to refresh the session token: %+v", diags))
+		return resp, nil
+	}
+	// a token file that was not rotated yields the rejected token again
+	if token == rejected {
+		tflog.Debug(ctx, "Session token of wiz_auth_token_file was not rotated, not retrying the request")
+		return resp, nil
+	}
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, token))
+
+	// discard the rejected response so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return t.Base.RoundTrip(retry)
+}
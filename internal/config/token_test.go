@@ -0,0 +1,339 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestAuthServer returns an authentication server that issues numbered tokens and counts the requests it receives
+func newTestAuthServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&count, 1)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &count
+}
+
+func TestTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	server, count := newTestAuthServer(t, 3600)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := NewTokenSource(&Settings{WizAuthURL: server.URL})
+	ts.now = func() time.Time { return now }
+
+	tokenType, token, diags := ts.Token(context.Background())
+	assert.Empty(t, diags)
+	assert.Equal(t, "Bearer", tokenType)
+	assert.Equal(t, "token-1", token)
+
+	// the token is reused while it is valid
+	now = now.Add(30 * time.Minute)
+	_, token, _ = ts.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(count))
+
+	// the token is refreshed shortly before it expires
+	now = now.Add(30*time.Minute - tokenExpiryDelta/2)
+	_, token, _ = ts.Token(context.Background())
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(count))
+}
+
+func TestTokenSourceConcurrentRefresh(t *testing.T) {
+	server, count := newTestAuthServer(t, 3600)
+	ts := NewTokenSource(&Settings{WizAuthURL: server.URL})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, token, _ := ts.Token(context.Background())
+			assert.Equal(t, "token-1", token)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(count))
+
+	// invalidating a stale token does not discard the current one
+	ts.Invalidate("token-0")
+	_, token, _ := ts.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(count))
+}
+
+func TestAuthTransportRetriesOnceAfterUnauthorized(t *testing.T) {
+	authServer, _ := newTestAuthServer(t, 3600)
+	ts := NewTokenSource(&Settings{WizAuthURL: authServer.URL})
+
+	var apiCalls int32
+	var lastBody string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		b, _ := io.ReadAll(r.Body)
+		lastBody = string(b)
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	// use the first token, the api only accepts the second one
+	tokenType, token, _ := ts.Token(context.Background())
+	request, err := http.NewRequest("POST", apiServer.URL, bytes.NewBufferString("request body"))
+	assert.NoError(t, err)
+	request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, token))
+
+	client := &http.Client{Transport: &AuthTransport{Base: http.DefaultTransport, Source: ts}}
+	resp, err := client.Do(request)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&apiCalls))
+	assert.Equal(t, "request body", lastBody)
+}
+
+func TestAuthTransportDoesNotRetryTwice(t *testing.T) {
+	authServer, _ := newTestAuthServer(t, 3600)
+	ts := NewTokenSource(&Settings{WizAuthURL: authServer.URL})
+
+	var apiCalls int32
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer apiServer.Close()
+
+	request, err := http.NewRequest("POST", apiServer.URL, nil)
+	assert.NoError(t, err)
+
+	client := &http.Client{Transport: &AuthTransport{Base: http.DefaultTransport, Source: ts}}
+	resp, err := client.Do(request)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&apiCalls))
+}
+
+// testJWT returns an unsigned JWT named name expiring at expiry
+func testJWT(name string, expiry time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg": "none"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub": %q, "exp": %d}`, name, expiry.Unix())))
+	return header + "." + claims + ".signature"
+}
+
+func TestAuthMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *Settings
+		expected string
+	}{
+		{name: "client credentials", settings: &Settings{WizAuthClientID: "id", WizAuthClientSecret: "secret"}, expected: AuthMethodClientCredentials},
+		{name: "token file", settings: &Settings{WizAuthClientID: "id", WizAuthClientSecret: "secret", WizAuthTokenFile: "token"}, expected: AuthMethodTokenFile},
+		{name: "token", settings: &Settings{WizAuthClientID: "id", WizAuthClientSecret: "secret", WizAuthTokenFile: "token", WizAuthToken: "token"}, expected: AuthMethodToken},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, AuthMethod(test.settings))
+		})
+	}
+}
+
+func TestTokenSourcePreIssuedToken(t *testing.T) {
+	authServer, count := newTestAuthServer(t, 3600)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+
+	// the token takes precedence over the token file and the client credentials
+	ts := NewTokenSource(&Settings{WizAuthURL: authServer.URL, WizAuthToken: "static-token", WizAuthTokenFile: tokenFile})
+	tokenType, token, diags := ts.Token(context.Background())
+	assert.Empty(t, diags)
+	assert.Equal(t, "Bearer", tokenType)
+	assert.Equal(t, "static-token", token)
+
+	// the token file takes precedence over the client credentials
+	ts = NewTokenSource(&Settings{WizAuthURL: authServer.URL, WizAuthTokenFile: tokenFile})
+	_, token, diags = ts.Token(context.Background())
+	assert.Empty(t, diags)
+	assert.Equal(t, "file-token", token)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(count))
+}
+
+func TestTokenSourceTokenFileRefresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	first := testJWT("first", now.Add(time.Hour))
+	assert.NoError(t, os.WriteFile(tokenFile, []byte(first), 0600))
+
+	ts := NewTokenSource(&Settings{WizAuthTokenFile: tokenFile})
+	ts.now = func() time.Time { return now }
+	_, token, diags := ts.Token(context.Background())
+	assert.Empty(t, diags)
+	assert.Equal(t, first, token)
+
+	// the token is kept until it is about to expire
+	second := testJWT("second", now.Add(2*time.Hour))
+	assert.NoError(t, os.WriteFile(tokenFile, []byte(second), 0600))
+	_, token, _ = ts.Token(context.Background())
+	assert.Equal(t, first, token)
+
+	// then the file is read again
+	now = now.Add(time.Hour - tokenExpiryDelta/2)
+	_, token, _ = ts.Token(context.Background())
+	assert.Equal(t, second, token)
+
+	// a token that is no longer rotated is reported once it expired
+	now = now.Add(2 * time.Hour)
+	_, _, diags = ts.Token(context.Background())
+	assert.True(t, diags.HasError())
+
+	// as is a missing file
+	assert.NoError(t, os.Remove(tokenFile))
+	_, _, diags = ts.Token(context.Background())
+	assert.True(t, diags.HasError())
+}
+
+func TestAuthTransportRereadsTokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("revoked-token"), 0600))
+	ts := NewTokenSource(&Settings{WizAuthTokenFile: tokenFile})
+
+	var authorizations []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer rotated-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	tokenType, token, _ := ts.Token(context.Background())
+	request, err := http.NewRequest("POST", apiServer.URL, nil)
+	assert.NoError(t, err)
+	request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, token))
+
+	// the broker rotates the token, the rejected request is retried with the token read from the file
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("rotated-token"), 0600))
+	client := &http.Client{Transport: &AuthTransport{Base: http.DefaultTransport, Source: ts}}
+	resp, err := client.Do(request)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer revoked-token", "Bearer rotated-token"}, authorizations)
+}
+
+func TestAuthTransportDoesNotRetryStaticToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("revoked-token"), 0600))
+
+	tests := []struct {
+		name     string
+		settings *Settings
+	}{
+		{name: "token", settings: &Settings{WizAuthToken: "revoked-token"}},
+		{name: "token file not rotated", settings: &Settings{WizAuthTokenFile: tokenFile}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var apiCalls int32
+			apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&apiCalls, 1)
+				w.WriteHeader(http.StatusUnauthorized)
+			}))
+			defer apiServer.Close()
+
+			ts := NewTokenSource(test.settings)
+			tokenType, token, diags := ts.Token(context.Background())
+			assert.Empty(t, diags)
+			request, err := http.NewRequest("POST", apiServer.URL, nil)
+			assert.NoError(t, err)
+			request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, token))
+
+			// the same token would be rejected again, so the request is not retried
+			client := &http.Client{Transport: &AuthTransport{Base: http.DefaultTransport, Source: ts}}
+			resp, err := client.Do(request)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&apiCalls))
+		})
+	}
+}
+
+func TestRejectedTokenDiagnostics(t *testing.T) {
+	diags := RejectedTokenDiagnostics(&Settings{WizAuthToken: "token"})
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "Session token rejected", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "wiz_auth_token (HTTP 401)")
+	}
+
+	diags = RejectedTokenDiagnostics(&Settings{WizAuthTokenFile: "token"})
+	if assert.True(t, diags.HasError()) {
+		assert.Contains(t, diags[0].Detail, "wiz_auth_token_file (HTTP 401)")
+	}
+
+	// session tokens requested with client credentials are reported as any other http error
+	assert.Nil(t, RejectedTokenDiagnostics(&Settings{WizAuthClientID: "id", WizAuthClientSecret: "secret"}))
+}
+
+func TestSessionTokenRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "access_denied", "error_description": "Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	// the rejection is reported instead of an empty token
+	_, token, diags := GetSessionToken(context.Background(), &Settings{WizAuthURL: server.URL})
+	assert.Empty(t, token)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "Unable to authenticate with Wiz", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "HTTP 401")
+		assert.Contains(t, diags[0].Detail, "access_denied")
+	}
+
+	conf, diags := NewProviderConf(context.Background(), &Settings{WizAuthURL: server.URL}, "test")
+	assert.Nil(t, conf)
+	assert.True(t, diags.HasError())
+}
+
+func TestNewProviderConfScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token": "token", "token_type": "Bearer", "expires_in": 3600, "scope": "read:all create:unknown"}`))
+	}))
+	defer server.Close()
+
+	conf, diags := NewProviderConf(context.Background(), &Settings{WizAuthURL: server.URL, HTTPClient: DefaultHTTPClientSettings()}, "test")
+	assert.False(t, diags.HasError())
+	assert.Equal(t, []string{"read:all", "create:unknown"}, conf.Scopes)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Session token has unknown scopes", diags[0].Summary)
+	}
+}
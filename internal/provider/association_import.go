@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
+)
+
+// associationImportPrefix is the first segment of the import id of the association resources
+const associationImportPrefix = "association"
+
+// extractAssociationIDs func - return the control or rule ids and the security sub-category ids encoded in an import id
+func extractAssociationIDs(id string) ([]string, []string, error) {
+	parts := strings.Split(id, "|")
+	if len(parts) != 3 || parts[0] != associationImportPrefix {
+		return nil, nil, fmt.Errorf("invalid ID format %q, expected 'association|<id>,<id>|<security_sub_category_id>,<security_sub_category_id>'", id)
+	}
+
+	ids, err := splitAssociationIDs(parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	securitySubCategoryIDs, err := splitAssociationIDs(parts[2])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ids, securitySubCategoryIDs, nil
+}
+
+// splitAssociationIDs func - split a comma separated list of ids, rejecting empty and duplicate entries
+func splitAssociationIDs(list string) ([]string, error) {
+	ids := strings.Split(list, ",")
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, fmt.Errorf("invalid ID format, empty id in %q", list)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("invalid ID format, %s is listed twice in %q", id, list)
+		}
+		seen[id] = true
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// importAssociation func - return the importer of an association resource, idsKey names the attribute holding the control or rule ids
+// the association is read back from Wiz, the import fails unless every control or rule has every security sub-category
+func importAssociation(idsKey string, read schema.ReadContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		// schema for import id: association|<id>,<id>|<security_sub_category_id>,<security_sub_category_id>
+		ids, securitySubCategoryIDs, err := extractAssociationIDs(d.Id())
+		if err != nil {
+			return nil, err
+		}
+
+		err = d.Set(idsKey, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		err = d.Set("security_sub_category_ids", securitySubCategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		// details are not stored by Wiz, the default keeps a configuration without details free of changes
+		err = d.Set("details", "undefined")
+		if err != nil {
+			return nil, err
+		}
+
+		d.SetId(uuid.NewString())
+
+		diags := read(ctx, d, m)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to read the association: %s", diags[0].Summary)
+		}
+		if d.Id() == "" {
+			return nil, fmt.Errorf("association not found, none of %s are associated with all of the security sub-categories %s", strings.Join(ids, ", "), strings.Join(securitySubCategoryIDs, ", "))
+		}
+
+		missing := utils.Missing(utils.ConvertListToString(d.Get(idsKey).([]interface{})), ids)
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("association not found, the security sub-categories %s are not all associated with %s", strings.Join(securitySubCategoryIDs, ", "), strings.Join(missing, ", "))
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractAssociationIDs(t *testing.T) {
+	tests := []struct {
+		name                   string
+		id                     string
+		ids                    []string
+		securitySubCategoryIDs []string
+		err                    string
+	}{
+		{
+			name:                   "single",
+			id:                     "association|c1|s1",
+			ids:                    []string{"c1"},
+			securitySubCategoryIDs: []string{"s1"},
+		},
+		{
+			name:                   "multiple",
+			id:                     "association|c1,c2|s1, s2",
+			ids:                    []string{"c1", "c2"},
+			securitySubCategoryIDs: []string{"s1", "s2"},
+		},
+		{name: "wrong prefix", id: "link|c1|s1", err: "invalid ID format"},
+		{name: "missing segment", id: "association|c1", err: "invalid ID format"},
+		{name: "empty id", id: "association|c1,|s1", err: "empty id"},
+		{name: "duplicate id", id: "association|c1|s1,s1", err: "s1 is listed twice"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ids, securitySubCategoryIDs, err := extractAssociationIDs(test.id)
+			if test.err != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.ids, ids)
+			assert.Equal(t, test.securitySubCategoryIDs, securitySubCategoryIDs)
+		})
+	}
+}
+
+func TestImportAssociation(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"control_ids":           resourceWizControlAssociations(),
+		"cloud_config_rule_ids": resourceWizCloudConfigRuleAssociations(),
+		"host_config_rule_ids":  resourceWizHostConfigRuleAssociations(),
+	}
+	for idsKey, resource := range resources {
+		t.Run(idsKey, func(t *testing.T) {
+			// associated mimics a read where only the listed ids have every security sub-category
+			read := func(associated ...string) schema.ReadContextFunc {
+				return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+					return diag.FromErr(d.Set(idsKey, associated))
+				}
+			}
+
+			d := resource.TestResourceData()
+			d.SetId("association|c1,c2|s1,s2")
+			imported, err := importAssociation(idsKey, read("c1", "c2"))(context.Background(), d, nil)
+			if assert.NoError(t, err) && assert.Len(t, imported, 1) {
+				assert.Equal(t, []interface{}{"c1", "c2"}, imported[0].Get(idsKey))
+				assert.Equal(t, []interface{}{"s1", "s2"}, imported[0].Get("security_sub_category_ids"))
+				assert.Equal(t, "undefined", imported[0].Get("details"))
+				assert.NotEqual(t, "association|c1,c2|s1,s2", imported[0].Id())
+
+				// details is not stored by Wiz, a plan with the default details is empty after the import
+				config := terraform.NewResourceConfigRaw(map[string]interface{}{
+					idsKey:                      []interface{}{"c1", "c2"},
+					"security_sub_category_ids": []interface{}{"s1", "s2"},
+				})
+				diff, err := resource.Diff(context.Background(), imported[0].State(), config, nil)
+				assert.NoError(t, err)
+				assert.True(t, diff == nil || diff.Empty(), "unexpected diff %v", diff)
+
+				// while a configuration setting details plans to update it
+				config = terraform.NewResourceConfigRaw(map[string]interface{}{
+					idsKey:                      []interface{}{"c1", "c2"},
+					"security_sub_category_ids": []interface{}{"s1", "s2"},
+					"details":                   "notes",
+				})
+				diff, err = resource.Diff(context.Background(), imported[0].State(), config, nil)
+				if assert.NoError(t, err) && assert.NotNil(t, diff) {
+					assert.Contains(t, diff.Attributes, "details")
+				}
+			}
+
+			d = resource.TestResourceData()
+			d.SetId("association|c1,c2|s1,s2")
+			_, err = importAssociation(idsKey, read("c1"))(context.Background(), d, nil)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "are not all associated with c2")
+			}
+
+			// a read that finds nothing removes the resource instead of failing
+			d = resource.TestResourceData()
+			d.SetId("association|c1,c2|s1,s2")
+			_, err = importAssociation(idsKey, func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				return removeDeletedResource(ctx, d, "wiz_association")
+			})(context.Background(), d, nil)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "none of c1, c2 are associated")
+			}
+		})
+	}
+}
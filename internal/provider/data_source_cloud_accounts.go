@@ -40,6 +40,13 @@ func dataSourceWizCloudAccounts() *schema.Resource {
 				Default:     0,
 				Description: "How many pages to return. 0 means all pages.",
 			},
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				Description:      "Stop fetching pages once this many cloud accounts have been returned, the accounts are then sorted by id. 0 means no limit.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
 			"ids": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -233,6 +240,10 @@ func dataSourceWizCloudAccountsRead(ctx context.Context, d *schema.ResourceData,
 	if b {
 		identifier.WriteString(utils.PrettyPrint(maxPages))
 	}
+	maxResults, b := d.GetOk("max_results")
+	if b {
+		identifier.WriteString(utils.PrettyPrint(maxResults))
+	}
 
 	h := sha1.New()
 	h.Write([]byte(identifier.String()))
@@ -363,16 +374,22 @@ func dataSourceWizCloudAccountsRead(ctx context.Context, d *schema.ResourceData,
 	}
 	vars.FilterBy = filterBy
 
-	// process the request
-	data := &ReadCloudAccounts{}
-	requestDiags, allData := client.ProcessPagedRequest(ctx, m, vars, data, query, "cloud_accounts", "read", maxPages.(int))
-
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
+	// process the request, flattening each page as it arrives so only one page of raw results is held at a time
+	cloudAccounts := make([]interface{}, 0)
+	for page, pageDiags := range client.Pages[ReadCloudAccounts](ctx, m, vars, query, "cloud_accounts", client.PageOptions{MaxPages: maxPages.(int)}) {
+		diags = append(diags, pageDiags...)
+		if len(diags) > 0 {
+			return diags
+		}
+		cloudAccounts = append(cloudAccounts, flattenCloudAccountNodes(ctx, &page.CloudAccounts.Nodes)...)
+		if maxResults.(int) > 0 && len(cloudAccounts) >= maxResults.(int) {
+			cloudAccounts = cloudAccounts[:maxResults.(int)]
+			break
+		}
 	}
+	// the accounts of every page are sorted together once the last page is fetched
+	sortCloudAccounts(cloudAccounts)
 
-	cloudAccounts := flattenCloudAccounts(ctx, allData)
 	if err := d.Set("cloud_accounts", cloudAccounts); err != nil {
 		return append(diags, diag.FromErr(err)...)
 	}
@@ -382,35 +399,48 @@ func dataSourceWizCloudAccountsRead(ctx context.Context, d *schema.ResourceData,
 
 func flattenCloudAccounts(ctx context.Context, cloudAccounts []interface{}) []interface{} {
 	tflog.Info(ctx, "flattenCloudAccounts called...")
-	tflog.Debug(ctx, fmt.Sprintf("cloudAccounts: %s", utils.PrettyPrint(cloudAccounts)))
 
-	// walk the slice and construct the map
+	// walk the pages and construct the map
 	var output = make([]interface{}, 0)
 	for _, cldacc := range cloudAccounts {
 		ca := cldacc.(*ReadCloudAccounts)
-		for _, c := range ca.CloudAccounts.Nodes {
-			tflog.Debug(ctx, fmt.Sprintf("c: %T %s", c, utils.PrettyPrint(c)))
-			accountMap := make(map[string]interface{})
-			accountMap["id"] = c.ID
-			accountMap["external_id"] = c.ExternalID
-			accountMap["name"] = c.Name
-			accountMap["cloud_provider"] = c.CloudProvider
-			accountMap["status"] = c.Status
-			accountMap["linked_project_ids"] = flattenProjectIDs(ctx, &c.LinkedProjects)
-			accountMap["source_connector_ids"] = flattenSourceConnectorIDs(ctx, &c.SourceConnectors)
-			output = append(output, accountMap)
+		output = append(output, flattenCloudAccountNodes(ctx, &ca.CloudAccounts.Nodes)...)
+	}
+	sortCloudAccounts(output)
+	tflog.Debug(ctx, fmt.Sprintf("flattenCloudAccounts output: %s", utils.PrettyPrint(output)))
 
-		}
-		// sort the return slice to avoid unwanted diffs
-		sort.Slice(output, func(i, j int) bool {
-			return output[i].(map[string]interface{})["id"].(string) < output[j].(map[string]interface{})["id"].(string)
-		})
-		tflog.Debug(ctx, fmt.Sprintf("flattenCloudAccounts output: %s", utils.PrettyPrint(output)))
+	return output
+}
+
+func flattenCloudAccountNodes(ctx context.Context, nodes *[]*wiz.CloudAccount) []interface{} {
+	tflog.Info(ctx, "flattenCloudAccountNodes called...")
+	tflog.Debug(ctx, fmt.Sprintf("cloudAccounts: %s", utils.PrettyPrint(nodes)))
 
+	// walk the slice and construct the map
+	var output = make([]interface{}, 0)
+	for _, c := range *nodes {
+		tflog.Debug(ctx, fmt.Sprintf("c: %T %s", c, utils.PrettyPrint(c)))
+		accountMap := make(map[string]interface{})
+		accountMap["id"] = c.ID
+		accountMap["external_id"] = c.ExternalID
+		accountMap["name"] = c.Name
+		accountMap["cloud_provider"] = c.CloudProvider
+		accountMap["status"] = c.Status
+		accountMap["linked_project_ids"] = flattenProjectIDs(ctx, &c.LinkedProjects)
+		accountMap["source_connector_ids"] = flattenSourceConnectorIDs(ctx, &c.SourceConnectors)
+		output = append(output, accountMap)
 	}
+
 	return output
 }
 
+// sortCloudAccounts func - sort flattened cloud accounts by id to avoid unwanted diffs
+func sortCloudAccounts(cloudAccounts []interface{}) {
+	sort.Slice(cloudAccounts, func(i, j int) bool {
+		return cloudAccounts[i].(map[string]interface{})["id"].(string) < cloudAccounts[j].(map[string]interface{})["id"].(string)
+	})
+}
+
 func flattenProjectIDs(ctx context.Context, projects *[]*wiz.Project) []interface{} {
 	tflog.Info(ctx, "flattenProjectIDs called...")
 	tflog.Debug(ctx, fmt.Sprintf("Projects: %s", utils.PrettyPrint(projects)))
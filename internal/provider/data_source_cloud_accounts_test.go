@@ -2,9 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
@@ -68,6 +78,104 @@ func TestFlattenCloudAccounts(t *testing.T) {
 
 }
 
+func TestFlattenCloudAccountsPages(t *testing.T) {
+	ctx := context.Background()
+	page := func(ids ...string) interface{} {
+		accs := &ReadCloudAccounts{}
+		for _, id := range ids {
+			accs.CloudAccounts.Nodes = append(accs.CloudAccounts.Nodes, &wiz.CloudAccount{ID: id})
+		}
+		return accs
+	}
+
+	flattened := flattenCloudAccounts(ctx, []interface{}{page("0000000c", "0000000e"), page("0000000a", "0000000d"), page("0000000b")})
+
+	var ids []string
+	for _, account := range flattened {
+		ids = append(ids, account.(map[string]interface{})["id"].(string))
+	}
+	expected := []string{"0000000a", "0000000b", "0000000c", "0000000d", "0000000e"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Unexpected result. Expected: %v, but got: %v", expected, ids)
+	}
+}
+
+func TestReadCloudAccountsSortedAcrossPages(t *testing.T) {
+	// the pages are not sorted against each other
+	var requests int32
+	pages := [][]string{
+		{"0000000c", "0000000e"},
+		{"0000000a", "0000000d"},
+		{"0000000b"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Variables map[string]interface{} `json:"variables"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		atomic.AddInt32(&requests, 1)
+
+		page := 0
+		if after, ok := request.Variables["after"].(string); ok {
+			fmt.Sscanf(after, "cursor-%d", &page)
+		}
+		var nodes []string
+		for _, id := range pages[page] {
+			nodes = append(nodes, fmt.Sprintf(`{"id": %q}`, id))
+		}
+		fmt.Fprintf(w, `{"data": {"cloudAccounts": {"nodes": [%s], "pageInfo": {"hasNextPage": %t, "endCursor": "cursor-%d"}}}}`, strings.Join(nodes, ","), page+1 < len(pages), page+1)
+	}))
+	t.Cleanup(server.Close)
+
+	conf := &config.ProviderConf{
+		HTTPClient: &http.Client{},
+		Settings: &config.Settings{
+			WizURL: server.URL,
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+
+	tests := []struct {
+		maxResults int
+		expected   []string
+		requests   int32
+	}{
+		{
+			maxResults: 0,
+			expected:   []string{"0000000a", "0000000b", "0000000c", "0000000d", "0000000e"},
+			requests:   3,
+		},
+		{
+			// fetching stops with the second page, the last page is never requested
+			maxResults: 3,
+			expected:   []string{"0000000a", "0000000c", "0000000e"},
+			requests:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("max_results %d", test.maxResults), func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+			d := schema.TestResourceDataRaw(t, dataSourceWizCloudAccounts().Schema, map[string]interface{}{
+				"max_results": test.maxResults,
+			})
+			diags := dataSourceWizCloudAccountsRead(context.Background(), d, conf)
+			assert.Empty(t, diags)
+
+			assert.Equal(t, test.requests, atomic.LoadInt32(&requests))
+
+			// the accounts are a set, the accounts of every page fetched are returned
+			var ids []string
+			for _, account := range d.Get("cloud_accounts").(*schema.Set).List() {
+				ids = append(ids, account.(map[string]interface{})["id"].(string))
+			}
+			assert.ElementsMatch(t, test.expected, ids)
+		})
+	}
+}
+
 func TestFlattenProjectIDs(t *testing.T) {
 	ctx := context.Background()
 	expected := []interface{}{
@@ -34,6 +34,20 @@ func dataSourceWizCloudConfigurationRules() *schema.Resource {
 				Default:     500,
 				Description: "How many results to return",
 			},
+			"max_pages": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1,
+				Description:      "How many pages to return. 0 means all pages.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				Description:      "Stop fetching pages once this many rules have been returned. 0 means no limit.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
 			"search": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -435,6 +449,14 @@ func dataSourceWizCloudConfigurationRuleRead(ctx context.Context, d *schema.Reso
 	if b {
 		identifier.WriteString(utils.PrettyPrint(a))
 	}
+	maxPages, b := d.GetOk("max_pages")
+	if b {
+		identifier.WriteString(utils.PrettyPrint(maxPages))
+	}
+	maxResults, b := d.GetOk("max_results")
+	if b {
+		identifier.WriteString(utils.PrettyPrint(maxResults))
+	}
 
 	h := sha1.New()
 	h.Write([]byte(identifier.String()))
@@ -679,15 +701,20 @@ func dataSourceWizCloudConfigurationRuleRead(ctx context.Context, d *schema.Reso
 
 	vars.FilterBy = filterBy
 
-	// process the request
-	data := &ReadCloudConfigurationRules{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cloud_config_rules", "read")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
+	// process the request, flattening each page as it arrives so only one page of raw results is held at a time
+	cloudConfigurationRules := make([]interface{}, 0)
+	for page, pageDiags := range client.Pages[ReadCloudConfigurationRules](ctx, m, vars, query, "cloud_config_rules", client.PageOptions{MaxPages: maxPages.(int)}) {
+		diags = append(diags, pageDiags...)
+		if len(diags) > 0 {
+			return diags
+		}
+		cloudConfigurationRules = append(cloudConfigurationRules, flattenCloudConfigurationRules(ctx, &page.CloudConfigurationRules.Nodes)...)
+		if maxResults.(int) > 0 && len(cloudConfigurationRules) >= maxResults.(int) {
+			cloudConfigurationRules = cloudConfigurationRules[:maxResults.(int)]
+			break
+		}
 	}
 
-	cloudConfigurationRules := flattenCloudConfigurationRules(ctx, &data.CloudConfigurationRules.Nodes)
 	if err := d.Set("cloud_configuration_rules", cloudConfigurationRules); err != nil {
 		return append(diags, diag.FromErr(err)...)
 	}
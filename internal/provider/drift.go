@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/client"
+)
+
+// objectDeleted func - report whether a read shows that the object was deleted outside of terraform
+// Wiz reports a deleted object with a NOT_FOUND error, or with a null object and no errors. Any other error, including
+// an internal error, says nothing about the object and the resource is kept in the state; id is the id read from the api.
+func objectDeleted(ctx context.Context, requestDiags diag.Diagnostics, gqlErrors client.GraphQLErrors, id string) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if client.IsNotFound(gqlErrors) {
+		return true
+	}
+	// a graphql response with a null object, as opposed to a failed http request
+	return id == "" && len(gqlErrors) == 0 && !requestDiags.HasError()
+}
+
+// objectDeletedOrInternalError func - report whether a read shows that the object was deleted outside of terraform, for the
+// types whose query answers a deleted object with a null object and an internal error instead of a NOT_FOUND error
+func objectDeletedOrInternalError(ctx context.Context, requestDiags diag.Diagnostics, gqlErrors client.GraphQLErrors, id string) bool {
+	if objectDeleted(ctx, requestDiags, gqlErrors, id) {
+		return true
+	}
+	if ctx.Err() != nil || id != "" || len(gqlErrors) == 0 {
+		return false
+	}
+	// the internal error carries no code, a coded error such as UNAUTHORIZED or RATE_LIMIT_EXCEEDED is not a deletion
+	for _, gqlError := range gqlErrors {
+		if gqlError.Code() != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// removeDeletedResource func - remove a resource deleted outside of terraform from the state, so terraform plans to create it again
+func removeDeletedResource(ctx context.Context, d *schema.ResourceData, typeName string) diag.Diagnostics {
+	id := d.Id()
+	tflog.Info(ctx, fmt.Sprintf("%s %s not found, removing it from the state", typeName, id))
+	d.SetId("")
+	return deletedResourceWarning(typeName, id)
+}
+
+// removeDeletedFrameworkResource func - remove a plugin framework resource deleted outside of terraform from the state
+func removeDeletedFrameworkResource(ctx context.Context, state *tfsdk.State, typeName string, id string) fwdiag.Diagnostics {
+	tflog.Info(ctx, fmt.Sprintf("%s %s not found, removing it from the state", typeName, id))
+	state.RemoveResource(ctx)
+	return frameworkDiagnostics(deletedResourceWarning(typeName, id))
+}
+
+// deletedResourceWarning func - return the warning about a resource removed from the state as it was deleted outside of terraform
+func deletedResourceWarning(typeName string, id string) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s no longer exists", typeName),
+			Detail:   fmt.Sprintf("%s %s was not found in Wiz, it was likely deleted outside of Terraform. It has been removed from the state and will be created again.", typeName, id),
+		},
+	}
+}
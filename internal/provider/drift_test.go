@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// newDriftProviderConf returns a provider configuration whose api answers every request with status and body
+func newDriftProviderConf(t *testing.T, status int, body string) *config.ProviderConf {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return &config.ProviderConf{
+		HTTPClient: &http.Client{},
+		Settings: &config.Settings{
+			WizURL: server.URL,
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+}
+
+// internalErrorDeletedTypes are the types whose query answers a deleted object with a null object and an internal error
+var internalErrorDeletedTypes = map[string]bool{
+	"wiz_cicd_scan_policy":   true,
+	"wiz_control":            true,
+	"wiz_saml_idp":           true,
+	"wiz_security_framework": true,
+}
+
+func TestReadDeletedOutOfBand(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		deleted bool
+		// the object is only deleted for internalErrorDeletedTypes
+		internalError bool
+	}{
+		{
+			name:    "not found",
+			status:  http.StatusOK,
+			body:    `{"data": null, "errors": [{"message": "not found", "extensions": {"code": "NOT_FOUND"}}]}`,
+			deleted: true,
+		},
+		{
+			name:    "null payload",
+			status:  http.StatusOK,
+			body:    `{"data": null}`,
+			deleted: true,
+		},
+		{
+			name:          "null payload with an internal error",
+			status:        http.StatusOK,
+			body:          `{"data": null, "errors": [{"message": "oops! an internal error has occurred"}]}`,
+			internalError: true,
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusOK,
+			body:   `{"data": null, "errors": [{"message": "unauthorized", "extensions": {"code": "UNAUTHORIZED"}}]}`,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusOK,
+			body:   `{"data": null, "errors": [{"message": "slow down", "extensions": {"code": "RATE_LIMIT_EXCEEDED"}}]}`,
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			body:   `internal server error`,
+		},
+	}
+
+	for name, resource := range New("test")().ResourcesMap {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%s/%s", name, test.name), func(t *testing.T) {
+				state := timeoutState(t, resource, resource.Timeouts)
+				newState, diags := resource.RefreshWithoutUpgrade(context.Background(), state, newDriftProviderConf(t, test.status, test.body))
+
+				if !test.deleted && !(test.internalError && internalErrorDeletedTypes[name]) {
+					// the resource is kept and the read fails
+					assert.True(t, diags.HasError())
+					return
+				}
+
+				// the resource is removed from the state with a warning, so terraform plans to create it again
+				assert.False(t, diags.HasError(), diags)
+				assert.Nil(t, newState)
+				if assert.Len(t, diags, 1) {
+					assert.Equal(t, diag.Warning, diags[0].Severity)
+					assert.Equal(t, fmt.Sprintf("%s no longer exists", name), diags[0].Summary)
+				}
+			})
+		}
+	}
+}
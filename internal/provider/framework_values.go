@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Wiz returns an empty string or list for a setting that was never set, while terraform tells an unset optional
+// attribute (null) from an empty one. The values read from Wiz are compared with the prior value of the attribute,
+// the state on refresh and the plan on apply, so an empty setting is stored the way the configuration wrote it.
+
+// stringValue func - return the value of an optional string attribute read from Wiz, null when it is empty and the prior value is null
+func stringValue(prior types.String, value string) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// stringListValue func - return the value of an optional list of strings read from Wiz, null when it is empty and the prior value is null
+func stringListValue(ctx context.Context, prior types.List, values []string) (types.List, diag.Diagnostics) {
+	if len(values) == 0 && (prior.IsNull() || prior.IsUnknown()) {
+		return types.ListNull(types.StringType), nil
+	}
+	if values == nil {
+		values = []string{}
+	}
+	return types.ListValueFrom(ctx, types.StringType, values)
+}
+
+// stringListElements func - return the elements of a list of strings, nil when the list is null or unknown
+func stringListElements(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	var values []string
+	diags := list.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// boolPointer func - return the value of an optional bool attribute, nil when it is null or unknown so Wiz applies its default
+func boolPointer(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	v := value.ValueBool()
+	return &v
+}
@@ -41,8 +41,8 @@ func New(version string) func() *schema.Provider {
 			Schema: map[string]*schema.Schema{
 				"wiz_url": {
 					Type:        schema.TypeString,
-					Required:    true,
-					Description: "Wiz api endpoint.  This varies for each Wiz deployment.  See https://docs.wiz.io/wiz-docs/docs/using-the-wiz-api#the-graphql-endpoint. (default: none, environment variable: WIZ_URL)",
+					Optional:    true,
+					Description: "Wiz api endpoint.  This varies for each Wiz deployment.  See https://docs.wiz.io/wiz-docs/docs/using-the-wiz-api#the-graphql-endpoint. Required unless `wiz_data_center` is set. (default: none, environment variable: WIZ_URL)",
 					DefaultFunc: schema.EnvDefaultFunc(
 						"WIZ_URL",
 						nil,
@@ -51,13 +51,25 @@ func New(version string) func() *schema.Provider {
 						validation.IsURLWithHTTPorHTTPS,
 					),
 				},
+				"wiz_data_center": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The Wiz data center of the tenant, such as `us17` or `eu1`, used to derive `wiz_url` when it is not set. (default: none, environment variable: WIZ_DATA_CENTER)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"WIZ_DATA_CENTER",
+						nil,
+					),
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringMatch(config.DataCenterPattern, "must be the name of a Wiz data center, such as us17 or eu1"),
+					),
+				},
 				"wiz_auth_url": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					Description: "The authentication endpoint. (default: https://auth.app.wiz.io/oauth/token, environment variable: WIZ_AUTH_URL)",
+					Description: "The authentication endpoint. When not set, it is derived from `wiz_auth_audience`: https://auth.wiz.io/oauth/token for Auth0 and https://auth.app.wiz.io/oauth/token for Cognito or an audience that is not known. (default: https://auth.app.wiz.io/oauth/token, environment variable: WIZ_AUTH_URL)",
 					DefaultFunc: schema.EnvDefaultFunc(
 						"WIZ_AUTH_URL",
-						"https://auth.app.wiz.io/oauth/token",
+						nil,
 					),
 					ValidateDiagFunc: validation.ToDiagFunc(
 						validation.IsURLWithHTTPorHTTPS,
@@ -74,8 +86,8 @@ func New(version string) func() *schema.Provider {
 				},
 				"wiz_auth_client_id": {
 					Type:        schema.TypeString,
-					Required:    true,
-					Description: "Your application's Client ID. You can find this value on the Settings > Service Accounts page. (default: none, environment variable: WIZ_AUTH_CLIENT_ID)",
+					Optional:    true,
+					Description: "Your application's Client ID. You can find this value on the Settings > Service Accounts page. Required unless `wiz_auth_token` or `wiz_auth_token_file` is set. (default: none, environment variable: WIZ_AUTH_CLIENT_ID)",
 					DefaultFunc: schema.EnvDefaultFunc(
 						"WIZ_AUTH_CLIENT_ID",
 						nil,
@@ -83,27 +95,46 @@ func New(version string) func() *schema.Provider {
 				},
 				"wiz_auth_client_secret": {
 					Type:        schema.TypeString,
-					Required:    true,
-					Description: "Your application's Client Secret. You can find this value on the Settings > Service Accounts page. (default: none, environment variable: WIZ_AUTH_CLIENT_SECRET)",
+					Optional:    true,
+					Description: "Your application's Client Secret. You can find this value on the Settings > Service Accounts page. Required unless `wiz_auth_token` or `wiz_auth_token_file` is set. (default: none, environment variable: WIZ_AUTH_CLIENT_SECRET)",
 					DefaultFunc: schema.EnvDefaultFunc(
 						"WIZ_AUTH_CLIENT_SECRET",
 						nil,
 					),
 					Sensitive: true,
 				},
+				"wiz_auth_token": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "A pre-issued Wiz session token, used instead of requesting one with the client credentials. Takes precedence over `wiz_auth_token_file` and the client credentials. (default: none, environment variable: WIZ_AUTH_TOKEN)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"WIZ_AUTH_TOKEN",
+						nil,
+					),
+				},
+				"wiz_auth_token_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path of a file holding a pre-issued Wiz session token. The file is read again whenever the token expires or is rejected, so an external process can rotate it. Takes precedence over the client credentials. (default: none, environment variable: WIZ_AUTH_TOKEN_FILE)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"WIZ_AUTH_TOKEN_FILE",
+						nil,
+					),
+				},
 				"wiz_auth_audience": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					Description: "Set this to 'beyond-api' if using auth0 and 'wiz-api' if using Cognito. (default: wiz-api, environment variable: WIZ_AUTH_AUDIENCE)",
+					Description: "Set this to 'beyond-api' if using auth0 and 'wiz-api' if using Cognito. When not set, it is derived from `wiz_auth_url`, an endpoint that is not known uses 'wiz-api' with a warning. A value that does not match the provider of `wiz_auth_url` is reported when the provider is configured. (default: wiz-api, environment variable: WIZ_AUTH_AUDIENCE)",
 					DefaultFunc: schema.EnvDefaultFunc(
 						"WIZ_AUTH_AUDIENCE",
-						"wiz-api",
+						nil,
 					),
 				},
 				"proxy": {
 					Type:        schema.TypeBool,
 					Optional:    true,
-					Description: "Use an http proxy server? (default: false, environment variable: PROXY)",
+					Description: "Use an http proxy server? When disabled, the proxy is read from the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables. (default: false, environment variable: PROXY)",
 					DefaultFunc: schema.EnvDefaultFunc(
 						"PROXY",
 						false,
@@ -121,6 +152,34 @@ func New(version string) func() *schema.Provider {
 						nil,
 					),
 				},
+				"proxy_username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Username used to authenticate with the proxy server, sent using basic authentication. Applies to the proxy from the environment variables as well. (default: none, environment variable: PROXY_USERNAME)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"PROXY_USERNAME",
+						nil,
+					),
+				},
+				"proxy_password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Password used to authenticate with the proxy server. (default: none, environment variable: PROXY_PASSWORD)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"PROXY_PASSWORD",
+						nil,
+					),
+					RequiredWith: []string{"proxy_username"},
+				},
+				"no_proxy": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Description: "Hosts reached without the proxy, in addition to the hosts in the `NO_PROXY` environment variable. Entries are host names, domain suffixes such as `.example.com`, IP addresses or CIDR ranges, optionally with a port.",
+				},
 				"ca_chain": {
 					Type:        schema.TypeString,
 					Optional:    true,
@@ -250,6 +309,142 @@ upRyzQ7qDn1X8nn8N8V7YJ6y68AtkHcNSRAnpTitxBKjtKPISLMVCx7i4hncxHZS
 yLyKQXhw2W2Xs0qLeC1etA+jTGDK4UfLeC0SF7FSi8o5LL21L8IzApar2pR/
 -----END CERTIFICATE-----`),
 				},
+				"ca_chain_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path of a file holding additional PEM encoded certificate authorities trusted when communicating with Wiz, added to `ca_chain`. (default: none, environment variable: CA_CHAIN_FILE)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"CA_CHAIN_FILE",
+						nil,
+					),
+				},
+				"ca_chain_mode": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "How the certificate authorities of `ca_chain` and `ca_chain_file` are trusted. Set to `replace` to trust only these certificate authorities, or to `append` to trust the system certificate authorities as well, so a rotation of the certificate authority used by Wiz does not break the provider. Certificate authorities that expired or expire within 30 days are reported as warnings. (default: replace, environment variable: CA_CHAIN_MODE)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"CA_CHAIN_MODE",
+						config.CAChainModeReplace,
+					),
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringInSlice([]string{config.CAChainModeReplace, config.CAChainModeAppend}, false),
+					),
+				},
+				"client_certificate": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM encoded client certificate, or the path of a file holding it, presented to the Wiz api and the authentication endpoint for mutual TLS. (default: none, environment variable: WIZ_CLIENT_CERTIFICATE)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"WIZ_CLIENT_CERTIFICATE",
+						nil,
+					),
+					RequiredWith: []string{"client_key"},
+				},
+				"client_key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "PEM encoded private key of the client certificate, or the path of a file holding it. (default: none, environment variable: WIZ_CLIENT_KEY)",
+					DefaultFunc: schema.EnvDefaultFunc(
+						"WIZ_CLIENT_KEY",
+						nil,
+					),
+					RequiredWith: []string{"client_certificate"},
+				},
+				"http_client": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "Connection pool and timeout settings of the http client used to call the Wiz api. Values exceeding the conservative defaults recommended by the provider are reported as warnings when the provider is configured. Timeouts are in seconds, 0 means no timeout.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"max_conns_per_host": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     10,
+								Description: "Maximum number of connections to the Wiz api, including connections in use and idle. Set to 0 for no limit.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"max_idle_conns": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     100,
+								Description: "Maximum number of idle connections kept open across all hosts. Set to 0 for no limit.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"max_idle_conns_per_host": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     10,
+								Description: "Maximum number of idle connections kept open to the Wiz api.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"idle_conn_timeout": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     55,
+								Description: "Time an idle connection is kept open before it is closed.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"tls_handshake_timeout": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     10,
+								Description: "Maximum time to wait for a TLS handshake.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"response_header_timeout": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     0,
+								Description: "Maximum time to wait for the response headers once a request is sent. Values above 120 are reported as warnings. A timed out request is retried, so a slow query is sent again.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"timeout": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     0,
+								Description: "Maximum time for a single request attempt, including connecting, redirects and reading the response body. Retries are given a new timeout.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+							"http2": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     false,
+								Description: "Attempt to use HTTP/2 when the server supports it.",
+							},
+							"keep_alive": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+								Description: "Reuse connections between requests. When disabled, every request opens a new connection.",
+							},
+							"keep_alive_interval": {
+								Type:        schema.TypeInt,
+								Optional:    true,
+								Default:     30,
+								Description: "Interval between TCP keep-alive probes of open connections. Set to 0 to use the operating system default.",
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.IntAtLeast(0),
+								),
+							},
+						},
+					},
+				},
 				"http_client_retry_max": {
 					Type:        schema.TypeInt,
 					Optional:    true,
@@ -266,7 +461,51 @@ yLyKQXhw2W2Xs0qLeC1etA+jTGDK4UfLeC0SF7FSi8o5LL21L8IzApar2pR/
 					Type:        schema.TypeInt,
 					Optional:    true,
 					Default:     10,
-					Description: "Maximum time to wait before retrying, in seconds.",
+					Description: "Maximum time to wait before retrying, in seconds. A longer Retry-After sent by the api is capped at this value.",
+				},
+				"max_requests_per_second": {
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Default:     0.0,
+					Description: "Maximum number of requests per second sent to the Wiz api, shared by all resources and data sources. Use this to stay within tenant-wide api quotas when running with high parallelism. Set to 0 for no limit.",
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.FloatAtLeast(0),
+					),
+				},
+				"max_concurrent_requests": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     0,
+					Description: "Maximum number of requests to the Wiz api in flight at the same time, shared by all resources and data sources. Set to 0 for no limit.",
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.IntAtLeast(0),
+					),
+				},
+				"read_cache_ttl": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     0,
+					Description: "Cache the responses of identical read queries for this many seconds, so data sources and resources called repeatedly with the same arguments only query Wiz once. Every cached response is dropped when the provider creates, updates or deletes an object, and reads sent meanwhile are not cached. At most 1000 responses are cached, the oldest are evicted first. Set to 0 to disable the cache.",
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.IntAtLeast(0),
+					),
+				},
+				"persisted_queries": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Send queries as automatic persisted queries, identified by the sha256 hash of their minified text. The full query text is only sent when Wiz has not seen the hash yet, or when the api does not support persisted queries.",
+				},
+				"log_redact_keys": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Description: fmt.Sprintf(
+						"Additional JSON keys and form fields whose values are masked in debug logs of api requests and responses. Keys are matched case insensitively. Authorization headers and the following keys are always masked: `%s`.",
+						strings.Join(config.DefaultRedactKeys, "`, `"),
+					),
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
 				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
@@ -285,7 +524,6 @@ yLyKQXhw2W2Xs0qLeC1etA+jTGDK4UfLeC0SF7FSi8o5LL21L8IzApar2pR/
 				"wiz_automation_rule_jira_transition_ticket":   resourceWizAutomationRuleJiraTransitionTicket(),
 				"wiz_automation_rule_jira_add_comment":         resourceWizAutomationRuleJiraAddComment(),
 				"wiz_automation_rule_jira_create_ticket":       resourceWizAutomationRuleJiraCreateTicket(),
-				"wiz_cicd_scan_policy":                         resourceWizCICDScanPolicy(),
 				"wiz_cloud_config_rule":                        resourceWizCloudConfigurationRule(),
 				"wiz_cloud_config_rule_associations":           resourceWizCloudConfigRuleAssociations(),
 				"wiz_control":                                  resourceWizControl(),
@@ -297,7 +535,6 @@ yLyKQXhw2W2Xs0qLeC1etA+jTGDK4UfLeC0SF7FSi8o5LL21L8IzApar2pR/
 				"wiz_integration_servicenow":                   resourceWizIntegrationServiceNow(),
 				"wiz_integration_jira":                         resourceWizIntegrationJira(),
 				"wiz_report_graph_query":                       resourceWizReportGraphQuery(),
-				"wiz_project":                                  resourceWizProject(),
 				"wiz_saml_idp":                                 resourceWizSAMLIdP(),
 				"wiz_saml_group_mapping":                       resourceWizSAMLGroupMapping(),
 				"wiz_security_framework":                       resourceWizSecurityFramework(),
@@ -320,17 +557,8 @@ func init() {
 	schema.SchemaDescriptionBuilder = func(s *schema.Schema) string {
 		desc := s.Description
 
-		// We have to do some magic here since some default values include golang text/template markers, which confuses tfplugindocs
-		switch v := s.Default.(type) {
-		case string:
-			_ = v
-			if s.Default != nil {
-				desc += fmt.Sprintf("\n    - Defaults to `%s`.", s.Default.(string))
-			}
-		default:
-			if s.Default != nil {
-				desc += fmt.Sprintf("\n    - Defaults to `%v`.", s.Default)
-			}
+		if s.Default != nil {
+			desc = describeDefault(desc, s.Default)
 		}
 		if s.ConflictsWith != nil {
 			desc += fmt.Sprintf("\n    - Conflicts with `%v`.", s.ConflictsWith)
@@ -346,6 +574,12 @@ func init() {
 	}
 }
 
+// describeDefault func - append the default value of an attribute to its description
+// the resources of the plugin framework provider describe their defaults the same way as the sdk resources.
+func describeDefault(desc string, value interface{}) string {
+	return desc + fmt.Sprintf("\n    - Defaults to `%v`.", value)
+}
+
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		tflog.Info(ctx, "configure called...")
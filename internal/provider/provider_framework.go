@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// The provider is served as a mux of the sdk provider and a plugin framework provider, so resources can move to the
+// framework one at a time. A migrated resource keeps its type name and attributes but bumps its schema version: the
+// sdk stored unset strings and lists as empty values, which the framework tells apart from null, so the upgrader of
+// version 0 (upgradeSDKState) turns them into null. Both providers share the configuration of the sdk provider.
+
+// NewProtocol6ProviderServer func - return the factory of the protocol 6 server muxing the sdk and the plugin framework providers
+func NewProtocol6ProviderServer(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
+	p := New(version)()
+
+	sdkServer, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return NewGRPCProviderServer(p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the sdk server comes first, it is configured before the framework provider reads its configuration
+	muxServer, err := tf6muxserver.NewMuxServer(
+		ctx,
+		func() tfprotov6.ProviderServer { return sdkServer },
+		providerserver.NewProtocol6(NewFrameworkProvider(version, p)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider serves the resources migrated to the plugin framework
+type frameworkProvider struct {
+	version string
+	sdk     *schema.Provider
+}
+
+// NewFrameworkProvider returns the plugin framework provider, configured by the sdk provider p
+func NewFrameworkProvider(version string, p *schema.Provider) fwprovider.Provider {
+	return &frameworkProvider{
+		version: version,
+		sdk:     p,
+	}
+}
+
+// Metadata implements provider.Provider
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "wiz"
+	resp.Version = p.version
+}
+
+// Schema implements provider.Provider, the schema mirrors the one of the sdk provider as the mux requires identical schemas
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	schemaResp, err := schema.NewGRPCProviderServer(p.sdk).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the provider schema", err.Error())
+		return
+	}
+	for _, d := range schemaResp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			resp.Diagnostics.AddError(d.Summary, d.Detail)
+		}
+	}
+	if resp.Diagnostics.HasError() || schemaResp.Provider == nil {
+		return
+	}
+
+	attributes, blocks, err := frameworkProviderBlock(schemaResp.Provider.Block)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to convert the provider schema", err.Error())
+		return
+	}
+	resp.Schema = fwschema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         description(schemaResp.Provider.Block.Description, schemaResp.Provider.Block.DescriptionKind, false),
+		MarkdownDescription: description(schemaResp.Provider.Block.Description, schemaResp.Provider.Block.DescriptionKind, true),
+	}
+}
+
+// Configure implements provider.Provider, the resources receive the configuration of the sdk provider
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	conf, ok := p.sdk.Meta().(*config.ProviderConf)
+	if !ok || conf == nil {
+		resp.Diagnostics.AddError(
+			"Unable to configure provider",
+			"The provider configuration was not initialized before the resources of the plugin framework provider.",
+		)
+		return
+	}
+	resp.ResourceData = conf
+	resp.DataSourceData = conf
+}
+
+// Resources implements provider.Provider
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewProjectResource,
+		NewCICDScanPolicyResource,
+	}
+}
+
+// DataSources implements provider.Provider
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// description func - return the description of a protocol schema element for the plain or the markdown description field
+func description(text string, kind tfprotov5.StringKind, markdown bool) string {
+	if (kind == tfprotov5.StringKindMarkdown) != markdown {
+		return ""
+	}
+	return text
+}
+
+// frameworkProviderBlock func - convert a block of the sdk provider protocol schema to provider schema attributes and blocks
+func frameworkProviderBlock(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		attribute, err := frameworkProviderAttribute(a)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", a.Name, err)
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := make(map[string]fwschema.Block, len(block.BlockTypes))
+	for _, b := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkProviderBlock(b.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", b.TypeName, err)
+		}
+		nested := fwschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		plain := description(b.Block.Description, b.Block.DescriptionKind, false)
+		markdown := description(b.Block.Description, b.Block.DescriptionKind, true)
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[b.TypeName] = fwschema.ListNestedBlock{NestedObject: nested, Description: plain, MarkdownDescription: markdown}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[b.TypeName] = fwschema.SetNestedBlock{NestedObject: nested, Description: plain, MarkdownDescription: markdown}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[b.TypeName] = fwschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: plain, MarkdownDescription: markdown}
+		default:
+			return nil, nil, fmt.Errorf("%s: unsupported nesting mode %s", b.TypeName, b.Nesting)
+		}
+	}
+	return attributes, blocks, nil
+}
+
+// frameworkProviderAttribute func - convert an attribute of the sdk provider protocol schema to a provider schema attribute
+func frameworkProviderAttribute(a *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	plain := description(a.Description, a.DescriptionKind, false)
+	markdown := description(a.Description, a.DescriptionKind, true)
+	var deprecation string
+	if a.Deprecated {
+		deprecation = fmt.Sprintf("%s is deprecated.", a.Name)
+	}
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: plain, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: plain, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: plain, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	}
+
+	var elementType tftypes.Type
+	switch t := a.Type.(type) {
+	case tftypes.List:
+		elementType = t.ElementType
+	case tftypes.Set:
+		elementType = t.ElementType
+	case tftypes.Map:
+		elementType = t.ElementType
+	default:
+		return nil, fmt.Errorf("unsupported type %s", a.Type)
+	}
+	element, err := frameworkElementType(elementType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch a.Type.(type) {
+	case tftypes.List:
+		return fwschema.ListAttribute{ElementType: element, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: plain, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	case tftypes.Set:
+		return fwschema.SetAttribute{ElementType: element, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: plain, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	default:
+		return fwschema.MapAttribute{ElementType: element, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, Description: plain, MarkdownDescription: markdown, DeprecationMessage: deprecation}, nil
+	}
+}
+
+// frameworkElementType func - return the framework type of the elements of a collection attribute
+func frameworkElementType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	}
+	return nil, fmt.Errorf("unsupported element type %s", t)
+}
+
+// frameworkDiagnostics func - convert the diagnostics of the client to plugin framework diagnostics
+func frameworkDiagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var output fwdiag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			output.AddWarning(d.Summary, d.Detail)
+			continue
+		}
+		output.AddError(d.Summary, d.Detail)
+	}
+	return output
+}
+
+// frameworkScopeWarning func - warn at plan time about the scopes missing to apply the planned change of a framework resource
+// it is the counterpart of the warning the sdk resources get from scopeCheckingServer.
+func frameworkScopeWarning(meta interface{}, typeName string, req resource.ModifyPlanRequest) fwdiag.Diagnostics {
+	var operations []string
+	switch {
+	case req.Plan.Raw.IsNull():
+		operations = []string{scopeOperationDelete}
+	case req.State.Raw.IsNull():
+		operations = []string{scopeOperationRead, scopeOperationCreate}
+	case req.Plan.Raw.Equal(req.State.Raw):
+		operations = []string{scopeOperationRead}
+	default:
+		operations = []string{scopeOperationRead, scopeOperationUpdate}
+	}
+
+	var diags fwdiag.Diagnostics
+	for _, warning := range scopeWarning(typeName, missingScopes(meta, typeName, operations...)) {
+		diags.AddWarning(warning.Summary, warning.Detail)
+	}
+	return diags
+}
+
+// providerConf func - return the provider configuration passed to Configure of a framework resource
+// the provider data is nil until the provider is configured, e.g. when terraform validates the configuration.
+func providerConf(data interface{}) (*config.ProviderConf, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	if data == nil {
+		return nil, diags
+	}
+	conf, ok := data.(*config.ProviderConf)
+	if !ok {
+		diags.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *config.ProviderConf, got: %T. Please report this issue to the provider developers.", data),
+		)
+	}
+	return conf, diags
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frameworkState returns the state of a framework resource with only an id, like an imported resource
+func frameworkState(t *testing.T, r resource.Resource, id string) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["id"] = tftypes.NewValue(tftypes.String, id)
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
+
+func TestProtocol6ProviderServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := NewProtocol6ProviderServer(ctx, "test")
+	require.NoError(t, err)
+
+	// the mux fails when the schemas of the providers differ
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+
+	// every sdk resource is still served, along with the migrated resources
+	for name := range New("test")().ResourcesMap {
+		assert.Contains(t, resp.ResourceSchemas, name)
+	}
+	for _, name := range []string{"wiz_project", "wiz_cicd_scan_policy"} {
+		if assert.Contains(t, resp.ResourceSchemas, name) {
+			assert.Equal(t, int64(1), resp.ResourceSchemas[name].Version)
+		}
+	}
+}
+
+func TestFrameworkResourceStateUpgraders(t *testing.T) {
+	tests := []struct {
+		typeName string
+		rawState string
+		// attributes the sdk stored empty and are now null
+		null []string
+	}{
+		{
+			typeName: "wiz_project",
+			rawState: sdkProjectState,
+			null:     []string{"description", "identifiers", "business_unit", "parent_project_id", "project_owners"},
+		},
+		{
+			typeName: "wiz_cicd_scan_policy",
+			rawState: sdkCICDScanPolicyState,
+			null:     []string{"description"},
+		},
+	}
+
+	ctx := context.Background()
+	serverFactory, err := NewProtocol6ProviderServer(ctx, "test")
+	require.NoError(t, err)
+	server := serverFactory()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.typeName, func(t *testing.T) {
+			resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: test.typeName,
+				Version:  0,
+				RawState: &tfprotov6.RawState{JSON: []byte(test.rawState)},
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Diagnostics)
+
+			value, err := resp.UpgradedState.Unmarshal(schemaResp.ResourceSchemas[test.typeName].ValueType())
+			require.NoError(t, err)
+			var attributes map[string]tftypes.Value
+			require.NoError(t, value.As(&attributes))
+
+			assert.True(t, attributes["id"].IsKnown() && !attributes["id"].IsNull())
+			for _, name := range test.null {
+				assert.True(t, attributes[name].IsNull(), name)
+			}
+		})
+	}
+}
+
+func TestFrameworkReadDeletedOutOfBand(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		deleted bool
+		// the object is only deleted for internalErrorDeletedTypes
+		internalError bool
+	}{
+		{
+			name:    "not found",
+			status:  http.StatusOK,
+			body:    `{"data": null, "errors": [{"message": "not found", "extensions": {"code": "NOT_FOUND"}}]}`,
+			deleted: true,
+		},
+		{
+			name:    "null payload",
+			status:  http.StatusOK,
+			body:    `{"data": null}`,
+			deleted: true,
+		},
+		{
+			name:          "null payload with an internal error",
+			status:        http.StatusOK,
+			body:          `{"data": null, "errors": [{"message": "oops! an internal error has occurred"}]}`,
+			internalError: true,
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusOK,
+			body:   `{"data": null, "errors": [{"message": "unauthorized", "extensions": {"code": "UNAUTHORIZED"}}]}`,
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			body:   `internal server error`,
+		},
+	}
+
+	resources := map[string]func() resource.Resource{
+		"wiz_project":          NewProjectResource,
+		"wiz_cicd_scan_policy": NewCICDScanPolicyResource,
+	}
+
+	ctx := context.Background()
+	for name, newResource := range resources {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%s/%s", name, test.name), func(t *testing.T) {
+				r := newResource()
+				configureResp := &resource.ConfigureResponse{}
+				r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+					ProviderData: newDriftProviderConf(t, test.status, test.body),
+				}, configureResp)
+				require.False(t, configureResp.Diagnostics.HasError(), configureResp.Diagnostics)
+
+				state := frameworkState(t, r, "00000000-0000-0000-0000-000000000000")
+				resp := &resource.ReadResponse{State: state}
+				r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+				if !test.deleted && !(test.internalError && internalErrorDeletedTypes[name]) {
+					// the resource is kept and the read fails
+					assert.True(t, resp.Diagnostics.HasError())
+					assert.False(t, resp.State.Raw.IsNull())
+					return
+				}
+
+				// the resource is removed from the state with a warning, so terraform plans to create it again
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				assert.True(t, resp.State.Raw.IsNull())
+				if assert.Len(t, resp.Diagnostics, 1) {
+					assert.Equal(t, fwdiag.SeverityWarning, resp.Diagnostics[0].Severity())
+					assert.Equal(t, fmt.Sprintf("%s no longer exists", name), resp.Diagnostics[0].Summary())
+				}
+			})
+		}
+	}
+}
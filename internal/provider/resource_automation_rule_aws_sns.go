@@ -115,6 +115,7 @@ func resourceWizAutomationRuleAwsSns() *schema.Resource {
 		ReadContext:   resourceWizAutomationRuleAwsSNSRead,
 		UpdateContext: resourceWizAutomationRuleAwsSNSUpdate,
 		DeleteContext: resourceWizAutomationRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -234,17 +235,12 @@ func resourceWizAutomationRuleAwsSNSRead(ctx context.Context, d *schema.Resource
 		},
 	}
 
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "automation_rule_aws_sns", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "automation_rule_aws_sns", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.AutomationRule.ID) {
+		return removeDeletedResource(ctx, d, "wiz_automation_rule_aws_sns")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.AutomationRule.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
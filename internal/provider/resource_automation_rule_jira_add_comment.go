@@ -126,6 +126,7 @@ func resourceWizAutomationRuleJiraAddComment() *schema.Resource {
 		ReadContext:   resourceWizAutomationRuleJiraAddCommentRead,
 		UpdateContext: resourceWizAutomationRuleJiraAddCommentUpdate,
 		DeleteContext: resourceWizAutomationRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -248,17 +249,12 @@ func resourceWizAutomationRuleJiraAddCommentRead(ctx context.Context, d *schema.
 			Actions: automationRuleActions,
 		},
 	}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "automation_rule_jira_add_comment", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "automation_rule_jira_add_comment", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.AutomationRule.ID) {
+		return removeDeletedResource(ctx, d, "wiz_automation_rule_jira_add_comment")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.AutomationRule.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -144,6 +144,7 @@ func resourceWizAutomationRuleJiraTransitionTicket() *schema.Resource {
 		ReadContext:   resourceWizAutomationRuleJiraTransitionTicketRead,
 		UpdateContext: resourceWizAutomationRuleJiraTransitionTicketUpdate,
 		DeleteContext: resourceWizAutomationRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -272,17 +273,12 @@ func resourceWizAutomationRuleJiraTransitionTicketRead(ctx context.Context, d *s
 			Actions: automationRuleActions,
 		},
 	}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "automation_rule_jira_transition_ticket", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "automation_rule_jira_transition_ticket", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.AutomationRule.ID) {
+		return removeDeletedResource(ctx, d, "wiz_automation_rule_jira_transition_ticket")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.AutomationRule.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -142,6 +142,7 @@ func resourceWizAutomationRuleServiceNowCreateTicket() *schema.Resource {
 		ReadContext:   resourceWizAutomationRuleServiceNowCreateTicketRead,
 		UpdateContext: resourceWizAutomationRuleServiceNowCreateTicketUpdate,
 		DeleteContext: resourceWizAutomationRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -274,17 +275,12 @@ func resourceWizAutomationRuleServiceNowCreateTicketRead(ctx context.Context, d
 		},
 	}
 
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "automation_rule_servicenow_create_ticket", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "automation_rule_servicenow_create_ticket", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.AutomationRule.ID) {
+		return removeDeletedResource(ctx, d, "wiz_automation_rule_servicenow_create_ticket")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.AutomationRule.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -4,325 +4,414 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"wiz.io/hashicorp/terraform-provider-wiz/internal"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/client"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
-func resourceWizCICDScanPolicy() *schema.Resource {
-	return &schema.Resource{
-		Description: "Configure CI/CD Scan Policies.",
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Description: "Internal identifier",
-				Computed:    true,
+var (
+	_ resource.ResourceWithConfigure      = &cicdScanPolicyResource{}
+	_ resource.ResourceWithImportState    = &cicdScanPolicyResource{}
+	_ resource.ResourceWithModifyPlan     = &cicdScanPolicyResource{}
+	_ resource.ResourceWithUpgradeState   = &cicdScanPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &cicdScanPolicyResource{}
+)
+
+// scan policy types, reported by the api as the type of the policy params
+const (
+	cicdScanPolicyTypeVulnerabilities = "CICDScanPolicyParamsVulnerabilities"
+	cicdScanPolicyTypeSecrets         = "CICDScanPolicyParamsSecrets"
+	cicdScanPolicyTypeIAC             = "CICDScanPolicyParamsIAC"
+)
+
+// cicdScanPolicyParamsAttributes lists the params blocks, a policy has exactly one of them
+var cicdScanPolicyParamsAttributes = []string{"disk_vulnerabilities_params", "disk_secrets_params", "iac_params"}
+
+// cicdScanPolicyResource manages wiz_cicd_scan_policy with the plugin framework provider
+type cicdScanPolicyResource struct {
+	conf *config.ProviderConf
+}
+
+// NewCICDScanPolicyResource returns the wiz_cicd_scan_policy resource
+func NewCICDScanPolicyResource() resource.Resource {
+	return &cicdScanPolicyResource{}
+}
+
+// cicdScanPolicyResourceModel describes the wiz_cicd_scan_policy resource data
+type cicdScanPolicyResourceModel struct {
+	ID                        types.String                     `tfsdk:"id"`
+	Name                      types.String                     `tfsdk:"name"`
+	Description               types.String                     `tfsdk:"description"`
+	Builtin                   types.Bool                       `tfsdk:"builtin"`
+	Type                      types.String                     `tfsdk:"type"`
+	DiskVulnerabilitiesParams []diskVulnerabilitiesParamsModel `tfsdk:"disk_vulnerabilities_params"`
+	DiskSecretsParams         []diskSecretsParamsModel         `tfsdk:"disk_secrets_params"`
+	IACParams                 []iacParamsModel                 `tfsdk:"iac_params"`
+	Timeouts                  timeouts.Value                   `tfsdk:"timeouts"`
+}
+
+// diskVulnerabilitiesParamsModel describes the disk_vulnerabilities_params block
+type diskVulnerabilitiesParamsModel struct {
+	Severity              types.String `tfsdk:"severity"`
+	PackageCountThreshold types.Int64  `tfsdk:"package_count_threshold"`
+	IgnoreUnfixed         types.Bool   `tfsdk:"ignore_unfixed"`
+	PackageAllowList      types.List   `tfsdk:"package_allow_list"`
+}
+
+// diskSecretsParamsModel describes the disk_secrets_params block
+type diskSecretsParamsModel struct {
+	CountThreshold types.Int64 `tfsdk:"count_threshold"`
+	PathAllowList  types.List  `tfsdk:"path_allow_list"`
+}
+
+// iacParamsModel describes the iac_params block
+type iacParamsModel struct {
+	SeverityThreshold        types.String           `tfsdk:"severity_threshold"`
+	CountThreshold           types.Int64            `tfsdk:"count_threshold"`
+	IgnoredRules             types.List             `tfsdk:"ignored_rules"`
+	BuiltinIgnoreTagsEnabled types.Bool             `tfsdk:"builtin_ignore_tags_enabled"`
+	CustomIgnoreTags         []customIgnoreTagModel `tfsdk:"custom_ignore_tags"`
+	SecurityFrameworks       types.List             `tfsdk:"security_frameworks"`
+}
+
+// customIgnoreTagModel describes a custom_ignore_tags block
+type customIgnoreTagModel struct {
+	Key            types.String `tfsdk:"key"`
+	Value          types.String `tfsdk:"value"`
+	RuleIDs        types.List   `tfsdk:"rule_ids"`
+	IgnoreAllRules types.Bool   `tfsdk:"ignore_all_rules"`
+}
+
+func (r *cicdScanPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cicd_scan_policy"
+}
+
+// describeExactlyOneOfParams func - append the params blocks a policy has exactly one of to the description of a block
+func describeExactlyOneOfParams(desc string) string {
+	return desc + fmt.Sprintf("\n    - Required exactly one of: `%v`.", cicdScanPolicyParamsAttributes)
+}
+
+func (r *cicdScanPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Configure CI/CD Scan Policies.",
+		Version:             1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Internal identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"name": {
-				Type:        schema.TypeString,
-				Description: "Name of the Scan Policy.",
-				Required:    true,
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the Scan Policy.",
+				Required:            true,
 			},
-			"description": {
-				Type:        schema.TypeString,
-				Description: "Description of the Scan Policy.",
-				Optional:    true,
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the Scan Policy.",
+				Optional:            true,
 			},
-			"builtin": {
-				Type:     schema.TypeBool,
+			"builtin": schema.BoolAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"type": {
-				Type:        schema.TypeString,
-				Description: "The scan policy type",
-				Computed:    true,
+			// set in ModifyPlan from the params block of the policy
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The scan policy type",
+				Computed:            true,
 			},
-			"disk_vulnerabilities_params": {
-				Type:        schema.TypeSet,
-				Description: "Vulnerability scan parameters.",
-				Optional:    true,
-				MaxItems:    1,
-				ExactlyOneOf: []string{
-					"disk_vulnerabilities_params",
-					"disk_secrets_params",
-					"iac_params",
+		},
+		Blocks: map[string]schema.Block{
+			"disk_vulnerabilities_params": schema.SetNestedBlock{
+				MarkdownDescription: describeExactlyOneOfParams("Vulnerability scan parameters."),
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(1),
 				},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"severity": {
-							Type:     schema.TypeString,
-							Required: true,
-							Description: fmt.Sprintf(
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"severity": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf(
 								"Severity.\n    - Allowed values: %s",
 								utils.SliceOfStringToMDUList(
 									wiz.DiskScanVulnerabilitySeverity,
 								),
 							),
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.DiskScanVulnerabilitySeverity,
-									false,
-								),
-							),
-						},
-						"package_count_threshold": {
-							Type:     schema.TypeInt,
 							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(wiz.DiskScanVulnerabilitySeverity...),
+							},
 						},
-						"ignore_unfixed": {
-							Type:     schema.TypeBool,
+						"package_count_threshold": schema.Int64Attribute{
 							Required: true,
 						},
-						"package_allow_list": {
-							Type:     schema.TypeList,
+						"ignore_unfixed": schema.BoolAttribute{
 							Required: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+						},
+						"package_allow_list": schema.ListAttribute{
+							Required:    true,
+							ElementType: types.StringType,
 						},
 					},
 				},
 			},
-			"disk_secrets_params": {
-				Type:        schema.TypeSet,
-				Description: "Secret scan parameters.",
-				Optional:    true,
-				MaxItems:    1,
-				ExactlyOneOf: []string{
-					"disk_vulnerabilities_params",
-					"disk_secrets_params",
-					"iac_params",
+			"disk_secrets_params": schema.SetNestedBlock{
+				MarkdownDescription: describeExactlyOneOfParams("Secret scan parameters."),
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(1),
 				},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"count_threshold": {
-							Type:     schema.TypeInt,
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"count_threshold": schema.Int64Attribute{
 							Required: true,
 						},
-						"path_allow_list": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+						"path_allow_list": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
 						},
 					},
 				},
 			},
-			"iac_params": {
-				Type:        schema.TypeSet,
-				Description: "IaC scan parameters.",
-				Optional:    true,
-				MaxItems:    1,
-				ExactlyOneOf: []string{
-					"disk_vulnerabilities_params",
-					"disk_secrets_params",
-					"iac_params",
+			"iac_params": schema.SetNestedBlock{
+				MarkdownDescription: describeExactlyOneOfParams("IaC scan parameters."),
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(1),
 				},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"severity_threshold": {
-							Type:     schema.TypeString,
-							Required: true,
-							Description: fmt.Sprintf(
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"severity_threshold": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf(
 								"Severity threshold.\n    - Allowed values: %s",
 								utils.SliceOfStringToMDUList(
 									wiz.IACScanSeverity,
 								),
 							),
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.IACScanSeverity,
-									false,
-								),
-							),
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(wiz.IACScanSeverity...),
+							},
 						},
-						"count_threshold": {
-							Type:     schema.TypeInt,
+						"count_threshold": schema.Int64Attribute{
 							Required: true,
 						},
-						"ignored_rules": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+						"ignored_rules": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
 						},
-						"builtin_ignore_tags_enabled": {
-							Type:     schema.TypeBool,
+						// Wiz applies its default when the setting is omitted
+						"builtin_ignore_tags_enabled": schema.BoolAttribute{
 							Optional: true,
+							Computed: true,
 						},
-						"custom_ignore_tags": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"key": {
-										Type:     schema.TypeString,
+						"security_frameworks": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"custom_ignore_tags": schema.SetNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
 										Required: true,
 									},
-									"value": {
-										Type:     schema.TypeString,
+									"value": schema.StringAttribute{
 										Required: true,
 									},
-									"rule_ids": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
+									"rule_ids": schema.ListAttribute{
+										Optional:    true,
+										ElementType: types.StringType,
 									},
-									"ignore_all_rules": {
-										Type:     schema.TypeBool,
+									"ignore_all_rules": schema.BoolAttribute{
 										Optional: true,
+										Computed: true,
 									},
 								},
 							},
 						},
-						"security_frameworks": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
 					},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
-		CreateContext: resourceWizCICDScanPolicyCreate,
-		ReadContext:   resourceWizCICDScanPolicyRead,
-		UpdateContext: resourceWizCICDScanPolicyUpdate,
-		DeleteContext: resourceWizCICDScanPolicyDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+	}
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState, version 0 is the state written by the sdk resource
+func (r *cicdScanPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: upgradeSDKState(
+				"description",
+				"disk_secrets_params.path_allow_list",
+				"iac_params.ignored_rules",
+				"iac_params.security_frameworks",
+				"iac_params.custom_ignore_tags.rule_ids",
+			),
 		},
 	}
 }
 
-// CreateCICDScanPolicy struct
-type CreateCICDScanPolicy struct {
-	CreateCICDScanPolicy wiz.CreateCICDScanPolicyPayload `json:"createCICDScanPolicy"`
+func (r *cicdScanPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	conf, diags := providerConf(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.conf = conf
 }
 
-func getDiskVulnerabilitiesParams(ctx context.Context, d *schema.ResourceData) *wiz.CreateCICDScanPolicyDiskVulnerabilitiesInput {
-	tflog.Info(ctx, "getDiskVulnerabilitiesParams called...")
-
-	// return var
-	var output wiz.CreateCICDScanPolicyDiskVulnerabilitiesInput
-
-	// fetch and walk the structure
-	params := d.Get("disk_vulnerabilities_params").(*schema.Set).List()
-	for _, a := range params {
-		tflog.Trace(ctx, fmt.Sprintf("param: %T %s", a, utils.PrettyPrint(a)))
-		for b, c := range a.(map[string]interface{}) {
-			tflog.Trace(ctx, fmt.Sprintf("b: %T %s", b, b))
-			tflog.Trace(ctx, fmt.Sprintf("c: %T %s", c, c))
-			switch b {
-			case "severity":
-				output.Severity = c.(string)
-			case "package_count_threshold":
-				output.PackageCountThreshold = c.(int)
-			case "ignore_unfixed":
-				output.IgnoreUnfixed = c.(bool)
-			case "package_allow_list":
-				output.PackageAllowList = utils.ConvertListToString(c.([]interface{}))
-			}
+// ValidateConfig requires exactly one params block, blocks are never null so they are checked for elements
+func (r *cicdScanPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var set []string
+	for _, attribute := range cicdScanPolicyParamsAttributes {
+		var params types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &params)...)
+		if params.IsUnknown() {
+			return
 		}
+		if len(params.Elements()) > 0 {
+			set = append(set, attribute)
+		}
+	}
+	if resp.Diagnostics.HasError() || len(set) == 1 {
+		return
 	}
+	resp.Diagnostics.AddError(
+		"Invalid scan policy configuration",
+		fmt.Sprintf("Exactly one of %s must be specified, got: [%s]", strings.Join(cicdScanPolicyParamsAttributes, ", "), strings.Join(set, ", ")),
+	)
+}
 
-	return &output
+// cicdScanPolicyTypes maps the params blocks to the type of the policy they configure
+var cicdScanPolicyTypes = map[string]string{
+	"disk_vulnerabilities_params": cicdScanPolicyTypeVulnerabilities,
+	"disk_secrets_params":         cicdScanPolicyTypeSecrets,
+	"iac_params":                  cicdScanPolicyTypeIAC,
 }
 
-func getDiskSecretsParams(ctx context.Context, d *schema.ResourceData) *wiz.CreateCICDScanPolicyDiskSecretsInput {
-	tflog.Info(ctx, "getDiskSecretsParams called...")
+// ModifyPlan sets the planned type of the policy, it follows the params block
+func (r *cicdScanPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(frameworkScopeWarning(r.conf, "wiz_cicd_scan_policy", req)...)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-	// return var
-	var output wiz.CreateCICDScanPolicyDiskSecretsInput
+	for _, attribute := range cicdScanPolicyParamsAttributes {
+		var params types.Set
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &params)...)
+		if len(params.Elements()) > 0 {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), cicdScanPolicyTypes[attribute])...)
+			return
+		}
+	}
+}
 
-	// fetch and walk the structure
-	params := d.Get("disk_secrets_params").(*schema.Set).List()
-	for _, a := range params {
-		tflog.Trace(ctx, fmt.Sprintf("param: %T %s", a, utils.PrettyPrint(a)))
-		for b, c := range a.(map[string]interface{}) {
-			tflog.Trace(ctx, fmt.Sprintf("b: %T %s", b, b))
-			tflog.Trace(ctx, fmt.Sprintf("c: %T %s", c, c))
-			switch b {
-			case "count_threshold":
-				output.CountThreshold = c.(int)
-			case "path_allow_list":
-				output.PathAllowList = utils.ConvertListToString(c.([]interface{}))
-			}
+// CreateCICDScanPolicy struct
+type CreateCICDScanPolicy struct {
+	CreateCICDScanPolicy wiz.CreateCICDScanPolicyPayload `json:"createCICDScanPolicy"`
+}
+
+// expandDiskVulnerabilitiesParams func - return the vulnerability scan params of the disk_vulnerabilities_params block, nil without a block
+func expandDiskVulnerabilitiesParams(ctx context.Context, params []diskVulnerabilitiesParamsModel) (*wiz.CreateCICDScanPolicyDiskVulnerabilitiesInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var output *wiz.CreateCICDScanPolicyDiskVulnerabilitiesInput
+	for _, p := range params {
+		output = &wiz.CreateCICDScanPolicyDiskVulnerabilitiesInput{
+			Severity:              p.Severity.ValueString(),
+			PackageCountThreshold: int(p.PackageCountThreshold.ValueInt64()),
+			IgnoreUnfixed:         p.IgnoreUnfixed.ValueBool(),
 		}
+		var elementDiags diag.Diagnostics
+		output.PackageAllowList, elementDiags = stringListElements(ctx, p.PackageAllowList)
+		diags.Append(elementDiags...)
 	}
+	return output, diags
+}
 
-	return &output
-}
-
-func getIACParams(ctx context.Context, d *schema.ResourceData) *wiz.CreateCICDScanPolicyIACInput {
-	tflog.Info(ctx, "getIACParams called...")
-
-	// return var
-	var output wiz.CreateCICDScanPolicyIACInput
-	var customTags []*wiz.CICDPolicyCustomIgnoreTagCreateInput
-
-	// fetch and walk the structure
-	params := d.Get("iac_params").(*schema.Set).List()
-	for _, a := range params {
-		tflog.Trace(ctx, fmt.Sprintf("param: %T %s", a, utils.PrettyPrint(a)))
-		for b, c := range a.(map[string]interface{}) {
-			tflog.Trace(ctx, fmt.Sprintf("b: %T %s", b, b))
-			tflog.Trace(ctx, fmt.Sprintf("c: %T %s", c, c))
-			switch b {
-			case "severity_threshold":
-				output.SeverityThreshold = c.(string)
-			case "count_threshold":
-				output.CountThreshold = c.(int)
-			case "ignored_rules":
-				output.IgnoredRules = utils.ConvertListToString(c.([]interface{}))
-			case "builtin_ignore_tags_enabled":
-				output.BuiltinIgnoreTagsEnabled = utils.ConvertBoolToPointer(c.(bool))
-			case "security_frameworks":
-				output.SecurityFrameworks = utils.ConvertListToString(c.([]interface{}))
-			case "custom_ignore_tags":
-				for _, f := range c.(*schema.Set).List() {
-					tflog.Trace(ctx, fmt.Sprintf("f: %T %s", f, f))
-					customTag := &wiz.CICDPolicyCustomIgnoreTagCreateInput{}
-					for g, h := range f.(map[string]interface{}) {
-						tflog.Trace(ctx, fmt.Sprintf("g: %T %s", g, g))
-						tflog.Trace(ctx, fmt.Sprintf("h: %T %s", h, h))
-						switch g {
-						case "key":
-							customTag.Key = h.(string)
-						case "value":
-							customTag.Value = h.(string)
-						case "rule_ids":
-							customTag.RuleIDs = utils.ConvertListToString(h.([]interface{}))
-						case "ignore_all_rules":
-							customTag.IgnoreAllRules = utils.ConvertBoolToPointer(h.(bool))
-						}
-					}
-					tflog.Debug(ctx, fmt.Sprintf("customTag: %s", utils.PrettyPrint(customTag)))
-					customTags = append(customTags, customTag)
-				}
-			}
+// expandDiskSecretsParams func - return the secret scan params of the disk_secrets_params block, nil without a block
+func expandDiskSecretsParams(ctx context.Context, params []diskSecretsParamsModel) (*wiz.CreateCICDScanPolicyDiskSecretsInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var output *wiz.CreateCICDScanPolicyDiskSecretsInput
+	for _, p := range params {
+		output = &wiz.CreateCICDScanPolicyDiskSecretsInput{
+			CountThreshold: int(p.CountThreshold.ValueInt64()),
 		}
+		var elementDiags diag.Diagnostics
+		output.PathAllowList, elementDiags = stringListElements(ctx, p.PathAllowList)
+		diags.Append(elementDiags...)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("customTags: %s", utils.PrettyPrint(customTags)))
-	output.CustomIgnoreTags = customTags
+	return output, diags
+}
 
-	return &output
+// expandIACParams func - return the IaC scan params of the iac_params block, nil without a block
+func expandIACParams(ctx context.Context, params []iacParamsModel) (*wiz.CreateCICDScanPolicyIACInput, diag.Diagnostics) {
+	var diags, elementDiags diag.Diagnostics
+	var output *wiz.CreateCICDScanPolicyIACInput
+	for _, p := range params {
+		output = &wiz.CreateCICDScanPolicyIACInput{
+			SeverityThreshold:        p.SeverityThreshold.ValueString(),
+			CountThreshold:           int(p.CountThreshold.ValueInt64()),
+			BuiltinIgnoreTagsEnabled: boolPointer(p.BuiltinIgnoreTagsEnabled),
+		}
+		output.IgnoredRules, elementDiags = stringListElements(ctx, p.IgnoredRules)
+		diags.Append(elementDiags...)
+		output.SecurityFrameworks, elementDiags = stringListElements(ctx, p.SecurityFrameworks)
+		diags.Append(elementDiags...)
+
+		for _, tag := range p.CustomIgnoreTags {
+			customTag := &wiz.CICDPolicyCustomIgnoreTagCreateInput{
+				Key:            tag.Key.ValueString(),
+				Value:          tag.Value.ValueString(),
+				IgnoreAllRules: boolPointer(tag.IgnoreAllRules),
+			}
+			customTag.RuleIDs, elementDiags = stringListElements(ctx, tag.RuleIDs)
+			diags.Append(elementDiags...)
+			output.CustomIgnoreTags = append(output.CustomIgnoreTags, customTag)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("customTags: %s", utils.PrettyPrint(output.CustomIgnoreTags)))
+	}
+	return output, diags
 }
 
-func resourceWizCICDScanPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizCICDScanPolicyCreate called...")
+func (r *cicdScanPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "cicdScanPolicyResource.Create called...")
+
+	var plan cicdScanPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	// define the graphql query
 	query := `mutation CreateCICDScanPolicy(
@@ -341,173 +430,152 @@ func resourceWizCICDScanPolicyCreate(ctx context.Context, d *schema.ResourceData
 
 	// populate the graphql variables
 	vars := &wiz.CreateCICDScanPolicyInput{}
-	var policyType string
-	vars.Name = d.Get("name").(string)
-	vars.Description = d.Get("description").(string)
-	if d.Get("disk_vulnerabilities_params").(*schema.Set).Len() > 0 {
-		policyType = "CICDScanPolicyParamsVulnerabilities"
-		vars.DiskVulnerabilitiesParams = getDiskVulnerabilitiesParams(ctx, d)
-	}
-	if d.Get("disk_secrets_params").(*schema.Set).Len() > 0 {
-		policyType = "CICDScanPolicyParamsSecrets"
-		vars.DiskSecretsParams = getDiskSecretsParams(ctx, d)
-	}
-	if d.Get("iac_params").(*schema.Set).Len() > 0 {
-		policyType = "CICDScanPolicyParamsIAC"
-		vars.IACParams = getIACParams(ctx, d)
+	vars.Name = plan.Name.ValueString()
+	vars.Description = plan.Description.ValueString()
+	vars.DiskVulnerabilitiesParams, diags = expandDiskVulnerabilitiesParams(ctx, plan.DiskVulnerabilitiesParams)
+	resp.Diagnostics.Append(diags...)
+	vars.DiskSecretsParams, diags = expandDiskSecretsParams(ctx, plan.DiskSecretsParams)
+	resp.Diagnostics.Append(diags...)
+	vars.IACParams, diags = expandIACParams(ctx, plan.IACParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// process the request
 	data := &CreateCICDScanPolicy{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cicd_scan_policy", "create")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
-	}
-
-	// set the id and computed values
-	d.SetId(data.CreateCICDScanPolicy.ScanPolicy.ID)
-	d.Set("builtin", data.CreateCICDScanPolicy.ScanPolicy.Builtin)
-	d.Set("type", policyType)
-
-	return resourceWizCICDScanPolicyRead(ctx, d, m)
-}
-
-func flattenScanPolicyParams(ctx context.Context, paramType string, params interface{}) []interface{} {
-	tflog.Info(ctx, "flattenParams called...")
-
-	// initialize the return var
-	var output = make([]interface{}, 0, 0)
-
-	// initialize the member
-	var myParams = make(map[string]interface{})
-
-	// log the incoming data
-	tflog.Debug(ctx, fmt.Sprintf("Type %s", paramType))
-	tflog.Trace(ctx, fmt.Sprintf("Params %T %s", params, utils.PrettyPrint(params)))
-
-	// populate the structure
-	switch paramType {
-	case "CICDScanPolicyParamsIAC":
-		tflog.Debug(ctx, "Handling CICDScanPolicyParamsIAC")
-
-		// convert generic params to specific type
-		tflog.Debug(ctx, fmt.Sprintf("params %T %s", params, utils.PrettyPrint(params)))
-		jsonString, _ := json.Marshal(params)
-		myCICDScanPolicyParamsIAC := &wiz.CICDScanPolicyParamsIAC{}
-		json.Unmarshal(jsonString, &myCICDScanPolicyParamsIAC)
-		tflog.Debug(
-			ctx,
-			fmt.Sprintf(
-				"myCICDScanPolicyParamsIAC %T %s",
-				myCICDScanPolicyParamsIAC,
-				utils.PrettyPrint(
-					myCICDScanPolicyParamsIAC,
-				),
-			),
-		)
+	requestDiags := client.ProcessRequest(ctx, r.conf, vars, data, query, "cicd_scan_policy", "create")
+	resp.Diagnostics.Append(frameworkDiagnostics(requestDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		myParams["count_threshold"] = myCICDScanPolicyParamsIAC.CountThreshold
-		myParams["builtin_ignore_tags_enabled"] = myCICDScanPolicyParamsIAC.BuiltinIgnoreTagsEnabled
-		myParams["severity_threshold"] = myCICDScanPolicyParamsIAC.SeverityThreshold
+	// set the id
+	plan.ID = types.StringValue(data.CreateCICDScanPolicy.ScanPolicy.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
 
-		var ignoredRules = make([]interface{}, 0, 0)
-		for a, b := range myCICDScanPolicyParamsIAC.IgnoredRules {
-			tflog.Debug(ctx, fmt.Sprintf("a: %T %d", a, a))
-			tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, utils.PrettyPrint(b)))
-			ignoredRules = append(ignoredRules, b.ID)
-		}
-		myParams["ignored_rules"] = ignoredRules
+	r.readAfterApply(ctx, plan, &resp.State, &resp.Diagnostics)
+}
 
-		var securityFrameWorks = make([]interface{}, 0, 0)
-		for a, b := range myCICDScanPolicyParamsIAC.SecurityFrameworks {
-			tflog.Debug(ctx, fmt.Sprintf("a: %T %d", a, a))
-			tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, utils.PrettyPrint(b)))
-			securityFrameWorks = append(securityFrameWorks, b.ID)
+// flattenCICDScanPolicy func - return the data of a scan policy read from Wiz, prior is the state or the plan the policy is compared with
+func flattenCICDScanPolicy(ctx context.Context, policy *wiz.CICDScanPolicy, prior cicdScanPolicyResourceModel) (cicdScanPolicyResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	output := prior
+	output.ID = types.StringValue(policy.ID)
+	output.Name = types.StringValue(policy.Name)
+	output.Description = stringValue(prior.Description, policy.Description)
+	output.Builtin = types.BoolValue(policy.Builtin)
+	output.Type = types.StringValue(policy.ParamsType.Type)
+	output.DiskVulnerabilitiesParams = []diskVulnerabilitiesParamsModel{}
+	output.DiskSecretsParams = []diskSecretsParamsModel{}
+	output.IACParams = []iacParamsModel{}
+
+	// convert generic params to the specific type
+	params, err := json.Marshal(policy.Params)
+	if err != nil {
+		diags.AddError("Unable to read the scan policy params", err.Error())
+		return output, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Type %s, params %s", policy.ParamsType.Type, params))
+
+	switch policy.ParamsType.Type {
+	case cicdScanPolicyTypeIAC:
+		iacParams := &wiz.CICDScanPolicyParamsIAC{}
+		if err := json.Unmarshal(params, iacParams); err != nil {
+			diags.AddError("Unable to read the scan policy params", err.Error())
+			return output, diags
 		}
-		myParams["security_frameworks"] = securityFrameWorks
-
-		var customIgnoreTags = make([]interface{}, 0, 0)
-		for a, b := range myCICDScanPolicyParamsIAC.CustomIgnoreTags {
-			tflog.Debug(ctx, fmt.Sprintf("a: %T %d", a, a))
-			tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, utils.PrettyPrint(b)))
-			var customIgnoreTag = make(map[string]interface{}, 0)
-			customIgnoreTag["ignore_all_rules"] = b.IgnoreAllRules
-			customIgnoreTag["key"] = b.Key
-			customIgnoreTag["value"] = b.Value
-			var rules = make([]interface{}, 0, 0)
-			for c, d := range b.Rules {
-				tflog.Debug(ctx, fmt.Sprintf("c: %T %d", c, c))
-				tflog.Debug(ctx, fmt.Sprintf("d: %T %s", d, utils.PrettyPrint(d)))
-				rules = append(rules, d.ID)
-			}
-			customIgnoreTag["rule_ids"] = rules
-			customIgnoreTags = append(customIgnoreTags, customIgnoreTag)
+		var priorParams iacParamsModel
+		if len(prior.IACParams) > 0 {
+			priorParams = prior.IACParams[0]
 		}
-		myParams["custom_ignore_tags"] = customIgnoreTags
-	case "CICDScanPolicyParamsSecrets":
-		tflog.Debug(ctx, "Handling CICDScanPolicyParamsSecrets")
-
-		// convert generic params to specific type
-		tflog.Debug(ctx, fmt.Sprintf("params %T %s", params, utils.PrettyPrint(params)))
-		jsonString, _ := json.Marshal(params)
-		myCICDScanPolicyParamsSecrets := &wiz.CICDScanPolicyParamsSecrets{}
-		json.Unmarshal(jsonString, &myCICDScanPolicyParamsSecrets)
-		tflog.Debug(
-			ctx,
-			fmt.Sprintf(
-				"myCICDScanPolicyParamsSecrets %T %s",
-				myCICDScanPolicyParamsSecrets,
-				utils.PrettyPrint(
-					myCICDScanPolicyParamsSecrets,
-				),
-			),
-		)
+		flattened, elementDiags := flattenIACParams(ctx, iacParams, priorParams)
+		diags.Append(elementDiags...)
+		output.IACParams = append(output.IACParams, flattened)
+	case cicdScanPolicyTypeSecrets:
+		secretsParams := &wiz.CICDScanPolicyParamsSecrets{}
+		if err := json.Unmarshal(params, secretsParams); err != nil {
+			diags.AddError("Unable to read the scan policy params", err.Error())
+			return output, diags
+		}
+		var priorParams diskSecretsParamsModel
+		if len(prior.DiskSecretsParams) > 0 {
+			priorParams = prior.DiskSecretsParams[0]
+		}
+		pathAllowList, elementDiags := stringListValue(ctx, priorParams.PathAllowList, secretsParams.PathAllowList)
+		diags.Append(elementDiags...)
+		output.DiskSecretsParams = append(output.DiskSecretsParams, diskSecretsParamsModel{
+			CountThreshold: types.Int64Value(int64(secretsParams.CountThreshold)),
+			PathAllowList:  pathAllowList,
+		})
+	case cicdScanPolicyTypeVulnerabilities:
+		vulnerabilitiesParams := &wiz.CICDScanPolicyParamsVulnerabilities{}
+		if err := json.Unmarshal(params, vulnerabilitiesParams); err != nil {
+			diags.AddError("Unable to read the scan policy params", err.Error())
+			return output, diags
+		}
+		var priorParams diskVulnerabilitiesParamsModel
+		if len(prior.DiskVulnerabilitiesParams) > 0 {
+			priorParams = prior.DiskVulnerabilitiesParams[0]
+		}
+		packageAllowList, elementDiags := stringListValue(ctx, priorParams.PackageAllowList, vulnerabilitiesParams.PackageAllowList)
+		diags.Append(elementDiags...)
+		output.DiskVulnerabilitiesParams = append(output.DiskVulnerabilitiesParams, diskVulnerabilitiesParamsModel{
+			Severity:              types.StringValue(vulnerabilitiesParams.Severity),
+			PackageCountThreshold: types.Int64Value(int64(vulnerabilitiesParams.PackageCountThreshold)),
+			IgnoreUnfixed:         types.BoolValue(vulnerabilitiesParams.IgnoreUnfixed),
+			PackageAllowList:      packageAllowList,
+		})
+	}
+
+	return output, diags
+}
 
-		myParams["count_threshold"] = myCICDScanPolicyParamsSecrets.CountThreshold
+// flattenIACParams func - return the iac_params block of IaC scan params, custom ignore tags are matched with prior by key and value
+func flattenIACParams(ctx context.Context, params *wiz.CICDScanPolicyParamsIAC, prior iacParamsModel) (iacParamsModel, diag.Diagnostics) {
+	var diags, elementDiags diag.Diagnostics
+	output := iacParamsModel{
+		SeverityThreshold:        types.StringValue(params.SeverityThreshold),
+		CountThreshold:           types.Int64Value(int64(params.CountThreshold)),
+		BuiltinIgnoreTagsEnabled: types.BoolValue(params.BuiltinIgnoreTagsEnabled),
+		CustomIgnoreTags:         make([]customIgnoreTagModel, 0, len(params.CustomIgnoreTags)),
+	}
 
-		var pathAllowList = make([]interface{}, 0, 0)
-		for a, b := range myCICDScanPolicyParamsSecrets.PathAllowList {
-			tflog.Debug(ctx, fmt.Sprintf("a: %T %d", a, a))
-			tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, utils.PrettyPrint(b)))
-			pathAllowList = append(pathAllowList, b)
-		}
-		myParams["path_allow_list"] = pathAllowList
-	case "CICDScanPolicyParamsVulnerabilities":
-		tflog.Debug(ctx, "Handling CICDScanPolicyParamsVulnerabilities")
-
-		// convert generic params to specific type
-		tflog.Debug(ctx, fmt.Sprintf("params %T %s", params, utils.PrettyPrint(params)))
-		jsonString, _ := json.Marshal(params)
-		myCICDScanPolicyParamsVulnerabilities := &wiz.CICDScanPolicyParamsVulnerabilities{}
-		json.Unmarshal(jsonString, &myCICDScanPolicyParamsVulnerabilities)
-		tflog.Debug(
-			ctx,
-			fmt.Sprintf(
-				"myCICDScanPolicyParamsVulnerabilities %T %s",
-				myCICDScanPolicyParamsVulnerabilities,
-				utils.PrettyPrint(
-					myCICDScanPolicyParamsVulnerabilities,
-				),
-			),
-		)
+	var ignoredRules = make([]string, 0, len(params.IgnoredRules))
+	for _, rule := range params.IgnoredRules {
+		ignoredRules = append(ignoredRules, rule.ID)
+	}
+	output.IgnoredRules, elementDiags = stringListValue(ctx, prior.IgnoredRules, ignoredRules)
+	diags.Append(elementDiags...)
 
-		myParams["ignore_unfixed"] = myCICDScanPolicyParamsVulnerabilities.IgnoreUnfixed
-		myParams["package_count_threshold"] = myCICDScanPolicyParamsVulnerabilities.PackageCountThreshold
-		myParams["severity"] = myCICDScanPolicyParamsVulnerabilities.Severity
+	var securityFrameworks = make([]string, 0, len(params.SecurityFrameworks))
+	for _, framework := range params.SecurityFrameworks {
+		securityFrameworks = append(securityFrameworks, framework.ID)
+	}
+	output.SecurityFrameworks, elementDiags = stringListValue(ctx, prior.SecurityFrameworks, securityFrameworks)
+	diags.Append(elementDiags...)
 
-		var packageAllowList = make([]interface{}, 0, 0)
-		for a, b := range myCICDScanPolicyParamsVulnerabilities.PackageAllowList {
-			tflog.Debug(ctx, fmt.Sprintf("a: %T %d", a, a))
-			tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, utils.PrettyPrint(b)))
-			packageAllowList = append(packageAllowList, b)
+	priorRuleIDs := make(map[string]types.List, len(prior.CustomIgnoreTags))
+	for _, tag := range prior.CustomIgnoreTags {
+		priorRuleIDs[tag.Key.ValueString()+"="+tag.Value.ValueString()] = tag.RuleIDs
+	}
+	for _, tag := range params.CustomIgnoreTags {
+		var ruleIDs = make([]string, 0, len(tag.Rules))
+		for _, rule := range tag.Rules {
+			ruleIDs = append(ruleIDs, rule.ID)
+		}
+		flattened := customIgnoreTagModel{
+			Key:            types.StringValue(tag.Key),
+			Value:          types.StringValue(tag.Value),
+			IgnoreAllRules: types.BoolValue(tag.IgnoreAllRules),
 		}
-		myParams["package_allow_list"] = packageAllowList
+		flattened.RuleIDs, elementDiags = stringListValue(ctx, nullIfMissing(priorRuleIDs, tag.Key+"="+tag.Value), ruleIDs)
+		diags.Append(elementDiags...)
+		output.CustomIgnoreTags = append(output.CustomIgnoreTags, flattened)
 	}
 
-	output = append(output, myParams)
-	tflog.Info(ctx, fmt.Sprintf("flattenScanPolicyParams output: %s", utils.PrettyPrint(output)))
-	return output
+	return output, diags
 }
 
 // ReadCICDScanPolicyPayload struct
@@ -515,20 +583,14 @@ type ReadCICDScanPolicyPayload struct {
 	CICDScanPolicy wiz.CICDScanPolicy `json:"cicdScanPolicy"`
 }
 
-func resourceWizCICDScanPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizCICDScanPolicyRead called...")
-
-	// check the id
-	if d.Id() == "" {
-		return nil
-	}
-
+// readCICDScanPolicy func - read a scan policy, deleted reports whether it was deleted outside of terraform
+func (r *cicdScanPolicyResource) readCICDScanPolicy(ctx context.Context, id string) (policy *wiz.CICDScanPolicy, deleted bool, diags diag.Diagnostics) {
 	// define the graphql query
 	query := `query CICDScanPolicy  (
 	    $id: ID!
 	) {
 	    cicdScanPolicy(
-                id: $id
+	        id: $id
 	    ) {
 	        id
 	        name
@@ -573,57 +635,72 @@ func resourceWizCICDScanPolicyRead(ctx context.Context, d *schema.ResourceData,
 
 	// populate the graphql variables
 	vars := &internal.QueryVariables{}
-	vars.ID = d.Id()
+	vars.ID = id
 
 	// process the request
 	// this query returns http 200 with a payload that contains errors and a null data body
 	// error message: oops! an internal error has occurred. for reference purposes, this is your request id
 	data := &ReadCICDScanPolicyPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cicd_scan_policy", "read")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.CICDScanPolicy.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
-		return diags
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, r.conf, vars, data, query, "cicd_scan_policy", "read")
+	if objectDeletedOrInternalError(ctx, requestDiags, gqlErrors, data.CICDScanPolicy.ID) {
+		return nil, true, nil
 	}
+	return &data.CICDScanPolicy, false, frameworkDiagnostics(requestDiags)
+}
 
-	// set the resource parameters
-	err := d.Set("name", data.CICDScanPolicy.Name)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+func (r *cicdScanPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "cicdScanPolicyResource.Read called...")
+
+	var state cicdScanPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err = d.Set("description", data.CICDScanPolicy.Description)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err = d.Set("builtin", data.CICDScanPolicy.Builtin)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	policy, deleted, diags := r.readCICDScanPolicy(ctx, state.ID.ValueString())
+	if deleted {
+		resp.Diagnostics.Append(removeDeletedFrameworkResource(ctx, &resp.State, "wiz_cicd_scan_policy", state.ID.ValueString())...)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	params := flattenScanPolicyParams(ctx, data.CICDScanPolicy.ParamsType.Type, data.CICDScanPolicy.Params)
-	switch data.CICDScanPolicy.ParamsType.Type {
-	case "CICDScanPolicyParamsIAC":
-		err = d.Set("type", "CICDScanPolicyParamsIAC")
-		err = d.Set("iac_params", params)
-	case "CICDScanPolicyParamsSecrets":
-		err = d.Set("type", "CICDScanPolicyParamsSecrets")
-		err = d.Set("disk_secrets_params", params)
-	case "CICDScanPolicyParamsVulnerabilities":
-		err = d.Set("type", "CICDScanPolicyParamsVulnerabilities")
-		err = d.Set("disk_vulnerabilities_params", params)
+	state, diags = flattenCICDScanPolicy(ctx, policy, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// readAfterApply func - read a scan policy after it was created or updated and store it in the state
+func (r *cicdScanPolicyResource) readAfterApply(ctx context.Context, plan cicdScanPolicyResourceModel, state *tfsdk.State, respDiags *diag.Diagnostics) {
+	policy, deleted, diags := r.readCICDScanPolicy(ctx, plan.ID.ValueString())
+	if deleted {
+		respDiags.AddError("Unable to read scan policy", fmt.Sprintf("scan policy %s was not found after it was applied", plan.ID.ValueString()))
+		return
+	}
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return
 	}
 
-	return diags
+	plan, diags = flattenCICDScanPolicy(ctx, policy, plan)
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return
+	}
+	respDiags.Append(state.Set(ctx, &plan)...)
 }
 
 // UpdateCICDScanPolicy struct
@@ -631,14 +708,75 @@ type UpdateCICDScanPolicy struct {
 	UpdateCICDScanPolicy wiz.UpdateCICDScanPolicyPayload `json:"updateCICDScanPolicy"`
 }
 
-func resourceWizCICDScanPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizCICDScanPolicyUpdate called...")
+// cicdScanPolicyPatch func - return the patch of a scan policy to the params of its configuration
+func cicdScanPolicyPatch(ctx context.Context, plan cicdScanPolicyResourceModel) (*wiz.UpdateCICDScanPolicyPatch, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	patch := &wiz.UpdateCICDScanPolicyPatch{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+	}
+
+	vulnerabilitiesParams, elementDiags := expandDiskVulnerabilitiesParams(ctx, plan.DiskVulnerabilitiesParams)
+	diags.Append(elementDiags...)
+	if vulnerabilitiesParams != nil {
+		patch.DiskVulnerabilitiesParams = &wiz.UpdateCICDScanPolicyDiskVulnerabilitiesPatch{
+			Severity:              vulnerabilitiesParams.Severity,
+			PackageCountThreshold: vulnerabilitiesParams.PackageCountThreshold,
+			IgnoreUnfixed:         utils.ConvertBoolToPointer(vulnerabilitiesParams.IgnoreUnfixed),
+			PackageAllowList:      vulnerabilitiesParams.PackageAllowList,
+		}
+	}
+
+	secretsParams, elementDiags := expandDiskSecretsParams(ctx, plan.DiskSecretsParams)
+	diags.Append(elementDiags...)
+	if secretsParams != nil {
+		patch.DiskSecretsParams = &wiz.UpdateCICDScanPolicyDiskSecretsPatch{
+			CountThreshold: secretsParams.CountThreshold,
+			PathAllowList:  secretsParams.PathAllowList,
+		}
+	}
+
+	iacParams, elementDiags := expandIACParams(ctx, plan.IACParams)
+	diags.Append(elementDiags...)
+	if iacParams != nil {
+		patch.IACParams = &wiz.UpdateCICDScanPolicyIACPatch{
+			SeverityThreshold:        iacParams.SeverityThreshold,
+			CountThreshold:           iacParams.CountThreshold,
+			IgnoredRules:             iacParams.IgnoredRules,
+			BuiltinIgnoreTagsEnabled: iacParams.BuiltinIgnoreTagsEnabled,
+			SecurityFrameworks:       iacParams.SecurityFrameworks,
+			CustomIgnoreTags:         make([]*wiz.CICDPolicyCustomIgnoreTagUpdateInput, 0, len(iacParams.CustomIgnoreTags)),
+		}
+		for _, tag := range iacParams.CustomIgnoreTags {
+			patch.IACParams.CustomIgnoreTags = append(patch.IACParams.CustomIgnoreTags, &wiz.CICDPolicyCustomIgnoreTagUpdateInput{
+				Key:            tag.Key,
+				Value:          tag.Value,
+				RuleIDs:        tag.RuleIDs,
+				IgnoreAllRules: tag.IgnoreAllRules,
+			})
+		}
+	}
+
+	return patch, diags
+}
+
+func (r *cicdScanPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "cicdScanPolicyResource.Update called...")
 
-	// check the id
-	if d.Id() == "" {
-		return nil
+	var plan cicdScanPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// define the graphql query
 	query := `mutation updateCICDScanPolicy(
 	    $input: UpdateCICDScanPolicyInput
@@ -654,133 +792,23 @@ func resourceWizCICDScanPolicyUpdate(ctx context.Context, d *schema.ResourceData
 
 	// populate the graphql variables
 	vars := &wiz.UpdateCICDScanPolicyInput{}
-	vars.ID = d.Id()
-	if d.HasChange("name") {
-		vars.Patch.Name = d.Get("name").(string)
-	}
-	if d.HasChange("description") {
-		vars.Patch.Name = d.Get("description").(string)
-	}
-	// we need to evaluate whether the policy type changed before setting the params
-	if d.Get("disk_vulnerabilities_params").(*schema.Set).Len() > 0 {
-		err := d.Set("type", "CICDScanPolicyParamsVulnerabilities")
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
-	}
-	if d.Get("disk_secrets_params").(*schema.Set).Len() > 0 {
-		err := d.Set("type", "CICDScanPolicyParamsSecrets")
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
-	}
-	if d.Get("iac_params").(*schema.Set).Len() > 0 {
-		err := d.Set("type", "CICDScanPolicyParamsIAC")
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
-	}
-
-	switch d.Get("type") {
-	case "CICDScanPolicyParamsIAC":
-		tflog.Debug(ctx, "Handling updates for CICDScanPolicyParamsIAC")
-		varsType := &wiz.UpdateCICDScanPolicyIACPatch{}
-		varsTypeIgnoreTags := make([]*wiz.CICDPolicyCustomIgnoreTagUpdateInput, 0)
-		for a, b := range d.Get("iac_params").(*schema.Set).List() {
-			tflog.Trace(ctx, fmt.Sprintf("a: (%T) %d", a, a))
-			tflog.Trace(ctx, fmt.Sprintf("b: (%T) %s", b, utils.PrettyPrint(b)))
-			for c, d := range b.(map[string]interface{}) {
-				tflog.Trace(ctx, fmt.Sprintf("c: (%T) %s", c, c))
-				tflog.Trace(ctx, fmt.Sprintf("d: (%T) %s", d, utils.PrettyPrint(d)))
-				switch c {
-				case "count_threshold":
-					varsType.CountThreshold = d.(int)
-				case "severity_threshold":
-					varsType.SeverityThreshold = d.(string)
-				case "builtin_ignore_tags_enabled":
-					varsType.BuiltinIgnoreTagsEnabled = utils.ConvertBoolToPointer(d.(bool))
-				case "ignored_rules":
-					varsType.IgnoredRules = utils.ConvertListToString(d.([]interface{}))
-				case "security_frameworks":
-					varsType.SecurityFrameworks = utils.ConvertListToString(d.([]interface{}))
-				case "custom_ignore_tags":
-					for e, f := range d.(*schema.Set).List() {
-						tflog.Trace(ctx, fmt.Sprintf("e: (%T) %d", e, e))
-						tflog.Trace(ctx, fmt.Sprintf("f: (%T) %s", f, utils.PrettyPrint(f)))
-						varsTypeIgnoreTag := &wiz.CICDPolicyCustomIgnoreTagUpdateInput{}
-						for g, h := range f.(map[string]interface{}) {
-							tflog.Trace(ctx, fmt.Sprintf("g: (%T) %s", g, g))
-							tflog.Trace(ctx, fmt.Sprintf("h: (%T) %s", h, utils.PrettyPrint(h)))
-							switch g {
-							case "key":
-								varsTypeIgnoreTag.Key = h.(string)
-							case "value":
-								varsTypeIgnoreTag.Value = h.(string)
-							case "ignore_all_rules":
-								varsTypeIgnoreTag.IgnoreAllRules = utils.ConvertBoolToPointer(h.(bool))
-							case "rule_ids":
-								varsTypeIgnoreTag.RuleIDs = utils.ConvertListToString(h.([]interface{}))
-							}
-						}
-						tflog.Debug(ctx, fmt.Sprintf("varsTypeIgnoreTag: %s", utils.PrettyPrint(varsTypeIgnoreTag)))
-						varsTypeIgnoreTags = append(varsTypeIgnoreTags, varsTypeIgnoreTag)
-					}
-				}
-			}
-		}
-		varsType.CustomIgnoreTags = varsTypeIgnoreTags
-		vars.Patch.IACParams = varsType
-	case "CICDScanPolicyParamsSecrets":
-		tflog.Debug(ctx, "Handling updates for CICDScanPolicyParamsSecrets")
-		varsType := &wiz.UpdateCICDScanPolicyDiskSecretsPatch{}
-		for a, b := range d.Get("disk_secrets_params").(*schema.Set).List() {
-			tflog.Trace(ctx, fmt.Sprintf("a: (%T) %d", a, a))
-			tflog.Trace(ctx, fmt.Sprintf("b: (%T) %s", b, utils.PrettyPrint(b)))
-			for c, d := range b.(map[string]interface{}) {
-				tflog.Trace(ctx, fmt.Sprintf("c: (%T) %s", c, c))
-				tflog.Trace(ctx, fmt.Sprintf("d: (%T) %s", d, utils.PrettyPrint(d)))
-				switch c {
-				case "count_threshold":
-					varsType.CountThreshold = d.(int)
-				case "path_allow_list":
-					varsType.PathAllowList = utils.ConvertListToString(d.([]interface{}))
-				}
-			}
-		}
-		vars.Patch.DiskSecretsParams = varsType
-	case "CICDScanPolicyParamsVulnerabilities":
-		tflog.Debug(ctx, "Handling updates for CICDScanPolicyParamsVulnerabilities")
-		varsType := &wiz.UpdateCICDScanPolicyDiskVulnerabilitiesPatch{}
-		for a, b := range d.Get("disk_vulnerabilities_params").(*schema.Set).List() {
-			tflog.Trace(ctx, fmt.Sprintf("a: (%T) %d", a, a))
-			tflog.Trace(ctx, fmt.Sprintf("b: (%T) %s", b, utils.PrettyPrint(b)))
-			for c, d := range b.(map[string]interface{}) {
-				tflog.Trace(ctx, fmt.Sprintf("c: (%T) %s", c, c))
-				tflog.Trace(ctx, fmt.Sprintf("d: (%T) %s", d, utils.PrettyPrint(d)))
-				switch c {
-				case "ignore_unfixed":
-					varsType.IgnoreUnfixed = utils.ConvertBoolToPointer(d.(bool))
-				case "package_allow_list":
-					varsType.PackageAllowList = utils.ConvertListToString(d.([]interface{}))
-				case "package_count_threshold":
-					varsType.PackageCountThreshold = d.(int)
-				case "severity":
-					varsType.Severity = d.(string)
-				}
-			}
-		}
-		vars.Patch.DiskVulnerabilitiesParams = varsType
+	vars.ID = plan.ID.ValueString()
+	patch, diags := cicdScanPolicyPatch(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	vars.Patch = *patch
 
 	// process the request
 	data := &UpdateCICDScanPolicy{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cicd_scan_policy", "update")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
+	requestDiags := client.ProcessRequest(ctx, r.conf, vars, data, query, "cicd_scan_policy", "update")
+	resp.Diagnostics.Append(frameworkDiagnostics(requestDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceWizCICDScanPolicyRead(ctx, d, m)
+	r.readAfterApply(ctx, plan, &resp.State, &resp.Diagnostics)
 }
 
 // DeleteCICDScanPolicy struct
@@ -788,14 +816,23 @@ type DeleteCICDScanPolicy struct {
 	DeleteCICDScanPolicy wiz.DeleteCICDScanPolicyPayload `json:"deleteCICDScanPolicy"`
 }
 
-func resourceWizCICDScanPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizCICDScanPolicyDelete called...")
+func (r *cicdScanPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "cicdScanPolicyResource.Delete called...")
 
-	// check the id
-	if d.Id() == "" {
-		return nil
+	var state cicdScanPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// define the graphql query
 	query := `mutation DeleteCICDScanPolicy (
 	    $input: DeleteCICDScanPolicyInput!
@@ -809,15 +846,14 @@ func resourceWizCICDScanPolicyDelete(ctx context.Context, d *schema.ResourceData
 
 	// populate the graphql variables
 	vars := &wiz.DeleteCICDScanPolicyInput{}
-	vars.ID = d.Id()
+	vars.ID = state.ID.ValueString()
 
 	// process the request
-	data := &UpdateCICDScanPolicy{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cicd_scan_policy", "delete")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
-	}
+	data := &DeleteCICDScanPolicy{}
+	requestDiags := client.ProcessRequest(ctx, r.conf, vars, data, query, "cicd_scan_policy", "delete")
+	resp.Diagnostics.Append(frameworkDiagnostics(requestDiags)...)
+}
 
-	return diags
+func (r *cicdScanPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
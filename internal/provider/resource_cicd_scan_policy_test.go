@@ -5,29 +5,28 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"wiz.io/hashicorp/terraform-provider-wiz/internal"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
-func TestFlattenScanPolicyParamsIACNoTags(t *testing.T) {
+func TestFlattenIACParamsNoTags(t *testing.T) {
 	ctx := context.Background()
-	expected := []interface{}{
-		map[string]interface{}{
-			"builtin_ignore_tags_enabled": false,
-			"count_threshold":             3,
-			"custom_ignore_tags":          []interface{}{},
-			"ignored_rules": []interface{}{
-				"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
-				"063fb380-9eda-4c08-a31b-9211ee37bd42",
-			},
-			"security_frameworks": []interface{}{
-				"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
-				"063fb380-9eda-4c08-a31b-9211ee37bd42",
-			},
-			"severity_threshold": "CRITICAL",
-		},
+	expected := iacParamsModel{
+		BuiltinIgnoreTagsEnabled: types.BoolValue(false),
+		CountThreshold:           types.Int64Value(3),
+		CustomIgnoreTags:         []customIgnoreTagModel{},
+		IgnoredRules: stringList(
+			"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
+			"063fb380-9eda-4c08-a31b-9211ee37bd42",
+		),
+		SecurityFrameworks: stringList(
+			"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
+			"063fb380-9eda-4c08-a31b-9211ee37bd42",
+		),
+		SeverityThreshold: types.StringValue("CRITICAL"),
 	}
 	var expanded = &wiz.CICDScanPolicyParamsIAC{
 		BuiltinIgnoreTagsEnabled: false,
@@ -50,57 +49,58 @@ func TestFlattenScanPolicyParamsIACNoTags(t *testing.T) {
 			},
 		},
 	}
-	scanPolicyParamsIAC := flattenScanPolicyParams(ctx, "CICDScanPolicyParamsIAC", expanded)
-	if !reflect.DeepEqual(scanPolicyParamsIAC, expected) {
+	scanPolicyParamsIAC, diags := flattenIACParams(ctx, expanded, iacParamsModel{})
+	if diags.HasError() || !reflect.DeepEqual(scanPolicyParamsIAC, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			scanPolicyParamsIAC,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenScanPolicyParamsIACTags(t *testing.T) {
+func TestFlattenIACParamsTags(t *testing.T) {
 	ctx := context.Background()
-	expected := []interface{}{
-		map[string]interface{}{
-			"builtin_ignore_tags_enabled": false,
-			"count_threshold":             3,
-			"custom_ignore_tags": []interface{}{
-				map[string]interface{}{
-					"ignore_all_rules": false,
-					"key":              "testkey1",
-					"rule_ids": []interface{}{
-						"063fb380-9eda-4c08-a31b-9211ee37bd42",
-					},
-					"value": "testval1",
-				},
-				map[string]interface{}{
-					"ignore_all_rules": false,
-					"key":              "testkey2",
-					"rule_ids": []interface{}{
-						"1f0ee3b5-5404-4b40-bbc8-33a990330ac3",
-						"a1958aa1-b810-4df6-bd82-487cb37c6039",
-					},
-					"value": "testval2",
-				},
-				map[string]interface{}{
-					"ignore_all_rules": true,
-					"key":              "testkey3",
-					"value":            "testval3",
-					"rule_ids":         []interface{}{},
-				},
+
+	// the rule ids of a tag ignoring all rules stay null unless configured
+	expected := iacParamsModel{
+		BuiltinIgnoreTagsEnabled: types.BoolValue(false),
+		CountThreshold:           types.Int64Value(3),
+		CustomIgnoreTags: []customIgnoreTagModel{
+			{
+				IgnoreAllRules: types.BoolValue(false),
+				Key:            types.StringValue("testkey1"),
+				RuleIDs: stringList(
+					"063fb380-9eda-4c08-a31b-9211ee37bd42",
+				),
+				Value: types.StringValue("testval1"),
 			},
-			"ignored_rules": []interface{}{
-				"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
-				"063fb380-9eda-4c08-a31b-9211ee37bd42",
+			{
+				IgnoreAllRules: types.BoolValue(false),
+				Key:            types.StringValue("testkey2"),
+				RuleIDs: stringList(
+					"1f0ee3b5-5404-4b40-bbc8-33a990330ac3",
+					"a1958aa1-b810-4df6-bd82-487cb37c6039",
+				),
+				Value: types.StringValue("testval2"),
 			},
-			"security_frameworks": []interface{}{
-				"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
-				"063fb380-9eda-4c08-a31b-9211ee37bd42",
+			{
+				IgnoreAllRules: types.BoolValue(true),
+				Key:            types.StringValue("testkey3"),
+				Value:          types.StringValue("testval3"),
+				RuleIDs:        types.ListNull(types.StringType),
 			},
-			"severity_threshold": "CRITICAL",
 		},
+		IgnoredRules: stringList(
+			"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
+			"063fb380-9eda-4c08-a31b-9211ee37bd42",
+		),
+		SecurityFrameworks: stringList(
+			"fd7dd0c6-4953-4b36-bc39-004ec3d870db",
+			"063fb380-9eda-4c08-a31b-9211ee37bd42",
+		),
+		SeverityThreshold: types.StringValue("CRITICAL"),
 	}
 	var expanded = &wiz.CICDScanPolicyParamsIAC{
 		BuiltinIgnoreTagsEnabled: false,
@@ -153,109 +153,129 @@ func TestFlattenScanPolicyParamsIACTags(t *testing.T) {
 			},
 		},
 	}
-	scanPolicyParamsIAC := flattenScanPolicyParams(ctx, "CICDScanPolicyParamsIAC", expanded)
-	if !reflect.DeepEqual(scanPolicyParamsIAC, expected) {
+	scanPolicyParamsIAC, diags := flattenIACParams(ctx, expanded, iacParamsModel{})
+	if diags.HasError() || !reflect.DeepEqual(scanPolicyParamsIAC, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			scanPolicyParamsIAC,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenScanPolicyParamsSecrets(t *testing.T) {
+func TestFlattenCICDScanPolicySecrets(t *testing.T) {
 	ctx := context.Background()
-	expected := []interface{}{
-		map[string]interface{}{
-			"count_threshold": 3,
-			"path_allow_list": []interface{}{
+	expected := []diskSecretsParamsModel{
+		{
+			CountThreshold: types.Int64Value(3),
+			PathAllowList: stringList(
 				"/root",
 				"/etc",
-			},
+			),
 		},
 	}
-	var expanded = &wiz.CICDScanPolicyParamsSecrets{
-		CountThreshold: 3,
-		PathAllowList: []string{
-			"/root",
-			"/etc",
+	var policy = &wiz.CICDScanPolicy{
+		ID:         "c9a2ea6c-8b9e-4d79-9c4c-d7c1f1c2b4a3",
+		Name:       "secrets",
+		ParamsType: internal.EnumType{Type: "CICDScanPolicyParamsSecrets"},
+		Params: &wiz.CICDScanPolicyParamsSecrets{
+			CountThreshold: 3,
+			PathAllowList: []string{
+				"/root",
+				"/etc",
+			},
 		},
 	}
-	scanPolicyParamsSecrets := flattenScanPolicyParams(ctx, "CICDScanPolicyParamsSecrets", expanded)
-	if !reflect.DeepEqual(scanPolicyParamsSecrets, expected) {
+	scanPolicy, diags := flattenCICDScanPolicy(ctx, policy, cicdScanPolicyResourceModel{})
+	if diags.HasError() || !reflect.DeepEqual(scanPolicy.DiskSecretsParams, expected) || len(scanPolicy.IACParams) != 0 {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
-			scanPolicyParamsSecrets,
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
+			scanPolicy.DiskSecretsParams,
 			expected,
+			diags,
 		)
 	}
+	if scanPolicy.Type.ValueString() != "CICDScanPolicyParamsSecrets" || !scanPolicy.Description.IsNull() {
+		t.Fatalf("Got:\n\n%#v\n", scanPolicy)
+	}
 }
 
-func TestFlattenScanPolicyParamsVulnerabilitiesTrue(t *testing.T) {
+func TestFlattenCICDScanPolicyVulnerabilitiesTrue(t *testing.T) {
 	ctx := context.Background()
-	expected := []interface{}{
-		map[string]interface{}{
-			"ignore_unfixed": true,
-			"package_allow_list": []interface{}{
+	expected := []diskVulnerabilitiesParamsModel{
+		{
+			IgnoreUnfixed: types.BoolValue(true),
+			PackageAllowList: stringList(
 				"lsof",
 				"tcpdump",
-			},
-			"package_count_threshold": 1,
-			"severity":                "HIGH",
+			),
+			PackageCountThreshold: types.Int64Value(1),
+			Severity:              types.StringValue("HIGH"),
 		},
 	}
-	var expanded = &wiz.CICDScanPolicyParamsVulnerabilities{
-		IgnoreUnfixed: true,
-		PackageAllowList: []string{
-			"lsof",
-			"tcpdump",
+	var policy = &wiz.CICDScanPolicy{
+		ParamsType: internal.EnumType{Type: "CICDScanPolicyParamsVulnerabilities"},
+		Params: &wiz.CICDScanPolicyParamsVulnerabilities{
+			IgnoreUnfixed: true,
+			PackageAllowList: []string{
+				"lsof",
+				"tcpdump",
+			},
+			PackageCountThreshold: 1,
+			Severity:              "HIGH",
 		},
-		PackageCountThreshold: 1,
-		Severity:              "HIGH",
 	}
-	scanPolicyParamsVulnerabilities := flattenScanPolicyParams(ctx, "CICDScanPolicyParamsVulnerabilities", expanded)
-	if !reflect.DeepEqual(scanPolicyParamsVulnerabilities, expected) {
+	scanPolicy, diags := flattenCICDScanPolicy(ctx, policy, cicdScanPolicyResourceModel{})
+	if diags.HasError() || !reflect.DeepEqual(scanPolicy.DiskVulnerabilitiesParams, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
-			scanPolicyParamsVulnerabilities,
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
+			scanPolicy.DiskVulnerabilitiesParams,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenScanPolicyParamsVulnerabilitiesFalse(t *testing.T) {
+func TestFlattenCICDScanPolicyVulnerabilitiesFalse(t *testing.T) {
 	ctx := context.Background()
-	expected := []interface{}{
-		map[string]interface{}{
-			"ignore_unfixed": false,
-			"package_allow_list": []interface{}{
-				"lsof",
-				"tcpdump",
-			},
-			"package_count_threshold": 1,
-			"severity":                "HIGH",
+
+	// an allow list configured empty stays empty
+	expected := []diskVulnerabilitiesParamsModel{
+		{
+			IgnoreUnfixed:         types.BoolValue(false),
+			PackageAllowList:      stringList(),
+			PackageCountThreshold: types.Int64Value(1),
+			Severity:              types.StringValue("HIGH"),
 		},
 	}
-	var expanded = &wiz.CICDScanPolicyParamsVulnerabilities{
-		IgnoreUnfixed: false,
-		PackageAllowList: []string{
-			"lsof",
-			"tcpdump",
+	var policy = &wiz.CICDScanPolicy{
+		ParamsType: internal.EnumType{Type: "CICDScanPolicyParamsVulnerabilities"},
+		Params: &wiz.CICDScanPolicyParamsVulnerabilities{
+			IgnoreUnfixed:         false,
+			PackageCountThreshold: 1,
+			Severity:              "HIGH",
+		},
+	}
+	prior := cicdScanPolicyResourceModel{
+		DiskVulnerabilitiesParams: []diskVulnerabilitiesParamsModel{
+			{
+				PackageAllowList: stringList(),
+			},
 		},
-		PackageCountThreshold: 1,
-		Severity:              "HIGH",
 	}
-	scanPolicyParamsVulnerabilities := flattenScanPolicyParams(ctx, "CICDScanPolicyParamsVulnerabilities", expanded)
-	if !reflect.DeepEqual(scanPolicyParamsVulnerabilities, expected) {
+	scanPolicy, diags := flattenCICDScanPolicy(ctx, policy, prior)
+	if diags.HasError() || !reflect.DeepEqual(scanPolicy.DiskVulnerabilitiesParams, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
-			scanPolicyParamsVulnerabilities,
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
+			scanPolicy.DiskVulnerabilitiesParams,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestGetDiskVulnerabilitiesParams(t *testing.T) {
+func TestExpandDiskVulnerabilitiesParams(t *testing.T) {
 	ctx := context.Background()
 
 	var expected = &wiz.CreateCICDScanPolicyDiskVulnerabilitiesInput{
@@ -268,37 +288,31 @@ func TestGetDiskVulnerabilitiesParams(t *testing.T) {
 		},
 	}
 
-	d := schema.TestResourceDataRaw(
-		t,
-		resourceWizCICDScanPolicy().Schema,
-		map[string]interface{}{
-			"name": "5fdb33cc-5b36-46ee-9d71-b7282d06271a",
-			"disk_vulnerabilities_params": []interface{}{
-				map[string]interface{}{
-					"severity":                "1525fe10-2575-43ef-84bc-6969f81625e7",
-					"package_count_threshold": 3,
-					"ignore_unfixed":          false,
-					"package_allow_list": []interface{}{
-						"f9de6434-38bc-4da7-b6ea-ff02ad55073f",
-						"675a4ecc-71cb-444a-920e-582b06bbadcb",
-					},
-				},
-			},
+	params := []diskVulnerabilitiesParamsModel{
+		{
+			Severity:              types.StringValue("1525fe10-2575-43ef-84bc-6969f81625e7"),
+			PackageCountThreshold: types.Int64Value(3),
+			IgnoreUnfixed:         types.BoolValue(false),
+			PackageAllowList: stringList(
+				"f9de6434-38bc-4da7-b6ea-ff02ad55073f",
+				"675a4ecc-71cb-444a-920e-582b06bbadcb",
+			),
 		},
-	)
+	}
 
-	cicdParams := getDiskVulnerabilitiesParams(ctx, d)
+	cicdParams, diags := expandDiskVulnerabilitiesParams(ctx, params)
 
-	if !reflect.DeepEqual(expected, cicdParams) {
+	if diags.HasError() || !reflect.DeepEqual(expected, cicdParams) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			cicdParams,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestGetDiskSecretsParams(t *testing.T) {
+func TestExpandDiskSecretsParams(t *testing.T) {
 	ctx := context.Background()
 
 	var expected = &wiz.CreateCICDScanPolicyDiskSecretsInput{
@@ -309,37 +323,38 @@ func TestGetDiskSecretsParams(t *testing.T) {
 		},
 	}
 
-	d := schema.TestResourceDataRaw(
-		t,
-		resourceWizCICDScanPolicy().Schema,
-		map[string]interface{}{
-			"name": "5fdb33cc-5b36-46ee-9d71-b7282d06271a",
-			"disk_secrets_params": []interface{}{
-				map[string]interface{}{
-					"count_threshold": 3,
-					"path_allow_list": []interface{}{
-						"f9de6434-38bc-4da7-b6ea-ff02ad55073f",
-						"675a4ecc-71cb-444a-920e-582b06bbadcb",
-					},
-				},
-			},
+	params := []diskSecretsParamsModel{
+		{
+			CountThreshold: types.Int64Value(3),
+			PathAllowList: stringList(
+				"f9de6434-38bc-4da7-b6ea-ff02ad55073f",
+				"675a4ecc-71cb-444a-920e-582b06bbadcb",
+			),
 		},
-	)
+	}
 
-	cicdParams := getDiskSecretsParams(ctx, d)
+	cicdParams, diags := expandDiskSecretsParams(ctx, params)
 
-	if !reflect.DeepEqual(expected, cicdParams) {
+	if diags.HasError() || !reflect.DeepEqual(expected, cicdParams) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			cicdParams,
 			expected,
+			diags,
 		)
 	}
+
+	// no block, no params
+	cicdParams, diags = expandDiskSecretsParams(ctx, nil)
+	if diags.HasError() || cicdParams != nil {
+		t.Fatalf("Got:\n\n%#v\n\nExpected:\n\nnil\n", cicdParams)
+	}
 }
 
-func TestGetIACParams(t *testing.T) {
+func TestExpandIACParams(t *testing.T) {
 	ctx := context.Background()
 
+	// builtin_ignore_tags_enabled left unset is not sent
 	var expected = &wiz.CreateCICDScanPolicyIACInput{
 		SeverityThreshold: "5f45a8d4-24b2-463d-b604-ca532e4ec4d3",
 		CountThreshold:    3,
@@ -347,7 +362,6 @@ func TestGetIACParams(t *testing.T) {
 			"1c1e4a07-8062-4c40-849f-b41417887768",
 			"3f25530e-3295-462e-a300-4ef456291263",
 		},
-		BuiltinIgnoreTagsEnabled: utils.ConvertBoolToPointer(false),
 		CustomIgnoreTags: []*wiz.CICDPolicyCustomIgnoreTagCreateInput{
 			{
 				Key:   "eb9b5425-1635-4cf6-a7b1-44f015795efc",
@@ -365,47 +379,41 @@ func TestGetIACParams(t *testing.T) {
 		},
 	}
 
-	d := schema.TestResourceDataRaw(
-		t,
-		resourceWizCICDScanPolicy().Schema,
-		map[string]interface{}{
-			"name": "5fdb33cc-5b36-46ee-9d71-b7282d06271a",
-			"iac_params": []interface{}{
-				map[string]interface{}{
-					"severity_threshold": "5f45a8d4-24b2-463d-b604-ca532e4ec4d3",
-					"count_threshold":    3,
-					"ignored_rules": []interface{}{
-						"1c1e4a07-8062-4c40-849f-b41417887768",
-						"3f25530e-3295-462e-a300-4ef456291263",
-					},
-					"builtin_ignore_tags_enabled": false,
-					"custom_ignore_tags": []interface{}{
-						map[string]interface{}{
-							"key":   "eb9b5425-1635-4cf6-a7b1-44f015795efc",
-							"value": "cdebef02-fc13-472e-a4cc-2fe4d355c924",
-							"rule_ids": []interface{}{
-								"f53784f1-a676-489b-aae6-6672e7005a5f",
-								"16eae9f8-b2b7-4cfe-9bff-b828f65d459a",
-							},
-							"ignore_all_rules": false,
-						},
-					},
-					"security_frameworks": []interface{}{
-						"5add2652-f417-4050-85de-c1c00c4a6a3c",
-						"57fb812b-1220-41c8-b71b-200abbf32c98",
-					},
+	params := []iacParamsModel{
+		{
+			SeverityThreshold: types.StringValue("5f45a8d4-24b2-463d-b604-ca532e4ec4d3"),
+			CountThreshold:    types.Int64Value(3),
+			IgnoredRules: stringList(
+				"1c1e4a07-8062-4c40-849f-b41417887768",
+				"3f25530e-3295-462e-a300-4ef456291263",
+			),
+			BuiltinIgnoreTagsEnabled: types.BoolUnknown(),
+			CustomIgnoreTags: []customIgnoreTagModel{
+				{
+					Key:   types.StringValue("eb9b5425-1635-4cf6-a7b1-44f015795efc"),
+					Value: types.StringValue("cdebef02-fc13-472e-a4cc-2fe4d355c924"),
+					RuleIDs: stringList(
+						"f53784f1-a676-489b-aae6-6672e7005a5f",
+						"16eae9f8-b2b7-4cfe-9bff-b828f65d459a",
+					),
+					IgnoreAllRules: types.BoolValue(false),
 				},
 			},
+			SecurityFrameworks: stringList(
+				"5add2652-f417-4050-85de-c1c00c4a6a3c",
+				"57fb812b-1220-41c8-b71b-200abbf32c98",
+			),
 		},
-	)
+	}
 
-	cicdParams := getIACParams(ctx, d)
+	cicdParams, diags := expandIACParams(ctx, params)
 
-	if !reflect.DeepEqual(expected, cicdParams) {
+	if diags.HasError() || !reflect.DeepEqual(expected, cicdParams) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
-			cicdParams,
-			expected,
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
+			utils.PrettyPrint(cicdParams),
+			utils.PrettyPrint(expected),
+			diags,
 		)
 	}
 }
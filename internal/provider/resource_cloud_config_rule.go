@@ -132,6 +132,7 @@ func resourceWizCloudConfigurationRule() *schema.Resource {
 		ReadContext:   resourceWizCloudConfigurationRuleRead,
 		UpdateContext: resourceWizCloudConfigurationRuleUpdate,
 		DeleteContext: resourceWizCloudConfigurationRuleDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -302,17 +303,12 @@ func resourceWizCloudConfigurationRuleRead(ctx context.Context, d *schema.Resour
 	// this query returns http 200 with a payload that contains errors and a null data body
 	// error message: record not found for id
 	data := &ReadCloudConfigurationRulePayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cloud_config_rule", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "cloud_config_rule", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.CloudConfigurationRule.ID) {
+		return removeDeletedResource(ctx, d, "wiz_cloud_config_rule")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.CloudConfigurationRule.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -17,7 +17,7 @@ import (
 
 func resourceWizCloudConfigRuleAssociations() *schema.Resource {
 	return &schema.Resource{
-		Description: "Manage associations between cloud configuration rules and security sub-categories. Associations defined outside this resouce will remain untouched through the lifecycle of this resource. Wiz managed cloud configuration rules cannot be associated to Wiz managed security sub-categories. This resource does not support imports; it can, however, overlay existing resources to bring them under management.",
+		Description: "Manage associations between cloud configuration rules and security sub-categories. Associations defined outside this resouce will remain untouched through the lifecycle of this resource. Wiz managed cloud configuration rules cannot be associated to Wiz managed security sub-categories. Existing associations can be imported with an ID of the form `association|<id>,<id>|<security_sub_category_id>,<security_sub_category_id>`, `details` is not stored by Wiz and is set to its default; the resource can also overlay existing associations to bring them under management.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -26,7 +26,7 @@ func resourceWizCloudConfigRuleAssociations() *schema.Resource {
 			},
 			"details": {
 				Type:        schema.TypeString,
-				Description: "Details of the association. This information is not used to manage resources but can serve as notes or documentation for the associations.",
+				Description: "Details of the association. This information is not used to manage resources but can serve as notes or documentation for the associations. It is not stored by Wiz, so an imported association has the default `undefined`.",
 				Optional:    true,
 				Default:     "undefined",
 			},
@@ -53,6 +53,10 @@ func resourceWizCloudConfigRuleAssociations() *schema.Resource {
 		ReadContext:   resourceWizCloudConfigRuleAssociationsRead,
 		UpdateContext: resourceWizCloudConfigRuleAssociationsUpdate,
 		DeleteContext: resourceWizCloudConfigRuleAssociationsDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importAssociation("cloud_config_rule_ids", resourceWizCloudConfigRuleAssociationsRead),
+		},
 	}
 }
 
@@ -73,16 +77,21 @@ func validateCloudConfigRulesExist(ctx context.Context, m interface{}, cloudConf
 	  }
 	}`
 
+	// read every cloud config rule in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(cloudConfigRuleIDs))
 	for _, b := range cloudConfigRuleIDs {
 		vars := &internal.QueryVariables{}
 		vars.ID = b
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadCloudConfigurationRulePayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "cloud_config_rule", "read")
+	if requestDiags.HasError() {
+		return append(diags, requestDiags...)
+	}
 
-		// process the request
-		data := &ReadCloudConfigurationRulePayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cloud_config_rule", "read")
-
+	for i, b := range cloudConfigRuleIDs {
 		// handle any errors
-		if len(requestDiags) > 0 {
+		if len(operations[i].Diags) > 0 {
 			tflog.Debug(ctx, fmt.Sprintf("Cloud config rule not found: %s", b))
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -203,27 +212,34 @@ func resourceWizCloudConfigRuleAssociationsRead(ctx context.Context, d *schema.R
 
 	// iterate over each cloud_config_rule
 	tflog.Debug(ctx, fmt.Sprintf("cloud_config_rule_ids for read: %s", d.Get("cloud_config_rule_ids").([]interface{})))
-	for _, b := range d.Get("cloud_config_rule_ids").([]interface{}) {
-		tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, b))
+	resourceIDs := d.Get("cloud_config_rule_ids").([]interface{})
 
+	// read every cloud config rule in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(resourceIDs))
+	for _, b := range resourceIDs {
 		// populate the graphql variables
 		vars := &internal.QueryVariables{}
 		vars.ID = b.(string)
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadCloudConfigurationRulePayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "cloud_config_rule_association", "read")
+	diags = append(diags, requestDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, b := range resourceIDs {
+		tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, b))
 
-		// process the request
-		data := &ReadCloudConfigurationRulePayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "cloud_config_rule_association", "read")
-		diags = append(diags, requestDiags...)
-		if len(diags) > 0 {
-			tflog.Error(ctx, "Error from API call, resource not found.")
-			if data.CloudConfigurationRule.ID == "" {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Error reading cloud_config_rule",
-					Detail:   fmt.Sprintf("Cloud Config Rule ID: %s", b.(string)),
-				})
-				return diags
-			}
+		data := operations[i].Data.(*ReadCloudConfigurationRulePayload)
+		// a cloud config rule deleted outside of terraform no longer carries the association
+		if objectDeleted(ctx, operations[i].Diags, operations[i].Errors, data.CloudConfigurationRule.ID) {
+			tflog.Info(ctx, fmt.Sprintf("Cloud config rule %s not found", b.(string)))
+			continue
+		}
+		diags = append(diags, operations[i].Diags...)
+		if diags.HasError() {
+			return diags
 		}
 
 		// store the security sub-category ids for the cloud_config_rule in sscids
@@ -242,6 +258,11 @@ func resourceWizCloudConfigRuleAssociationsRead(ctx context.Context, d *schema.R
 		}
 	}
 
+	// the association no longer exists when none of its cloud config rules carry all of the security sub-categories
+	if len(cleanCloudConfigRules) == 0 {
+		return removeDeletedResource(ctx, d, "wiz_cloud_config_rule_associations")
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Clean cloud_config_rules from read operation: %T %s", cleanCloudConfigRules, utils.PrettyPrint(cleanCloudConfigRules)))
 
 	err = d.Set("cloud_config_rule_ids", cleanCloudConfigRules)
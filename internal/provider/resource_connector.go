@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
+)
+
+// forceNewIfAuthParamsChange func - recreate a connector when its auth_params change, they cannot be updated
+// to accommodate for importing resources into state, auth_params set for the first time do not recreate the connector.
+// the sdk does not recreate a resource for changes within a block marked ForceNew, so every changed key is marked instead.
+func forceNewIfAuthParamsChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	old, _ := d.GetChange("auth_params")
+	if len(old.([]interface{})) == 0 {
+		return nil
+	}
+	for _, key := range d.GetChangedKeysPrefix("auth_params") {
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeConnectorConfig func - decode the authParams or extraConfig of a connector into v
+// When certain fields are deprecated, vendor returns null and/or empty string (i.e. cloudTrailConfig)
+// We need to handle to avoid unwanted diffs, below traverses the map to a maximum depth of 5 levels
+func decodeConnectorConfig(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var mapConfig map[string]interface{}
+	err := json.Unmarshal(raw, &mapConfig)
+	if err != nil {
+		return err
+	}
+	utils.RemoveNullAndEmptyValues(mapConfig, 5)
+
+	config, err := json.Marshal(mapConfig)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(config, v)
+}
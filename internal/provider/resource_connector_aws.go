@@ -3,13 +3,10 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"wiz.io/hashicorp/terraform-provider-wiz/internal"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/client"
@@ -19,125 +16,336 @@ import (
 
 func resourceWizConnectorAws() *schema.Resource {
 	return &schema.Resource{
-		Description: "Connectors are used to connect AWS resources to Wiz.",
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Description: "Wiz internal identifier for the connector.",
-				Computed:    true,
-			},
-			"name": {
-				Type:        schema.TypeString,
-				Description: "The connector name.",
-				Required:    true,
+		Description: "Connectors are used to connect AWS resources to Wiz. `auth_params` and `extra_config` were JSON strings before version 1 of the schema, the state is upgraded to the blocks but the configuration has to be rewritten with them.",
+		Schema:      resourceWizConnectorAwsSchema(),
+		// auth_params requires a resource recreation as they cannot be updated.
+		// to accommodate for importing resources into state, we can't use `ForceNew` in the schema definition.
+		CustomizeDiff: forceNewIfAuthParamsChange,
+		// version 1 replaced the JSON strings auth_params and extra_config with blocks
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceWizConnectorAwsV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceWizConnectorAwsStateUpgradeV0,
 			},
-			"enabled": {
-				Type:        schema.TypeBool,
-				Description: "Whether the connector is enabled.",
-				Optional:    true,
-				Default:     true,
+		},
+		CreateContext: resourceWizConnectorAwsCreate,
+		ReadContext:   resourceWizConnectorAwsRead,
+		UpdateContext: resourceWizConnectorAwsUpdate,
+		DeleteContext: resourceWizConnectorAwsDelete,
+		Timeouts:      longResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// resourceWizConnectorAwsSchema func - the schema of wiz_connector_aws
+func resourceWizConnectorAwsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "Wiz internal identifier for the connector.",
+			Computed:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The connector name.",
+			Required:    true,
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Description: "Whether the connector is enabled.",
+			Optional:    true,
+			Default:     true,
+		},
+		"customer_role_arn": {
+			Type:        schema.TypeString,
+			Description: "The AWS customer role arn for Wiz to assume.",
+			Computed:    true,
+		},
+		"excluded_accounts": {
+			Type:        schema.TypeList,
+			Description: "The AWS accounts excluded from the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"customer_role_arn": {
-				Type:        schema.TypeString,
-				Description: "The AWS customer role arn for Wiz to assume.",
-				Computed:    true,
+		},
+		"excluded_ous": {
+			Type:        schema.TypeList,
+			Description: "The AWS OUs excluded from the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"excluded_accounts": {
-				Type:        schema.TypeList,
-				Description: "The AWS accounts excluded from the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		},
+		"audit_log_monitor_enabled": {
+			Type:        schema.TypeBool,
+			Description: "Whether audit log monitor is enabled. Note an advanced license is required.",
+			Computed:    true,
+		},
+		"skip_organization_scan": {
+			Type:        schema.TypeBool,
+			Description: "Whether to skip the organization scan (account-scoped only).",
+			Computed:    true,
+		},
+		"external_id_nonce": {
+			Type:        schema.TypeString,
+			Description: "The AWS external ID / nonce, this will be used for IAM-related dependencies (`sts:ExternalId` conditional trust policies).",
+			Computed:    true,
+		},
+		"opted_in_regions": {
+			Type:        schema.TypeList,
+			Description: "The AWS regions opted in for the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"excluded_ous": {
-				Type:        schema.TypeList,
-				Description: "The AWS OUs excluded from the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "The AWS region for the connector.",
+			Computed:    true,
+		},
+		"events_cloudtrail_bucket_name": {
+			Type:        schema.TypeString,
+			Description: "If using Wiz Cloud Events, the CloudTrail bucket name.",
+			Computed:    true,
+		},
+		"events_cloudtrail_bucket_sub_account": {
+			Type:        schema.TypeString,
+			Description: "If using Wiz Cloud Events and CloudTrail is organizational, the CloudTrail bucket sub account.",
+			Computed:    true,
+		},
+		"events_cloudtrail_organization": {
+			Type:        schema.TypeString,
+			Description: "If using Wiz Cloud Events and CloudTrail is deployed to AWS organizations, the organizational ID.",
+			Computed:    true,
+		},
+		"auth_params": {
+			Type:        schema.TypeList,
+			Description: "The authentication parameters. They cannot be updated, changing them recreates the connector.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"customer_role_arn": {
+						Type:        schema.TypeString,
+						Description: "The AWS customer role arn for Wiz to assume.",
+						Required:    true,
+					},
+					"outpost_id": {
+						Type:        schema.TypeString,
+						Description: "The identifier of the outpost scanning the connected accounts.",
+						Optional:    true,
+					},
+					"disk_analyzer": {
+						Type:        schema.TypeList,
+						Description: "The disk analyzer of the outpost.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"scanner_role_arn": {
+									Type:        schema.TypeString,
+									Description: "The AWS role arn the outpost scanner assumes.",
+									Required:    true,
+								},
+								"scanner_external_id": {
+									Type:        schema.TypeString,
+									Description: "The AWS external ID of the outpost scanner role.",
+									Optional:    true,
+									Sensitive:   true,
+								},
+							},
+						},
+					},
 				},
 			},
-			"audit_log_monitor_enabled": {
-				Type:        schema.TypeBool,
-				Description: "Whether audit log monitor is enabled. Note an advanced license is required.",
-				Computed:    true,
-			},
-			"skip_organization_scan": {
-				Type:        schema.TypeBool,
-				Description: "Whether to skip the organization scan (account-scoped only).",
-				Computed:    true,
-			},
-			"external_id_nonce": {
-				Type:        schema.TypeString,
-				Description: "The AWS external ID / nonce, this will be used for IAM-related dependencies (`sts:ExternalId` conditional trust policies).",
-				Computed:    true,
-			},
-			"opted_in_regions": {
-				Type:        schema.TypeList,
-				Description: "The AWS regions opted in for the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		"extra_config": {
+			// once a setting is applied, Wiz keeps it until it is changed, so the block is computed when it is not configured
+			Type:        schema.TypeList,
+			Description: "Extra configuration for the connector.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"audit_log_monitor_enabled": {
+						Type:        schema.TypeBool,
+						Description: "Whether audit log monitor is enabled. Note an advanced license is required.",
+						Optional:    true,
+						Default:     false,
+					},
+					"cloud_trail": {
+						Type:        schema.TypeList,
+						Description: "The CloudTrail used by Wiz Cloud Events.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"bucket_name": {
+									Type:        schema.TypeString,
+									Description: "The CloudTrail bucket name.",
+									Required:    true,
+								},
+								"bucket_sub_account": {
+									Type:        schema.TypeString,
+									Description: "If CloudTrail is organizational, the CloudTrail bucket sub account.",
+									Optional:    true,
+								},
+								"trail_org": {
+									Type:        schema.TypeString,
+									Description: "If CloudTrail is deployed to AWS organizations, the organizational ID.",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"excluded_accounts": {
+						Type:        schema.TypeList,
+						Description: "The AWS accounts to exclude from the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"excluded_ous": {
+						Type:        schema.TypeList,
+						Description: "The AWS OUs to exclude from the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"included_accounts": {
+						Type:        schema.TypeList,
+						Description: "The AWS accounts to include in the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"opted_in_regions": {
+						Type:        schema.TypeList,
+						Description: "The AWS regions to opt in for the connector, all regions when empty.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"skip_organization_scan": {
+						Type:        schema.TypeBool,
+						Description: "Whether to skip the organization scan (account-scoped only).",
+						Optional:    true,
+						Default:     false,
+					},
 				},
 			},
-			"region": {
-				Type:        schema.TypeString,
-				Description: "The AWS region for the connector.",
-				Computed:    true,
-			},
-			"events_cloudtrail_bucket_name": {
-				Type:        schema.TypeString,
-				Description: "If using Wiz Cloud Events, the CloudTrail bucket name.",
-				Computed:    true,
-			},
-			"events_cloudtrail_bucket_sub_account": {
-				Type:        schema.TypeString,
-				Description: "If using Wiz Cloud Events and CloudTrail is organizational, the CloudTrail bucket sub account.",
-				Computed:    true,
-			},
-			"events_cloudtrail_organization": {
-				Type:        schema.TypeString,
-				Description: "If using Wiz Cloud Events and CloudTrail is deployed to AWS organizations, the organizational ID.",
-				Computed:    true,
-			},
-			"auth_params": {
-				Type:        schema.TypeString,
-				Description: "The authentication parameters. Must be represented in `JSON` format.",
-				Required:    true,
-				Sensitive:   true,
-				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringIsJSON,
-				),
-			},
-			"extra_config": {
-				// these are JSON fields; the schema does not support overrides, once a field is set, future changes require it to be passed
-				Type:        schema.TypeString,
-				Description: "Extra configuration for the connector. Must be represented in `JSON` format.",
-				Optional:    true,
-				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringIsJSON,
-				),
-			},
 		},
-		// auth_params requires a resource recreation as they cannot be updated.
-		// to accommodate for importing resources into state, we can't use `ForceNew` in the schema definition.
-		// we use a customdiff and `ForceNewIfChange` for below attributes for only a change condition.
-		CustomizeDiff: customdiff.All(
-			customdiff.ForceNewIfChange("auth_params", func(ctx context.Context, old, new, meta any) bool {
-				if old.(string) != "" {
-					return old.(string) != new.(string)
-				}
-				return false
+	}
+}
+
+// resourceWizConnectorAwsV0 func - wiz_connector_aws before auth_params and extra_config became blocks
+func resourceWizConnectorAwsV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: schemaWithJSONAttributes(resourceWizConnectorAwsSchema(), "auth_params", "extra_config"),
+	}
+}
+
+// resourceWizConnectorAwsStateUpgradeV0 func - move the JSON auth_params and extra_config of the state into their blocks
+func resourceWizConnectorAwsStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	err := upgradeJSONAttribute(ctx, rawState, "auth_params", flattenConnectorAwsAuthParams)
+	if err != nil {
+		return nil, err
+	}
+	err = upgradeJSONAttribute(ctx, rawState, "extra_config", flattenConnectorAwsExtraConfig)
+	if err != nil {
+		return nil, err
+	}
+	return rawState, nil
+}
+
+// expandConnectorAwsAuthParams func - return the authParams of the connector from its auth_params block
+func expandConnectorAwsAuthParams(d *schema.ResourceData) (json.RawMessage, error) {
+	authParams := wiz.ConnectorAuthParamsAWS{
+		CustomerRoleARN: d.Get("auth_params.0.customer_role_arn").(string),
+		OutpostID:       d.Get("auth_params.0.outpost_id").(string),
+	}
+	if _, ok := d.GetOk("auth_params.0.disk_analyzer"); ok {
+		authParams.DiskAnalyzer = &wiz.ConnectorAuthConfigAWSOutpost{
+			Scanner: wiz.ConnectorAuthConfigAWSOutpostScanner{
+				RoleARN:    d.Get("auth_params.0.disk_analyzer.0.scanner_role_arn").(string),
+				ExternalID: d.Get("auth_params.0.disk_analyzer.0.scanner_external_id").(string),
 			},
-			),
-		),
-		CreateContext: resourceWizConnectorAwsCreate,
-		ReadContext:   resourceWizConnectorAwsRead,
-		UpdateContext: resourceWizConnectorAwsUpdate,
-		DeleteContext: resourceWizConnectorAwsDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		}
+	}
+	return json.Marshal(authParams)
+}
+
+// flattenConnectorAwsAuthParams func - return the auth_params block for the authParams of the connector
+func flattenConnectorAwsAuthParams(authParams wiz.ConnectorAuthParamsAWS) []interface{} {
+	diskAnalyzer := []interface{}{}
+	if authParams.DiskAnalyzer != nil {
+		diskAnalyzer = append(diskAnalyzer, map[string]interface{}{
+			"scanner_role_arn":    authParams.DiskAnalyzer.Scanner.RoleARN,
+			"scanner_external_id": authParams.DiskAnalyzer.Scanner.ExternalID,
+		})
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"customer_role_arn": authParams.CustomerRoleARN,
+			"outpost_id":        authParams.OutpostID,
+			"disk_analyzer":     diskAnalyzer,
+		},
+	}
+}
+
+// expandConnectorAwsExtraConfig func - return the extraConfig of the connector from its extra_config block, nil when it is not set
+func expandConnectorAwsExtraConfig(d *schema.ResourceData) (json.RawMessage, error) {
+	if _, ok := d.GetOk("extra_config"); !ok {
+		return nil, nil
+	}
+	extraConfig := wiz.ConnectorExtraConfigAWS{
+		AuditLogMonitorEnabled: d.Get("extra_config.0.audit_log_monitor_enabled").(bool),
+		ExcludedAccounts:       utils.ConvertListToString(d.Get("extra_config.0.excluded_accounts").([]interface{})),
+		ExcludedOUs:            utils.ConvertListToString(d.Get("extra_config.0.excluded_ous").([]interface{})),
+		IncludedAccounts:       utils.ConvertListToString(d.Get("extra_config.0.included_accounts").([]interface{})),
+		OptedInRegions:         utils.ConvertListToString(d.Get("extra_config.0.opted_in_regions").([]interface{})),
+		SkipOrganizationScan:   d.Get("extra_config.0.skip_organization_scan").(bool),
+	}
+	if _, ok := d.GetOk("extra_config.0.cloud_trail"); ok {
+		extraConfig.CloudTrailConfig = &wiz.ConnectorConfigAWSCloudTrail{
+			BucketName:       d.Get("extra_config.0.cloud_trail.0.bucket_name").(string),
+			BucketSubAccount: d.Get("extra_config.0.cloud_trail.0.bucket_sub_account").(string),
+			TrailOrg:         d.Get("extra_config.0.cloud_trail.0.trail_org").(string),
+		}
+	}
+	return json.Marshal(extraConfig)
+}
+
+// flattenConnectorAwsExtraConfig func - return the extra_config block for the extraConfig of the connector
+func flattenConnectorAwsExtraConfig(extraConfig wiz.ConnectorExtraConfigAWS) []interface{} {
+	cloudTrail := []interface{}{}
+	if extraConfig.CloudTrailConfig != nil && extraConfig.CloudTrailConfig.BucketName != "" {
+		cloudTrail = append(cloudTrail, map[string]interface{}{
+			"bucket_name":        extraConfig.CloudTrailConfig.BucketName,
+			"bucket_sub_account": extraConfig.CloudTrailConfig.BucketSubAccount,
+			"trail_org":          extraConfig.CloudTrailConfig.TrailOrg,
+		})
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"audit_log_monitor_enabled": extraConfig.AuditLogMonitorEnabled,
+			"cloud_trail":               cloudTrail,
+			"excluded_accounts":         utils.ConvertSliceToGenericArray(extraConfig.ExcludedAccounts),
+			"excluded_ous":              utils.ConvertSliceToGenericArray(extraConfig.ExcludedOUs),
+			"included_accounts":         utils.ConvertSliceToGenericArray(extraConfig.IncludedAccounts),
+			"opted_in_regions":          utils.ConvertSliceToGenericArray(extraConfig.OptedInRegions),
+			"skip_organization_scan":    extraConfig.SkipOrganizationScan,
 		},
 	}
 }
@@ -160,8 +368,16 @@ func resourceWizConnectorAwsCreate(ctx context.Context, d *schema.ResourceData,
 	vars.Type = "aws"
 	vars.Enabled = &enabled
 
-	vars.AuthParams = json.RawMessage(d.Get("auth_params").(string))
-	vars.ExtraConfig = json.RawMessage(d.Get("extra_config").(string))
+	authParams, err := expandConnectorAwsAuthParams(d)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	vars.AuthParams = authParams
+	extraConfig, err := expandConnectorAwsExtraConfig(d)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	vars.ExtraConfig = extraConfig
 
 	// process the request
 	data := &CreateConnector{}
@@ -228,21 +444,12 @@ func resourceWizConnectorAwsRead(ctx context.Context, d *schema.ResourceData, m
 
 	// process the request
 	data := &ReadConnectorPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "connector", "read")
-	diags = append(diags, requestDiags...,
-	)
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "connector", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Connector.ID) {
+		return removeDeletedResource(ctx, d, "wiz_connector_aws")
+	}
+	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-		// we are checking if the response does not have an ID and if so we mark the resource as new
-		// once the vendor implements consistent and documented error handling we can
-		// move to an error handling factory to inspect definitive errors and handle accordingly
-		if data.Connector.ID == "" {
-			tflog.Info(ctx, "resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -259,22 +466,12 @@ func resourceWizConnectorAwsRead(ctx context.Context, d *schema.ResourceData, m
 		return append(diags, diag.FromErr(err)...)
 	}
 
-	var mapExtraConfig map[string]interface{}
-	err = json.Unmarshal(data.Connector.ExtraConfig, &mapExtraConfig)
+	var extraConfig wiz.ConnectorExtraConfigAWS
+	err = decodeConnectorConfig(data.Connector.ExtraConfig, &extraConfig)
 	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+		return append(diags, diag.Errorf("unable to unmarshal the extraConfig of the connector: %v", err)...)
 	}
-
-	// When certain fields are deprecated, vendor returns null and/or empty string (i.e. cloudTrailConfig)
-	// We need to handle to avoid unwanted diffs, below traverses the map to a maximum depth of 5 levels
-	utils.RemoveNullAndEmptyValues(mapExtraConfig, 5)
-
-	extraConfig, err := json.Marshal(mapExtraConfig)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
-	}
-
-	err = d.Set("extra_config", string(extraConfig))
+	err = d.Set("extra_config", flattenConnectorAwsExtraConfig(extraConfig))
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
 	}
@@ -368,7 +565,11 @@ func resourceWizConnectorAwsUpdate(ctx context.Context, d *schema.ResourceData,
 		vars.Patch.Enabled = &enabled
 	}
 	if d.HasChange("extra_config") {
-		vars.Patch.ExtraConfig = json.RawMessage(d.Get("extra_config").(string))
+		extraConfig, err := expandConnectorAwsExtraConfig(d)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		vars.Patch.ExtraConfig = extraConfig
 	}
 
 	// process the request
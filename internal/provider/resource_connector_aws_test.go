@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceWizConnectorAwsStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":                "7be792ba-bfd1-46d0-9fba-5f6bc19df4a8",
+		"name":              "example",
+		"enabled":           true,
+		"customer_role_arn": "arn:aws:iam::100000000009:role/wiz-customer",
+		"excluded_accounts": []interface{}{"100000000010"},
+		"auth_params":       `{"customerRoleARN":"arn:aws:iam::100000000009:role/wiz-customer","outpostId":"078862d0-a62f-406c-b966-13445af34c0d","diskAnalyzer":{"scanner":{"roleARN":"arn:aws:iam::100000000009:role/outpost-scanner"}}}`,
+		"extra_config":      `{"auditLogMonitorEnabled":false,"cloudTrailConfig":{"bucketName":"buckethere","bucketSubAccount":"000000000012","trailOrg":"o-myorg"},"excludedAccounts":["100000000010"],"optedInRegions":["us-east-1"],"skipOrganizationScan":true}`,
+	}
+
+	upgraded := upgradeState(t, resourceWizConnectorAws(), 0, rawState)
+	expectedAuthParams := []interface{}{
+		map[string]interface{}{
+			"customer_role_arn": "arn:aws:iam::100000000009:role/wiz-customer",
+			"outpost_id":        "078862d0-a62f-406c-b966-13445af34c0d",
+			"disk_analyzer": []interface{}{
+				map[string]interface{}{
+					"scanner_role_arn":    "arn:aws:iam::100000000009:role/outpost-scanner",
+					"scanner_external_id": "",
+				},
+			},
+		},
+	}
+	expectedExtraConfig := []interface{}{
+		map[string]interface{}{
+			"audit_log_monitor_enabled": false,
+			"cloud_trail": []interface{}{
+				map[string]interface{}{
+					"bucket_name":        "buckethere",
+					"bucket_sub_account": "000000000012",
+					"trail_org":          "o-myorg",
+				},
+			},
+			"excluded_accounts":      []interface{}{"100000000010"},
+			"excluded_ous":           []interface{}{},
+			"included_accounts":      []interface{}{},
+			"opted_in_regions":       []interface{}{"us-east-1"},
+			"skip_organization_scan": true,
+		},
+	}
+	assert.Equal(t, expectedAuthParams, upgraded["auth_params"])
+	assert.Equal(t, expectedExtraConfig, upgraded["extra_config"])
+	assert.Equal(t, "example", upgraded["name"])
+}
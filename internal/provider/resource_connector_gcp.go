@@ -7,9 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"wiz.io/hashicorp/terraform-provider-wiz/internal"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/client"
@@ -30,124 +28,371 @@ const (
 
 func resourceWizConnectorGcp() *schema.Resource {
 	return &schema.Resource{
-		Description: "Connectors are used to connect GCP resources to Wiz.",
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Description: "Wiz internal identifier for the connector.",
-				Computed:    true,
-			},
-			"name": {
-				Type:        schema.TypeString,
-				Description: "The connector name.",
-				Required:    true,
-			},
-			"enabled": {
-				Type:        schema.TypeBool,
-				Description: "Whether the connector is enabled.",
-				Optional:    true,
-				Default:     true,
-			},
-			"is_managed_identity": {
-				Type:        schema.TypeString,
-				Description: "Is managed identity?",
-				Computed:    true,
+		Description: "Connectors are used to connect GCP resources to Wiz. `auth_params` and `extra_config` were JSON strings before version 1 of the schema, the state is upgraded to the blocks but the configuration has to be rewritten with them.",
+		Schema:      resourceWizConnectorGcpSchema(),
+		// auth_params requires a resource recreation as they cannot be updated.
+		// to accommodate for importing resources into state, we can't use `ForceNew` in the schema definition.
+		CustomizeDiff: forceNewIfAuthParamsChange,
+		// version 1 replaced the JSON strings auth_params and extra_config with blocks
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceWizConnectorGcpV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceWizConnectorGcpStateUpgradeV0,
 			},
-			"folder_id": {
-				Type:        schema.TypeString,
-				Description: "The GCP folder ID.",
-				Computed:    true,
+		},
+		CreateContext: resourceWizConnectorGcpCreate,
+		ReadContext:   resourceWizConnectorGcpRead,
+		UpdateContext: resourceWizConnectorGcpUpdate,
+		DeleteContext: resourceWizConnectorGcpDelete,
+		Timeouts:      longResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// resourceWizConnectorGcpSchema func - the schema of wiz_connector_gcp
+func resourceWizConnectorGcpSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "Wiz internal identifier for the connector.",
+			Computed:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The connector name.",
+			Required:    true,
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Description: "Whether the connector is enabled.",
+			Optional:    true,
+			Default:     true,
+		},
+		"is_managed_identity": {
+			Type:        schema.TypeString,
+			Description: "Is managed identity?",
+			Computed:    true,
+		},
+		"folder_id": {
+			Type:        schema.TypeString,
+			Description: "The GCP folder ID.",
+			Computed:    true,
+		},
+		"organization_id": {
+			Type:        schema.TypeString,
+			Description: "The GCP organization ID.",
+			Computed:    true,
+		},
+		"projects": {
+			Type:        schema.TypeList,
+			Description: "The GCP projects to target with the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"organization_id": {
-				Type:        schema.TypeString,
-				Description: "The GCP organization ID.",
-				Computed:    true,
+		},
+		"excluded_projects": {
+			Type:        schema.TypeList,
+			Description: "The GCP projects excluded by the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"projects": {
-				Type:        schema.TypeList,
-				Description: "The GCP projects to target with the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		},
+		"included_folders": {
+			Type:        schema.TypeList,
+			Description: "The GCP folders included by the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"excluded_projects": {
-				Type:        schema.TypeList,
-				Description: "The GCP projects excluded by the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		},
+		"excluded_folders": {
+			Type:        schema.TypeList,
+			Description: "The GCP folders excluded by the connector.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"included_folders": {
-				Type:        schema.TypeList,
-				Description: "The GCP folders included by the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		"audit_log_monitor_enabled": {
+			Type:        schema.TypeBool,
+			Description: "Whether audit log monitor is enabled. Note an advanced license is required.",
+			Computed:    true,
+		},
+
+		"events_topic_name": {
+			Type:        schema.TypeString,
+			Description: "If using Wiz Cloud Events, the Topic Name in format `projects/<project_id>/topics/<topic_id>`.",
+			Computed:    true,
+		},
+		"events_pub_sub_subscription_id": {
+			Type:        schema.TypeString,
+			Description: "If using Wiz Cloud Events, the Pub/Sub Subscription ID.",
+			Computed:    true,
+		},
+		"auth_params": {
+			Type:        schema.TypeList,
+			Description: "The authentication parameters, either a managed identity or the fields of a service account key. They cannot be updated, changing them recreates the connector.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"auth_provider_x509_cert_url": {
+						Type:        schema.TypeString,
+						Description: "The `auth_provider_x509_cert_url` of the service account key.",
+						Optional:    true,
+					},
+					"auth_uri": {
+						Type:        schema.TypeString,
+						Description: "The `auth_uri` of the service account key.",
+						Optional:    true,
+					},
+					"client_email": {
+						Type:        schema.TypeString,
+						Description: "The `client_email` of the service account key.",
+						Optional:    true,
+					},
+					"client_id": {
+						Type:        schema.TypeString,
+						Description: "The `client_id` of the service account key.",
+						Optional:    true,
+					},
+					"client_x509_cert_url": {
+						Type:        schema.TypeString,
+						Description: "The `client_x509_cert_url` of the service account key.",
+						Optional:    true,
+					},
+					"delegate_user": {
+						Type:        schema.TypeString,
+						Description: "The Google Workspace user the service account impersonates.",
+						Optional:    true,
+					},
+					"folder_id": {
+						Type:        schema.TypeString,
+						Description: "The GCP folder ID to connect.",
+						Optional:    true,
+					},
+					"is_managed_identity": {
+						Type:        schema.TypeBool,
+						Description: "Whether Wiz authenticates with a managed identity rather than a service account key.",
+						Optional:    true,
+					},
+					"organization_id": {
+						Type:        schema.TypeString,
+						Description: "The GCP organization ID to connect.",
+						Optional:    true,
+					},
+					"private_key": {
+						Type:        schema.TypeString,
+						Description: "The `private_key` of the service account key.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"private_key_id": {
+						Type:        schema.TypeString,
+						Description: "The `private_key_id` of the service account key.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"project_id": {
+						Type:        schema.TypeString,
+						Description: "The GCP project ID to connect, or the `project_id` of the service account key.",
+						Optional:    true,
+					},
+					"token_uri": {
+						Type:        schema.TypeString,
+						Description: "The `token_uri` of the service account key.",
+						Optional:    true,
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Description: "The `type` of the service account key.",
+						Optional:    true,
+					},
 				},
 			},
-			"excluded_folders": {
-				Type:        schema.TypeList,
-				Description: "The GCP folders excluded by the connector.",
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		"extra_config": {
+			// once a setting is applied, Wiz keeps it until it is changed, so the block is computed when it is not configured
+			Type:        schema.TypeList,
+			Description: "Extra configuration for the connector.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"audit_log_monitor_enabled": {
+						Type:        schema.TypeBool,
+						Description: "Whether audit log monitor is enabled. Note an advanced license is required.",
+						Optional:    true,
+						Default:     false,
+					},
+					"audit_logs_pub_sub": {
+						Type:        schema.TypeList,
+						Description: "The Pub/Sub subscription used by Wiz Cloud Events, when audit log monitor is enabled.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"subscription_id": {
+									Type:        schema.TypeString,
+									Description: "The Pub/Sub Subscription ID.",
+									Required:    true,
+								},
+								"topic_name": {
+									Type:        schema.TypeString,
+									Description: "The Topic Name in format `projects/<project_id>/topics/<topic_id>`.",
+									Required:    true,
+								},
+							},
+						},
+					},
+					"excluded_folders": {
+						Type:        schema.TypeList,
+						Description: "The GCP folders to exclude from the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"excluded_projects": {
+						Type:        schema.TypeList,
+						Description: "The GCP projects to exclude from the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"included_folders": {
+						Type:        schema.TypeList,
+						Description: "The GCP folders to include in the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"projects": {
+						Type:        schema.TypeList,
+						Description: "The GCP projects to target with the connector.",
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
 				},
 			},
-			"audit_log_monitor_enabled": {
-				Type:        schema.TypeBool,
-				Description: "Whether audit log monitor is enabled. Note an advanced license is required.",
-				Computed:    true,
-			},
+		},
+	}
+}
 
-			"events_topic_name": {
-				Type:        schema.TypeString,
-				Description: "If using Wiz Cloud Events, the Topic Name in format `projects/<project_id>/topics/<topic_id>`.",
-				Computed:    true,
-			},
-			"events_pub_sub_subscription_id": {
-				Type:        schema.TypeString,
-				Description: "If using Wiz Cloud Events, the Pub/Sub Subscription ID.",
-				Computed:    true,
-			},
-			"auth_params": {
-				Type:        schema.TypeString,
-				Description: "The authentication parameters. Must be represented in `JSON` format.",
-				Required:    true,
-				Sensitive:   true,
-				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringIsJSON,
-				),
-			},
-			"extra_config": {
-				// these are JSON fields; the schema does not support overrides, once a field is set, future changes require it to be passed
-				Type:        schema.TypeString,
-				Description: "Extra configuration for the connector. Must be represented in `JSON` format.",
-				Optional:    true,
-				ValidateDiagFunc: validation.ToDiagFunc(
-					validation.StringIsJSON,
-				),
-			},
+// resourceWizConnectorGcpV0 func - wiz_connector_gcp before auth_params and extra_config became blocks
+func resourceWizConnectorGcpV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: schemaWithJSONAttributes(resourceWizConnectorGcpSchema(), "auth_params", "extra_config"),
+	}
+}
+
+// resourceWizConnectorGcpStateUpgradeV0 func - move the JSON auth_params and extra_config of the state into their blocks
+func resourceWizConnectorGcpStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	err := upgradeJSONAttribute(ctx, rawState, "auth_params", flattenConnectorGcpAuthParams)
+	if err != nil {
+		return nil, err
+	}
+	err = upgradeJSONAttribute(ctx, rawState, "extra_config", flattenConnectorGcpExtraConfig)
+	if err != nil {
+		return nil, err
+	}
+	return rawState, nil
+}
+
+// expandConnectorGcpAuthParams func - return the authParams of the connector from its auth_params block
+func expandConnectorGcpAuthParams(d *schema.ResourceData) (json.RawMessage, error) {
+	authParams := wiz.ConnectorAuthParamsGCP{
+		AuthProviderX509CertURL: d.Get("auth_params.0.auth_provider_x509_cert_url").(string),
+		AuthURI:                 d.Get("auth_params.0.auth_uri").(string),
+		ClientEmail:             d.Get("auth_params.0.client_email").(string),
+		ClientID:                d.Get("auth_params.0.client_id").(string),
+		ClientX509CertURL:       d.Get("auth_params.0.client_x509_cert_url").(string),
+		DelegateUser:            d.Get("auth_params.0.delegate_user").(string),
+		FolderID:                d.Get("auth_params.0.folder_id").(string),
+		IsManagedIdentity:       d.Get("auth_params.0.is_managed_identity").(bool),
+		OrganizationID:          d.Get("auth_params.0.organization_id").(string),
+		PrivateKey:              d.Get("auth_params.0.private_key").(string),
+		PrivateKeyID:            d.Get("auth_params.0.private_key_id").(string),
+		ProjectID:               d.Get("auth_params.0.project_id").(string),
+		TokenURI:                d.Get("auth_params.0.token_uri").(string),
+		Type:                    d.Get("auth_params.0.type").(string),
+	}
+	return json.Marshal(authParams)
+}
+
+// flattenConnectorGcpAuthParams func - return the auth_params block for the authParams of the connector
+func flattenConnectorGcpAuthParams(authParams wiz.ConnectorAuthParamsGCP) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"auth_provider_x509_cert_url": authParams.AuthProviderX509CertURL,
+			"auth_uri":                    authParams.AuthURI,
+			"client_email":                authParams.ClientEmail,
+			"client_id":                   authParams.ClientID,
+			"client_x509_cert_url":        authParams.ClientX509CertURL,
+			"delegate_user":               authParams.DelegateUser,
+			"folder_id":                   authParams.FolderID,
+			"is_managed_identity":         authParams.IsManagedIdentity,
+			"organization_id":             authParams.OrganizationID,
+			"private_key":                 authParams.PrivateKey,
+			"private_key_id":              authParams.PrivateKeyID,
+			"project_id":                  authParams.ProjectID,
+			"token_uri":                   authParams.TokenURI,
+			"type":                        authParams.Type,
 		},
-		// auth_params requires a resource recreation as they cannot be updated.
-		// to accommodate for importing resources into state, we can't use `ForceNew` in the schema definition.
-		// we use a customdiff and `ForceNewIfChange` for below attributes for only a change condition.
-		CustomizeDiff: customdiff.All(
-			customdiff.ForceNewIfChange("auth_params", func(ctx context.Context, old, new, meta any) bool {
-				if old.(string) != "" {
-					return old.(string) != new.(string)
-				}
-				return false
+	}
+}
+
+// expandConnectorGcpExtraConfig func - return the extraConfig of the connector from its extra_config block, nil when it is not set
+func expandConnectorGcpExtraConfig(d *schema.ResourceData) (json.RawMessage, error) {
+	if _, ok := d.GetOk("extra_config"); !ok {
+		return nil, nil
+	}
+	extraConfig := wiz.ConnectorExtraConfigGCP{
+		AuditLogMonitorEnabled: d.Get("extra_config.0.audit_log_monitor_enabled").(bool),
+		ExcludedFolders:        utils.ConvertListToString(d.Get("extra_config.0.excluded_folders").([]interface{})),
+		ExcludedProjects:       utils.ConvertListToString(d.Get("extra_config.0.excluded_projects").([]interface{})),
+		IncludedFolders:        utils.ConvertListToString(d.Get("extra_config.0.included_folders").([]interface{})),
+		Projects:               utils.ConvertListToString(d.Get("extra_config.0.projects").([]interface{})),
+	}
+	if _, ok := d.GetOk("extra_config.0.audit_logs_pub_sub"); ok {
+		extraConfig.AuditLogsConfig = &wiz.ConnectorConfigGCPAuditLogs{
+			PubSub: wiz.ConnectorConfigGCPPubSub{
+				SubscriptionID: d.Get("extra_config.0.audit_logs_pub_sub.0.subscription_id").(string),
+				TopicName:      d.Get("extra_config.0.audit_logs_pub_sub.0.topic_name").(string),
 			},
-			),
-		),
-		CreateContext: resourceWizConnectorGcpCreate,
-		ReadContext:   resourceWizConnectorGcpRead,
-		UpdateContext: resourceWizConnectorGcpUpdate,
-		DeleteContext: resourceWizConnectorGcpDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		}
+	}
+	return json.Marshal(extraConfig)
+}
+
+// flattenConnectorGcpExtraConfig func - return the extra_config block for the extraConfig of the connector
+func flattenConnectorGcpExtraConfig(extraConfig wiz.ConnectorExtraConfigGCP) []interface{} {
+	pubSub := []interface{}{}
+	if extraConfig.AuditLogsConfig != nil && extraConfig.AuditLogsConfig.PubSub.TopicName != "" {
+		pubSub = append(pubSub, map[string]interface{}{
+			"subscription_id": extraConfig.AuditLogsConfig.PubSub.SubscriptionID,
+			"topic_name":      extraConfig.AuditLogsConfig.PubSub.TopicName,
+		})
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"audit_log_monitor_enabled": extraConfig.AuditLogMonitorEnabled,
+			"audit_logs_pub_sub":        pubSub,
+			"excluded_folders":          utils.ConvertSliceToGenericArray(extraConfig.ExcludedFolders),
+			"excluded_projects":         utils.ConvertSliceToGenericArray(extraConfig.ExcludedProjects),
+			"included_folders":          utils.ConvertSliceToGenericArray(extraConfig.IncludedFolders),
+			"projects":                  utils.ConvertSliceToGenericArray(extraConfig.Projects),
 		},
 	}
 }
@@ -170,8 +415,16 @@ func resourceWizConnectorGcpCreate(ctx context.Context, d *schema.ResourceData,
 	vars.Type = "gcp"
 	vars.Enabled = &enabled
 
-	vars.AuthParams = json.RawMessage(d.Get("auth_params").(string))
-	vars.ExtraConfig = json.RawMessage(d.Get("extra_config").(string))
+	authParams, err := expandConnectorGcpAuthParams(d)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	vars.AuthParams = authParams
+	extraConfig, err := expandConnectorGcpExtraConfig(d)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	vars.ExtraConfig = extraConfig
 
 	// process the request
 	data := &CreateConnector{}
@@ -241,20 +494,12 @@ func resourceWizConnectorGcpRead(ctx context.Context, d *schema.ResourceData, m
 
 	// process the request
 	data := &ReadConnectorPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "connector", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "connector", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Connector.ID) {
+		return removeDeletedResource(ctx, d, "wiz_connector_gcp")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-		// we are checking if the response does not have an ID and if so we mark the resource as new
-		// once the vendor implements consistent and documented error handling we can
-		// move to an error handling factory to inspect definitive errors and handle accordingly
-		if data.Connector.ID == "" {
-			tflog.Info(ctx, "resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -357,7 +602,11 @@ func resourceWizConnectorGcpUpdate(ctx context.Context, d *schema.ResourceData,
 		vars.Patch.Enabled = &enabled
 	}
 	if d.HasChange("extra_config") {
-		vars.Patch.ExtraConfig = json.RawMessage(d.Get("extra_config").(string))
+		extraConfig, err := expandConnectorGcpExtraConfig(d)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		vars.Patch.ExtraConfig = extraConfig
 	}
 
 	// process the request
@@ -448,12 +697,17 @@ func updateExtraConfig(ctx context.Context, data ReadConnectorPayload, d *schema
 	}
 	tflog.Debug(ctx, fmt.Sprintf("mapExtraConfig: %s", mapExtraConfig))
 
-	extraConfig, err := json.Marshal(mapExtraConfig)
+	normalizedExtraConfig, err := json.Marshal(mapExtraConfig)
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
 	}
 
-	err = d.Set("extra_config", string(extraConfig))
+	var extraConfig wiz.ConnectorExtraConfigGCP
+	err = decodeConnectorConfig(normalizedExtraConfig, &extraConfig)
+	if err != nil {
+		return append(diags, diag.Errorf("unable to unmarshal the extraConfig of the connector: %v", err)...)
+	}
+	err = d.Set("extra_config", flattenConnectorGcpExtraConfig(extraConfig))
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
 	}
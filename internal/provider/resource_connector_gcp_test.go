@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
 )
 
 var extraConfigErrorSummary = "Invalid extra configuration"
@@ -45,3 +46,26 @@ func TestAddFieldError(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceWizConnectorGcpStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":              "7be792ba-bfd1-46d0-9fba-5f6bc19df4a8",
+		"name":            "example",
+		"enabled":         true,
+		"organization_id": "o-example",
+		"auth_params":     `{"isManagedIdentity":true,"organization_id":"o-example"}`,
+		"extra_config":    `{"auditLogMonitorEnabled":true,"auditLogsConfig":{"pub_sub":{"subscriptionID":"wiz-sub","topicName":"projects/example/topics/wiz"}},"excludedProjects":["excluded"],"projects":[]}`,
+	}
+
+	upgraded := upgradeState(t, resourceWizConnectorGcp(), 0, rawState)
+	authParams := upgraded["auth_params"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, authParams["is_managed_identity"])
+	assert.Equal(t, "o-example", authParams["organization_id"])
+	assert.Equal(t, "", authParams["private_key"])
+
+	extraConfig := upgraded["extra_config"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, extraConfig["audit_log_monitor_enabled"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"subscription_id": "wiz-sub", "topic_name": "projects/example/topics/wiz"}}, extraConfig["audit_logs_pub_sub"])
+	assert.Equal(t, []interface{}{"excluded"}, extraConfig["excluded_projects"])
+	assert.Equal(t, []interface{}{}, extraConfig["projects"])
+}
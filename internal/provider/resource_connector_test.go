@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForceNewIfAuthParamsChange(t *testing.T) {
+	resource := resourceWizConnectorAws()
+	config := func(outpostID string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "example",
+			"auth_params": []interface{}{
+				map[string]interface{}{
+					"customer_role_arn": "arn:aws:iam::100000000009:role/wiz-customer",
+					"outpost_id":        outpostID,
+				},
+			},
+		})
+	}
+	state := &terraform.InstanceState{
+		ID: "7be792ba-bfd1-46d0-9fba-5f6bc19df4a8",
+		Attributes: map[string]string{
+			"id":                              "7be792ba-bfd1-46d0-9fba-5f6bc19df4a8",
+			"name":                            "example",
+			"enabled":                         "true",
+			"auth_params.#":                   "1",
+			"auth_params.0.customer_role_arn": "arn:aws:iam::100000000009:role/wiz-customer",
+			"auth_params.0.outpost_id":        "078862d0-a62f-406c-b966-13445af34c0d",
+			"auth_params.0.disk_analyzer.#":   "0",
+		},
+	}
+
+	// unchanged auth_params
+	diff, err := resource.Diff(context.Background(), state, config("078862d0-a62f-406c-b966-13445af34c0d"), nil)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+	}
+
+	// a change within the block recreates the connector
+	diff, err = resource.Diff(context.Background(), state, config("00000000-0000-0000-0000-000000000000"), nil)
+	if assert.NoError(t, err) {
+		assert.True(t, diff.RequiresNew())
+	}
+
+	// auth_params set for the first time after an import do not
+	imported := &terraform.InstanceState{
+		ID: state.ID,
+		Attributes: map[string]string{
+			"id":      state.ID,
+			"name":    "example",
+			"enabled": "true",
+		},
+	}
+	diff, err = resource.Diff(context.Background(), imported, config("078862d0-a62f-406c-b966-13445af34c0d"), nil)
+	if assert.NoError(t, err) {
+		assert.False(t, diff.RequiresNew())
+	}
+}
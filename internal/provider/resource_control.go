@@ -111,6 +111,7 @@ func resourceWizControl() *schema.Resource {
 		ReadContext:   resourceWizControlRead,
 		UpdateContext: resourceWizControlUpdate,
 		DeleteContext: resourceWizControlDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -230,17 +231,12 @@ func resourceWizControlRead(ctx context.Context, d *schema.ResourceData, m inter
 	// this query returns http 200 with a payload that contains errors and a null data body
 	// error message: oops! an internal error has occurred. for reference purposes, this is your request id
 	data := &ReadControlPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "control", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "control", "read")
+	if objectDeletedOrInternalError(ctx, requestDiags, gqlErrors, data.Control.ID) {
+		return removeDeletedResource(ctx, d, "wiz_control")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.Control.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
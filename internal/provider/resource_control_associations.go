@@ -17,7 +17,7 @@ import (
 
 func resourceWizControlAssociations() *schema.Resource {
 	return &schema.Resource{
-		Description: "Manage associations between controls and security sub-categories. Associations defined outside this resouce will remain untouched through the lifecycle of this resource. Wiz managed controls cannot be associated to Wiz managed security sub-categories. This resource does not support imports; it can, however, overlay existing resources to bring them under management.",
+		Description: "Manage associations between controls and security sub-categories. Associations defined outside this resouce will remain untouched through the lifecycle of this resource. Wiz managed controls cannot be associated to Wiz managed security sub-categories. Existing associations can be imported with an ID of the form `association|<id>,<id>|<security_sub_category_id>,<security_sub_category_id>`, `details` is not stored by Wiz and is set to its default; the resource can also overlay existing associations to bring them under management.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -26,7 +26,7 @@ func resourceWizControlAssociations() *schema.Resource {
 			},
 			"details": {
 				Type:        schema.TypeString,
-				Description: "Details of the association. This information is not used to manage resources but can serve as notes or documentation for the associations.",
+				Description: "Details of the association. This information is not used to manage resources but can serve as notes or documentation for the associations. It is not stored by Wiz, so an imported association has the default `undefined`.",
 				Optional:    true,
 				Default:     "undefined",
 			},
@@ -52,6 +52,10 @@ func resourceWizControlAssociations() *schema.Resource {
 		ReadContext:   resourceWizControlAssociationsRead,
 		UpdateContext: resourceWizControlAssociationsUpdate,
 		DeleteContext: resourceWizControlAssociationsDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importAssociation("control_ids", resourceWizControlAssociationsRead),
+		},
 	}
 }
 
@@ -68,16 +72,21 @@ func validateControlsExist(ctx context.Context, m interface{}, controlIDs []stri
 	  }
 	}`
 
+	// read every control in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(controlIDs))
 	for _, b := range controlIDs {
 		vars := &internal.QueryVariables{}
 		vars.ID = b
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadControlPayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "control", "read")
+	if requestDiags.HasError() {
+		return append(diags, requestDiags...)
+	}
 
-		// process the request
-		data := &ReadControlPayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "control", "read")
-
+	for i, b := range controlIDs {
 		// handle any errors
-		if len(requestDiags) > 0 {
+		if len(operations[i].Diags) > 0 {
 			tflog.Debug(ctx, fmt.Sprintf("Control not found: %s", b))
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -102,16 +111,21 @@ func validateSecuritySubCategoriesExist(ctx context.Context, m interface{}, secu
 	  }
 	}`
 
+	// read every security sub-category in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(securitySubCategoryIDs))
 	for _, b := range securitySubCategoryIDs {
 		vars := &internal.QueryVariables{}
 		vars.ID = b
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadSecuritySubCategoryPayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "security_sub_category", "read")
+	if requestDiags.HasError() {
+		return append(diags, requestDiags...)
+	}
 
-		// process the request
-		data := &ReadSecuritySubCategoryPayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "security_sub_category", "read")
-
+	for i, b := range securitySubCategoryIDs {
 		// handle any errors
-		if len(requestDiags) > 0 {
+		if len(operations[i].Diags) > 0 {
 			tflog.Debug(ctx, fmt.Sprintf("Security sub-category not found: %s", b))
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -238,27 +252,33 @@ func resourceWizControlAssociationsRead(ctx context.Context, d *schema.ResourceD
 	// iterate over each control
 	tflog.Debug(ctx, fmt.Sprintf("control_ids for read: %s", d.Get("control_ids").([]interface{})))
 	resourceControlIDs := d.Get("control_ids").([]interface{})
-	for _, b := range resourceControlIDs {
-		tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, b))
 
+	// read every control in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(resourceControlIDs))
+	for _, b := range resourceControlIDs {
 		// populate the graphql variables
 		vars := &internal.QueryVariables{}
 		vars.ID = b.(string)
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadControlPayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "control_association", "read")
+	diags = append(diags, requestDiags...)
+	if diags.HasError() {
+		return diags
+	}
 
-		// process the request
-		data := &ReadControlPayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "control_association", "read")
-		diags = append(diags, requestDiags...)
-		if len(diags) > 0 {
-			tflog.Error(ctx, "Error from API call, resource not found.")
-			if data.Control.ID == "" {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Error reading control",
-					Detail:   fmt.Sprintf("Control ID: %s", b.(string)),
-				})
-				return diags
-			}
+	for i, b := range resourceControlIDs {
+		tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, b))
+
+		data := operations[i].Data.(*ReadControlPayload)
+		// a control deleted outside of terraform no longer carries the association
+		if objectDeleted(ctx, operations[i].Diags, operations[i].Errors, data.Control.ID) {
+			tflog.Info(ctx, fmt.Sprintf("Control %s not found", b.(string)))
+			continue
+		}
+		diags = append(diags, operations[i].Diags...)
+		if diags.HasError() {
+			return diags
 		}
 
 		// store the security sub-category ids for the control in sscids
@@ -277,27 +297,32 @@ func resourceWizControlAssociationsRead(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
+	// the association no longer exists when none of its controls carry all of the security sub-categories
+	if len(cleanControls) == 0 {
+		return removeDeletedResource(ctx, d, "wiz_control_associations")
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Clean controls from read operation: %T %s", cleanControls, utils.PrettyPrint(cleanControls)))
 
 	// terraform presents a diff even when the values and their order are the same, so long as Set() is called
 	tflog.Debug(ctx, "Ensuring that we only set control IDs when they have different lengths, or when the orders are different.")
-	var newControlIds []string
+	controlIDsChanged := false
 	resourceControlIDsLen := len(resourceControlIDs)
 	cleanControlsLen := len(cleanControls)
 	if resourceControlIDsLen != cleanControlsLen {
-		newControlIds = cleanControls
+		controlIDsChanged = true
 	} else {
 		for i, resourceControlID := range resourceControlIDs {
 			cleanControlID := cleanControls[i]
 			if resourceControlID != cleanControlID {
-				newControlIds = cleanControls
+				controlIDsChanged = true
 				break
 			}
 		}
 	}
 
-	if len(newControlIds) > 0 {
-		err = d.Set("control_ids", newControlIds)
+	if controlIDsChanged {
+		err = d.Set("control_ids", cleanControls)
 		if err != nil {
 			return append(diags, diag.FromErr(err)...)
 		}
@@ -16,7 +16,7 @@ import (
 
 func resourceWizHostConfigRuleAssociations() *schema.Resource {
 	return &schema.Resource{
-		Description: "Manage associations between host configuration rules and security sub-categories. Associations defined outside this resouce will remain untouched through the lifecycle of this resource. Wiz managed host configuration rules cannot be associated to Wiz managed security sub-categories. This resource does not support imports; it can, however, overlay existing resources to bring them under management.",
+		Description: "Manage associations between host configuration rules and security sub-categories. Associations defined outside this resouce will remain untouched through the lifecycle of this resource. Wiz managed host configuration rules cannot be associated to Wiz managed security sub-categories. Existing associations can be imported with an ID of the form `association|<id>,<id>|<security_sub_category_id>,<security_sub_category_id>`, `details` is not stored by Wiz and is set to its default; the resource can also overlay existing associations to bring them under management.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -25,7 +25,7 @@ func resourceWizHostConfigRuleAssociations() *schema.Resource {
 			},
 			"details": {
 				Type:        schema.TypeString,
-				Description: "Details of the association. This information is not used to manage resources but can serve as notes or documentation for the associations.",
+				Description: "Details of the association. This information is not used to manage resources but can serve as notes or documentation for the associations. It is not stored by Wiz, so an imported association has the default `undefined`.",
 				Optional:    true,
 				Default:     "undefined",
 			},
@@ -52,6 +52,10 @@ func resourceWizHostConfigRuleAssociations() *schema.Resource {
 		ReadContext:   resourceWizHostConfigRuleAssociationsRead,
 		UpdateContext: resourceWizHostConfigRuleAssociationsUpdate,
 		DeleteContext: resourceWizHostConfigRuleAssociationsDelete,
+		Timeouts:      defaultResourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: importAssociation("host_config_rule_ids", resourceWizHostConfigRuleAssociationsRead),
+		},
 	}
 }
 
@@ -71,16 +75,21 @@ func validateHostConfigRulesExist(ctx context.Context, m interface{}, hostConfig
 	  }
 	}`
 
+	// read every host config rule in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(hostConfigRuleIDs))
 	for _, b := range hostConfigRuleIDs {
 		vars := &internal.QueryVariables{}
 		vars.ID = b
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadHostConfigurationRulePayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "host_config_rule", "read")
+	if requestDiags.HasError() {
+		return append(diags, requestDiags...)
+	}
 
-		// process the request
-		data := &ReadHostConfigurationRulePayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "host_config_rule", "read")
-
+	for i, b := range hostConfigRuleIDs {
 		// handle any errors
-		if len(requestDiags) > 0 {
+		if len(operations[i].Diags) > 0 {
 			tflog.Debug(ctx, fmt.Sprintf("Host config rule not found: %s", b))
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -201,27 +210,34 @@ func resourceWizHostConfigRuleAssociationsRead(ctx context.Context, d *schema.Re
 
 	// iterate over each host config rule
 	tflog.Debug(ctx, fmt.Sprintf("host_config_rule_ids for read: %s", d.Get("host_config_rule_ids").([]interface{})))
-	for _, b := range d.Get("host_config_rule_ids").([]interface{}) {
-		tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, b))
+	resourceIDs := d.Get("host_config_rule_ids").([]interface{})
 
+	// read every host config rule in a single batched request
+	operations := make([]*client.BatchOperation, 0, len(resourceIDs))
+	for _, b := range resourceIDs {
 		// populate the graphql variables
 		vars := &internal.QueryVariables{}
 		vars.ID = b.(string)
+		operations = append(operations, &client.BatchOperation{Query: query, Vars: vars, Data: &ReadHostConfigurationRulePayload{}})
+	}
+	requestDiags := client.ProcessBatchRequest(ctx, m, operations, "host_config_rule_association", "read")
+	diags = append(diags, requestDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, b := range resourceIDs {
+		tflog.Debug(ctx, fmt.Sprintf("b: %T %s", b, b))
 
-		// process the request
-		data := &ReadHostConfigurationRulePayload{}
-		requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "host_config_rule_association", "read")
-		diags = append(diags, requestDiags...)
-		if len(diags) > 0 {
-			tflog.Error(ctx, "Error from API call, resource not found.")
-			if data.HostConfigurationRule.ID == "" {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Error reading host configuration rule",
-					Detail:   fmt.Sprintf("Host configuration rule ID: %s", b.(string)),
-				})
-				return diags
-			}
+		data := operations[i].Data.(*ReadHostConfigurationRulePayload)
+		// a host config rule deleted outside of terraform no longer carries the association
+		if objectDeleted(ctx, operations[i].Diags, operations[i].Errors, data.HostConfigurationRule.ID) {
+			tflog.Info(ctx, fmt.Sprintf("Host config rule %s not found", b.(string)))
+			continue
+		}
+		diags = append(diags, operations[i].Diags...)
+		if diags.HasError() {
+			return diags
 		}
 
 		// store the security sub-category ids for the host config rule in sscids
@@ -240,6 +256,11 @@ func resourceWizHostConfigRuleAssociationsRead(ctx context.Context, d *schema.Re
 		}
 	}
 
+	// the association no longer exists when none of its host config rules carry all of the security sub-categories
+	if len(cleanHostConfigRules) == 0 {
+		return removeDeletedResource(ctx, d, "wiz_host_config_rule_associations")
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Clean host config rules from read operation: %T %s", cleanHostConfigRules, utils.PrettyPrint(cleanHostConfigRules)))
 
 	err = d.Set("host_config_rule_ids", cleanHostConfigRules)
@@ -100,6 +100,7 @@ func resourceWizIntegrationAwsSNS() *schema.Resource {
 		ReadContext:   resourceWizIntegrationAwsSNSRead,
 		UpdateContext: resourceWizIntegrationAwsSNSUpdate,
 		DeleteContext: resourceWizIntegrationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -196,17 +197,12 @@ func resourceWizIntegrationAwsSNSRead(ctx context.Context, d *schema.ResourceDat
 	data := &ReadIntegrationPayload{}
 	params := &wiz.AwsSNSIntegrationParams{}
 	data.Integration.Params = params
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "integration_aws_sns", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "integration_aws_sns", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Integration.ID) {
+		return removeDeletedResource(ctx, d, "wiz_integration_aws_sns")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.Integration.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
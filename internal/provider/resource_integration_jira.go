@@ -129,6 +129,7 @@ func resourceWizIntegrationJira() *schema.Resource {
 		ReadContext:   resourceWizIntegrationJiraRead,
 		UpdateContext: resourceWizIntegrationJiraUpdate,
 		DeleteContext: resourceWizIntegrationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -244,17 +245,12 @@ func resourceWizIntegrationJiraRead(ctx context.Context, d *schema.ResourceData,
 	data := &ReadIntegrationPayload{}
 	params := &wiz.JiraIntegrationParams{}
 	data.Integration.Params = params
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "integration_jira", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "integration_jira", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Integration.ID) {
+		return removeDeletedResource(ctx, d, "wiz_integration_jira")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.Integration.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
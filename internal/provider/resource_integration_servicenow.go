@@ -110,6 +110,7 @@ func resourceWizIntegrationServiceNow() *schema.Resource {
 		ReadContext:   resourceWizIntegrationAwsServiceNowRead,
 		UpdateContext: resourceWizIntegrationAwsServiceNowUpdate,
 		DeleteContext: resourceWizIntegrationDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -217,17 +218,12 @@ func resourceWizIntegrationAwsServiceNowRead(ctx context.Context, d *schema.Reso
 	data := &ReadIntegrationPayload{}
 	params := &wiz.ServiceNowIntegrationParams{}
 	data.Integration.Params = params
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "integration_servicenow", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "integration_servicenow", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Integration.ID) {
+		return removeDeletedResource(ctx, d, "wiz_integration_servicenow")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.Integration.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -3,587 +3,447 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"wiz.io/hashicorp/terraform-provider-wiz/internal"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/client"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
-func resourceWizProject() *schema.Resource {
-	return &schema.Resource{
-		Description: "Projects let you group your cloud resources according to their users and/or purposes.",
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Description: "The project name to display in Wiz.",
-				Required:    true,
+var (
+	_ resource.ResourceWithConfigure      = &projectResource{}
+	_ resource.ResourceWithImportState    = &projectResource{}
+	_ resource.ResourceWithModifyPlan     = &projectResource{}
+	_ resource.ResourceWithUpgradeState   = &projectResource{}
+	_ resource.ResourceWithValidateConfig = &projectResource{}
+)
+
+// projectResource manages wiz_project with the plugin framework provider
+type projectResource struct {
+	conf *config.ProviderConf
+}
+
+// NewProjectResource returns the wiz_project resource
+func NewProjectResource() resource.Resource {
+	return &projectResource{}
+}
+
+// projectResourceModel describes the wiz_project resource data
+type projectResourceModel struct {
+	ID                    types.String                        `tfsdk:"id"`
+	Name                  types.String                        `tfsdk:"name"`
+	Description           types.String                        `tfsdk:"description"`
+	Identifiers           types.List                          `tfsdk:"identifiers"`
+	Archived              types.Bool                          `tfsdk:"archived"`
+	IsFolder              types.Bool                          `tfsdk:"is_folder"`
+	BusinessUnit          types.String                        `tfsdk:"business_unit"`
+	ParentProjectID       types.String                        `tfsdk:"parent_project_id"`
+	ProjectOwners         types.List                          `tfsdk:"project_owners"`
+	SecurityChampions     types.List                          `tfsdk:"security_champions"`
+	Slug                  types.String                        `tfsdk:"slug"`
+	RiskProfile           []projectRiskProfileModel           `tfsdk:"risk_profile"`
+	KubernetesClusterLink []projectKubernetesClusterLinkModel `tfsdk:"kubernetes_cluster_link"`
+	CloudAccountLink      []projectCloudAccountLinkModel      `tfsdk:"cloud_account_link"`
+	CloudOrganizationLink []projectCloudOrganizationLinkModel `tfsdk:"cloud_organization_link"`
+	Timeouts              timeouts.Value                      `tfsdk:"timeouts"`
+}
+
+// projectRiskProfileModel describes the risk_profile block
+type projectRiskProfileModel struct {
+	BusinessImpact      types.String `tfsdk:"business_impact"`
+	IsActivelyDeveloped types.String `tfsdk:"is_actively_developed"`
+	HasAuthentication   types.String `tfsdk:"has_authentication"`
+	HasExposedAPI       types.String `tfsdk:"has_exposed_api"`
+	IsInternetFacing    types.String `tfsdk:"is_internet_facing"`
+	IsCustomerFacing    types.String `tfsdk:"is_customer_facing"`
+	StoresData          types.String `tfsdk:"stores_data"`
+	IsRegulated         types.String `tfsdk:"is_regulated"`
+	SensitiveDataTypes  types.List   `tfsdk:"sensitive_data_types"`
+	RegulatoryStandards types.List   `tfsdk:"regulatory_standards"`
+}
+
+// projectKubernetesClusterLinkModel describes a kubernetes_cluster_link block
+type projectKubernetesClusterLinkModel struct {
+	KubernetesCluster types.String `tfsdk:"kubernetes_cluster"`
+	Shared            types.Bool   `tfsdk:"shared"`
+	Environment       types.String `tfsdk:"environment"`
+	Namespaces        types.List   `tfsdk:"namespaces"`
+}
+
+// projectCloudAccountLinkModel describes a cloud_account_link block
+type projectCloudAccountLinkModel struct {
+	CloudAccountID types.String       `tfsdk:"cloud_account_id"`
+	Environment    types.String       `tfsdk:"environment"`
+	Shared         types.Bool         `tfsdk:"shared"`
+	ResourceGroups types.List         `tfsdk:"resource_groups"`
+	ResourceTags   []resourceTagModel `tfsdk:"resource_tags"`
+}
+
+// projectCloudOrganizationLinkModel describes a cloud_organization_link block
+type projectCloudOrganizationLinkModel struct {
+	CloudOrganization types.String       `tfsdk:"cloud_organization"`
+	Environment       types.String       `tfsdk:"environment"`
+	Shared            types.Bool         `tfsdk:"shared"`
+	ResourceGroups    types.List         `tfsdk:"resource_groups"`
+	ResourceTags      []resourceTagModel `tfsdk:"resource_tags"`
+}
+
+// resourceTagModel describes a resource_tags block
+type resourceTagModel struct {
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+// projectLinkAttributes lists the link blocks a folder cannot have
+var projectLinkAttributes = []string{"cloud_account_link", "cloud_organization_link", "kubernetes_cluster_link"}
+
+func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+// yesNoUnknownAttribute func - return a risk profile attribute answered with YES, NO or UNKNOWN
+func yesNoUnknownAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: describeDefault(
+			fmt.Sprintf(
+				"%s\n    - Allowed values: %s",
+				description,
+				utils.SliceOfStringToMDUList(
+					wiz.YesNoUnknown,
+				),
+			),
+			"UNKNOWN",
+		),
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString("UNKNOWN"),
+		Validators: []validator.String{
+			stringvalidator.OneOf(wiz.YesNoUnknown...),
+		},
+	}
+}
+
+// projectEnvironmentAttribute func - return the environment attribute of a project link
+func projectEnvironmentAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: describeDefault(
+			fmt.Sprintf(
+				"The environment.\n    - Allowed values: %s",
+				utils.SliceOfStringToMDUList(
+					wiz.Environment,
+				),
+			),
+			"PRODUCTION",
+		),
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString("PRODUCTION"),
+		Validators: []validator.String{
+			stringvalidator.OneOf(wiz.Environment...),
+		},
+	}
+}
+
+// resourceTagsBlock func - return the resource_tags block of a project link
+func resourceTagsBlock() schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		MarkdownDescription: "Provide a key and value pair for filtering resources. `shared` must be true to define resource_tags.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					Required: true,
+				},
+				"value": schema.StringAttribute{
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Projects let you group your cloud resources according to their users and/or purposes.",
+		Version:             1,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The project name to display in Wiz.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The project description.",
+				Optional:            true,
 			},
-			"description": {
-				Type:        schema.TypeString,
-				Description: "The project description.",
-				Optional:    true,
+			"identifiers": schema.ListAttribute{
+				MarkdownDescription: "Identifiers for the project.",
+				Optional:            true,
+				ElementType:         types.StringType,
 			},
-			"identifiers": {
-				Type:        schema.TypeList,
-				Description: "Identifiers for the project.",
-				Optional:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+			"archived": schema.BoolAttribute{
+				MarkdownDescription: describeDefault("Whether the project is archived/inactive", false),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_folder": schema.BoolAttribute{
+				MarkdownDescription: describeDefault("Whether the project is a folder.", false),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				// projects cannot be changed from folder to non-folder or vice versa.
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
 				},
 			},
-			"archived": {
-				Type:        schema.TypeBool,
-				Description: "Whether the project is archived/inactive",
-				Optional:    true,
-				Default:     false,
+			"business_unit": schema.StringAttribute{
+				MarkdownDescription: "The business unit to which the project belongs.",
+				Optional:            true,
 			},
-			"is_folder": {
-				Type:        schema.TypeBool,
-				Description: "Whether the project is a folder.",
-				Optional:    true,
-				Default:     false,
+			"parent_project_id": schema.StringAttribute{
+				MarkdownDescription: "The parent project ID.",
+				Optional:            true,
 			},
-			"business_unit": {
-				Type:        schema.TypeString,
-				Description: "The business unit to which the project belongs.",
-				Optional:    true,
+			"project_owners": schema.ListAttribute{
+				MarkdownDescription: "A list of project owner IDs.",
+				Optional:            true,
+				ElementType:         types.StringType,
 			},
-			"parent_project_id": {
-				Type:        schema.TypeString,
-				Description: "The parent project ID.",
-				Optional:    true,
+			"security_champions": schema.ListAttribute{
+				MarkdownDescription: "A list of security champions IDs.",
+				Optional:            true,
+				ElementType:         types.StringType,
 			},
-			"project_owners": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "A list of project owner IDs.",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Short identifier for the project. The value must be unique, even against archived projects, so a uuid is generated and used as the slug value.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"security_champions": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "A list of security champions IDs.",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the project.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"slug": {
-				Type:        schema.TypeString,
-				Description: "Short identifier for the project. The value must be unique, even against archived projects, so a uuid is generated and used as the slug value.",
-				Computed:    true,
-			},
-			"id": {
-				Type:        schema.TypeString,
-				Description: "Unique identifier for the project.",
-				Computed:    true,
-			},
-			"risk_profile": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Contains risk profile related properties for the project",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"business_impact": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
+		},
+		Blocks: map[string]schema.Block{
+			"risk_profile": schema.ListNestedBlock{
+				MarkdownDescription: "Contains risk profile related properties for the project",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						// Wiz assigns a business impact to projects created without one
+						"business_impact": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf(
 								"Business impact.\n    - Allowed values: %s",
 								utils.SliceOfStringToMDUList(
 									wiz.BusinessImpact,
 								),
 							),
 							Optional: true,
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.BusinessImpact,
-									false,
-								),
-							),
-						},
-						"is_actively_developed": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Is the project under active development?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
-						},
-						"has_authentication": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Does the project require authentication?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
-						},
-						"has_exposed_api": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Does the project expose an API?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
-						},
-						"is_internet_facing": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Is the project Internet facing?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
-						},
-						"is_customer_facing": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Is the project customer facing?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
-						},
-						"stores_data": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Does the project store data?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
-						},
-						"is_regulated": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"Is the project regulated?\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.YesNoUnknown,
-								),
-							),
-							Optional: true,
-							Default:  "UNKNOWN",
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.YesNoUnknown,
-									false,
-								),
-							),
+							Computed: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(wiz.BusinessImpact...),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 						},
-						"sensitive_data_types": {
-							Type: schema.TypeList,
-							Description: fmt.Sprintf(
+						"is_actively_developed": yesNoUnknownAttribute("Is the project under active development?"),
+						"has_authentication":    yesNoUnknownAttribute("Does the project require authentication?"),
+						"has_exposed_api":       yesNoUnknownAttribute("Does the project expose an API?"),
+						"is_internet_facing":    yesNoUnknownAttribute("Is the project Internet facing?"),
+						"is_customer_facing":    yesNoUnknownAttribute("Is the project customer facing?"),
+						"stores_data":           yesNoUnknownAttribute("Does the project store data?"),
+						"is_regulated":          yesNoUnknownAttribute("Is the project regulated?"),
+						"sensitive_data_types": schema.ListAttribute{
+							MarkdownDescription: fmt.Sprintf(
 								"Sensitive Data Types.\n    - Allowed values: %s",
 								utils.SliceOfStringToMDUList(
 									wiz.ProjectDataType,
 								),
 							),
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-								ValidateDiagFunc: validation.ToDiagFunc(
-									validation.StringInSlice(
-										wiz.ProjectDataType,
-										false,
-									),
-								),
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(wiz.ProjectDataType...)),
 							},
 						},
-						"regulatory_standards": {
-							Type: schema.TypeList,
-							Description: fmt.Sprintf(
+						"regulatory_standards": schema.ListAttribute{
+							MarkdownDescription: fmt.Sprintf(
 								"Regulatory Standards.\n    - Allowed values: %s",
 								utils.SliceOfStringToMDUList(
 									wiz.RegulatoryStandard,
 								),
 							),
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-								ValidateDiagFunc: validation.ToDiagFunc(
-									validation.StringInSlice(
-										wiz.RegulatoryStandard,
-										false,
-									),
-								),
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(wiz.RegulatoryStandard...)),
 							},
 						},
 					},
 				},
 			},
-			"kubernetes_cluster_link": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Associate the project with kubernetes clusters.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"kubernetes_cluster": {
-							Type:        schema.TypeString,
-							Description: "The Wiz internal identifier for the kubernetes cluster.",
-							Required:    true,
+			"kubernetes_cluster_link": schema.SetNestedBlock{
+				MarkdownDescription: "Associate the project with kubernetes clusters.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"kubernetes_cluster": schema.StringAttribute{
+							MarkdownDescription: "The Wiz internal identifier for the kubernetes cluster.",
+							Required:            true,
 						},
-						"shared": {
-							Type:        schema.TypeBool,
-							Description: "Mark the kubernetes cluster as shared, in which case, specific namespaces can be linked. This needs to be set to `true` if `namespaces` are set.",
-							Optional:    true,
-							Default:     true,
+						"shared": schema.BoolAttribute{
+							MarkdownDescription: describeDefault("Mark the kubernetes cluster as shared, in which case, specific namespaces can be linked. This needs to be set to `true` if `namespaces` are set.", true),
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
 						},
-						"environment": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"The environment.\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.Environment,
-								),
-							),
-							Optional: true,
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.Environment,
-									false,
-								),
-							),
-							Default: "PRODUCTION",
-						},
-						"namespaces": {
-							Type:        schema.TypeList,
-							Description: "The kubernetes namespaces to link. `shared` must be set to `true` if namespaces are set.",
-							Optional:    true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+						"environment": projectEnvironmentAttribute(),
+						"namespaces": schema.ListAttribute{
+							MarkdownDescription: "The kubernetes namespaces to link. `shared` must be set to `true` if namespaces are set.",
+							Optional:            true,
+							ElementType:         types.StringType,
 						},
 					},
 				},
 			},
-			"cloud_account_link": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Description: "Please either use this embedded set or the resource wiz_project_cloud_account_link. " +
+			"cloud_account_link": schema.SetNestedBlock{
+				MarkdownDescription: "Please either use this embedded set or the resource wiz_project_cloud_account_link. " +
 					"Associate the project directly with a cloud account by wiz identifier UID to organize all the subscription resources, issues, and findings within this project.",
-
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"cloud_account_id": {
-							Type:        schema.TypeString,
-							Description: "The Wiz internal identifier for the Cloud Account Subscription.",
-							Required:    true,
-						},
-						"environment": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"The environment.\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.Environment,
-								),
-							),
-							Optional: true,
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.Environment,
-									false,
-								),
-							),
-							Default: "PRODUCTION",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"cloud_account_id": schema.StringAttribute{
+							MarkdownDescription: "The Wiz internal identifier for the Cloud Account Subscription.",
+							Required:            true,
 						},
-						"shared": {
-							Type:        schema.TypeBool,
-							Description: "Subscriptions that host a few projects can be marked as ‘shared subscriptions’ and resources can be filtered by tags.",
-							Optional:    true,
-						},
-						"resource_groups": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Please provide a list of resource group identifiers for filtering by resource groups. `shared` must be true to define resource_groups.",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+						"environment": projectEnvironmentAttribute(),
+						// Wiz decides whether the cloud account is shared when it is not set
+						"shared": schema.BoolAttribute{
+							MarkdownDescription: "Subscriptions that host a few projects can be marked as ‘shared subscriptions’ and resources can be filtered by tags.",
+							Optional:            true,
+							Computed:            true,
 						},
-						"resource_tags": {
-							Type:        schema.TypeSet,
-							Description: "Provide a key and value pair for filtering resources. `shared` must be true to define resource_tags.",
-							Optional:    true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"key": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"value": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
+						"resource_groups": schema.ListAttribute{
+							MarkdownDescription: "Please provide a list of resource group identifiers for filtering by resource groups. `shared` must be true to define resource_groups.",
+							Optional:            true,
+							ElementType:         types.StringType,
 						},
 					},
+					Blocks: map[string]schema.Block{
+						"resource_tags": resourceTagsBlock(),
+					},
 				},
 			},
-			"cloud_organization_link": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Associate the project with an organizational link to organize all the subscription resources, issues, and findings within this project.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"cloud_organization": {
-							Type:        schema.TypeString,
-							Description: "The Wiz internal identifier for the Organizational Unit.",
-							Required:    true,
+			"cloud_organization_link": schema.SetNestedBlock{
+				MarkdownDescription: "Associate the project with an organizational link to organize all the subscription resources, issues, and findings within this project.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"cloud_organization": schema.StringAttribute{
+							MarkdownDescription: "The Wiz internal identifier for the Organizational Unit.",
+							Required:            true,
 						},
-						"environment": {
-							Type: schema.TypeString,
-							Description: fmt.Sprintf(
-								"The environment.\n    - Allowed values: %s",
-								utils.SliceOfStringToMDUList(
-									wiz.Environment,
-								),
-							),
-							Optional: true,
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(
-									wiz.Environment,
-									false,
-								),
-							),
-							Default: "PRODUCTION",
+						"environment": projectEnvironmentAttribute(),
+						"shared": schema.BoolAttribute{
+							MarkdownDescription: describeDefault("Subscriptions that host a few projects can be marked as ‘shared subscriptions’ and resources can be filtered by tags.", true),
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
 						},
-						"shared": {
-							Type:        schema.TypeBool,
-							Description: "Subscriptions that host a few projects can be marked as ‘shared subscriptions’ and resources can be filtered by tags.",
-							Optional:    true,
-							Default:     true,
-						},
-						"resource_groups": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Description: "Please provide a list of strings for filtering by resource groups. `shared` must be true to define resource_groups.",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"resource_tags": {
-							Type:        schema.TypeSet,
-							Description: "Provide a key and value pair for filtering resources. `shared` must be true to define resource_tags.",
-							Optional:    true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"key": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"value": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
+						"resource_groups": schema.ListAttribute{
+							MarkdownDescription: "Please provide a list of strings for filtering by resource groups. `shared` must be true to define resource_groups.",
+							Optional:            true,
+							ElementType:         types.StringType,
 						},
 					},
+					Blocks: map[string]schema.Block{
+						"resource_tags": resourceTagsBlock(),
+					},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
-		CustomizeDiff: customdiff.All(
-			// projects cannot be changed from folder to non-folder or vice versa.
-			// to accommodate for importing resources into state, we can't use `ForceNew` in the schema definition.
-			customdiff.ForceNewIfChange("is_folder", func(ctx context.Context, old, new, meta any) bool {
-				return old.(bool) != new.(bool)
-			},
+	}
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState, version 0 is the state written by the sdk resource
+func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: upgradeSDKState(
+				"description",
+				"business_unit",
+				"parent_project_id",
+				"identifiers",
+				"project_owners",
+				"security_champions",
+				"risk_profile.sensitive_data_types",
+				"risk_profile.regulatory_standards",
+				"kubernetes_cluster_link.namespaces",
+				"cloud_account_link.resource_groups",
+				"cloud_organization_link.resource_groups",
 			),
-			func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
-				var errMsgs []string
-
-				if isFolder, ok := diff.Get("is_folder").(bool); ok && isFolder {
-					resources := []string{"cloud_account_link", "cloud_organization_link", "kubernetes_cluster_link"}
-					for _, link := range resources {
-						if _, ok := diff.GetOk(link); ok {
-							errMsgs = append(errMsgs, fmt.Sprintf("'%s' cannot be set if 'is_folder' is true", link))
-						}
-					}
-				}
-				if len(errMsgs) > 0 {
-					var sb strings.Builder
-					for _, errMsg := range errMsgs {
-						sb.WriteString(errMsg)
-						sb.WriteString("; ")
-					}
-					// Remove the last delimiters "; "
-					return fmt.Errorf(sb.String()[:sb.Len()-2])
-				}
-				return nil
-			},
-		),
-		CreateContext: resourceWizProjectCreate,
-		ReadContext:   resourceWizProjectRead,
-		UpdateContext: resourceWizProjectUpdate,
-		DeleteContext: resourceWizProjectDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func getOrganizationLinksVar(ctx context.Context, d *schema.ResourceData) []*wiz.ProjectCloudOrganizationLinkInput {
-	linkSet := d.Get("cloud_organization_link").(*schema.Set).List()
-	var myLinks []*wiz.ProjectCloudOrganizationLinkInput
-	for _, y := range linkSet {
-		var localLink wiz.ProjectCloudOrganizationLinkInput
-		for a, b := range y.(map[string]interface{}) {
-			switch a {
-			case "environment":
-				localLink.Environment = b.(string)
-			case "cloud_organization":
-				localLink.CloudOrganization = b.(string)
-			case "shared":
-				localLink.Shared = b.(bool)
-			case "resource_groups":
-				rgs := utils.ConvertListToString(b.([]interface{}))
-				if len(rgs) > 0 {
-					localLink.ResourceGroups = rgs
-				}
-			case "resource_tags":
-				var myResourceTags []*wiz.ResourceTagInput
-				for _, d := range b.(*schema.Set).List() {
-					var localResourceTag wiz.ResourceTagInput
-					for e, f := range d.(map[string]interface{}) {
-						if e == "key" {
-							localResourceTag.Key = f.(string)
-						}
-						if e == "value" {
-							localResourceTag.Value = f.(string)
-						}
-					}
-					myResourceTags = append(myResourceTags, &localResourceTag)
-				}
-				localLink.ResourceTags = myResourceTags
-			}
-		}
-		myLinks = append(myLinks, &localLink)
-	}
-	return myLinks
+func (r *projectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	conf, diags := providerConf(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.conf = conf
 }
 
-func getKubernetesClusterLinksVar(ctx context.Context, d *schema.ResourceData) []*wiz.ProjectKubernetesClusterLinkInput {
-	clusterSet := d.Get("kubernetes_cluster_link").(*schema.Set).List()
-	var myClusters []*wiz.ProjectKubernetesClusterLinkInput
-	for _, y := range clusterSet {
-		var localCluster wiz.ProjectKubernetesClusterLinkInput
-		for a, b := range y.(map[string]interface{}) {
-			switch a {
-			case "environment":
-				localCluster.Environment = b.(string)
-			case "shared":
-				localCluster.Shared = b.(bool)
-			case "kubernetes_cluster":
-				localCluster.KubernetesCluster = b.(string)
-			case "namespaces":
-				localCluster.Namespaces = utils.ConvertListToString(b.([]interface{}))
-			}
-		}
-		myClusters = append(myClusters, &localCluster)
+// ValidateConfig rejects links on folders, projects cannot be linked to resources once they contain other projects
+func (r *projectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var isFolder types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("is_folder"), &isFolder)...)
+	if resp.Diagnostics.HasError() || !isFolder.ValueBool() {
+		return
 	}
 
-	return myClusters
-}
-
-func getAccountLinksVar(ctx context.Context, d *schema.ResourceData) []*wiz.ProjectCloudAccountLinkInput {
-	accountSet := d.Get("cloud_account_link").(*schema.Set).List()
-	var myAccounts []*wiz.ProjectCloudAccountLinkInput
-	for _, y := range accountSet {
-		var localAccount wiz.ProjectCloudAccountLinkInput
-		for a, b := range y.(map[string]interface{}) {
-			switch a {
-			case "environment":
-				localAccount.Environment = b.(string)
-			case "cloud_account_id":
-				localAccount.CloudAccount = b.(string)
-			case "shared":
-				localAccount.Shared = utils.ConvertBoolToPointer(b.(bool))
-			case "resource_groups":
-				rgs := utils.ConvertListToString(b.([]interface{}))
-				if len(rgs) > 0 {
-					localAccount.ResourceGroups = rgs
-				}
-			case "resource_tags":
-				var myResourceTags []*wiz.ResourceTagInput
-				for _, d := range b.(*schema.Set).List() {
-					var localResourceTag wiz.ResourceTagInput
-					for e, f := range d.(map[string]interface{}) {
-						if e == "key" {
-							localResourceTag.Key = f.(string)
-						}
-						if e == "value" {
-							localResourceTag.Value = f.(string)
-						}
-					}
-					myResourceTags = append(myResourceTags, &localResourceTag)
-				}
-				localAccount.ResourceTags = myResourceTags
-			}
+	for _, link := range projectLinkAttributes {
+		var links types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(link), &links)...)
+		if len(links.Elements()) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(link),
+				"Invalid project configuration",
+				fmt.Sprintf("'%s' cannot be set if 'is_folder' is true", link),
+			)
 		}
-
-		myAccounts = append(myAccounts, &localAccount)
 	}
-	return myAccounts
+}
+
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(frameworkScopeWarning(r.conf, "wiz_project", req)...)
 }
 
 // CreateProject struct
@@ -591,8 +451,22 @@ type CreateProject struct {
 	CreateProject wiz.CreateProjectPayload `json:"createProject"`
 }
 
-func resourceWizProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizProjectCreate called...")
+func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "projectResource.Create called...")
+
+	var plan projectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, longCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	// define the graphql query
 	query := `mutation CreateProject($input: CreateProjectInput!) {
@@ -605,161 +479,133 @@ func resourceWizProjectCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	// populate the graphql variables
 	vars := &wiz.CreateProjectInput{}
-	isFolder := d.Get("is_folder").(bool)
-	vars.IsFolder = &isFolder
-
-	vars.Name = d.Get("name").(string)
-	vars.Description = d.Get("description").(string)
-	vars.BusinessUnit = d.Get("business_unit").(string)
-	vars.CloudOrganizationLinks = getOrganizationLinksVar(ctx, d)
-	vars.CloudAccountLinks = getAccountLinksVar(ctx, d)
-	vars.Identifiers = utils.ConvertListToString(d.Get("identifiers").([]interface{}))
-	vars.KubernetesClusterLinks = getKubernetesClusterLinksVar(ctx, d)
-	vars.RiskProfile.BusinessImpact = d.Get("risk_profile.0.business_impact").(string)
-	vars.RiskProfile.IsActivelyDeveloped = d.Get("risk_profile.0.is_actively_developed").(string)
-	vars.RiskProfile.HasAuthentication = d.Get("risk_profile.0.has_authentication").(string)
-	vars.RiskProfile.HasExposedAPI = d.Get("risk_profile.0.has_exposed_api").(string)
-	vars.RiskProfile.IsInternetFacing = d.Get("risk_profile.0.is_internet_facing").(string)
-	vars.RiskProfile.IsCustomerFacing = d.Get("risk_profile.0.is_customer_facing").(string)
-	vars.RiskProfile.StoresData = d.Get("risk_profile.0.stores_data").(string)
-	vars.RiskProfile.IsRegulated = d.Get("risk_profile.0.is_regulated").(string)
-	vars.RiskProfile.SensitiveDataTypes = utils.ConvertListToString(d.Get("risk_profile.0.sensitive_data_types").([]interface{}))
-	vars.RiskProfile.RegulatoryStandards = utils.ConvertListToString(d.Get("risk_profile.0.regulatory_standards").([]interface{}))
-	vars.ParentProjectID = d.Get("parent_project_id").(string)
-	vars.ProjectOwners = utils.ConvertListToString(d.Get("project_owners").([]interface{}))
-	vars.SecurityChampion = utils.ConvertListToString(d.Get("security_champions").([]interface{}))
+	vars.IsFolder = boolPointer(plan.IsFolder)
+	vars.Name = plan.Name.ValueString()
+	vars.Description = plan.Description.ValueString()
+	vars.BusinessUnit = plan.BusinessUnit.ValueString()
+	vars.ParentProjectID = plan.ParentProjectID.ValueString()
 	vars.Slug = uuid.New().String()
+	vars.CloudOrganizationLinks, diags = expandProjectCloudOrganizationLinks(ctx, plan.CloudOrganizationLink)
+	resp.Diagnostics.Append(diags...)
+	vars.CloudAccountLinks, diags = expandProjectCloudAccountLinks(ctx, plan.CloudAccountLink)
+	resp.Diagnostics.Append(diags...)
+	vars.KubernetesClusterLinks, diags = expandProjectKubernetesClusterLinks(ctx, plan.KubernetesClusterLink)
+	resp.Diagnostics.Append(diags...)
+	riskProfile, diags := expandProjectRiskProfile(ctx, plan.RiskProfile)
+	resp.Diagnostics.Append(diags...)
+	vars.RiskProfile = *riskProfile
+	vars.Identifiers, diags = stringListElements(ctx, plan.Identifiers)
+	resp.Diagnostics.Append(diags...)
+	vars.ProjectOwners, diags = stringListElements(ctx, plan.ProjectOwners)
+	resp.Diagnostics.Append(diags...)
+	vars.SecurityChampion, diags = stringListElements(ctx, plan.SecurityChampions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// process the request
 	data := &CreateProject{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "project", "create")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
+	requestDiags := client.ProcessRequest(ctx, r.conf, vars, data, query, "project", "create")
+	resp.Diagnostics.Append(frameworkDiagnostics(requestDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// set the id
-	d.SetId(data.CreateProject.Project.ID)
+	plan.ID = types.StringValue(data.CreateProject.Project.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
 
-	return resourceWizProjectRead(ctx, d, m)
+	r.readAfterApply(ctx, plan, &resp.State, &resp.Diagnostics)
 }
 
-func flattenRiskProfile(ctx context.Context, riskProfile *wiz.ProjectRiskProfile) []interface{} {
-	var output = make([]interface{}, 0)
-	riskProfileMap := make(map[string]interface{})
-	riskProfileMap["business_impact"] = riskProfile.BusinessImpact
-	riskProfileMap["is_actively_developed"] = riskProfile.IsActivelyDeveloped
-	riskProfileMap["has_authentication"] = riskProfile.HasAuthentication
-	riskProfileMap["has_exposed_api"] = riskProfile.HasExposedAPI
-	riskProfileMap["is_internet_facing"] = riskProfile.IsInternetFacing
-	riskProfileMap["is_customer_facing"] = riskProfile.IsCustomerFacing
-	riskProfileMap["stores_data"] = riskProfile.StoresData
-	riskProfileMap["is_regulated"] = riskProfile.IsRegulated
-
-	var sensitiveDataTypes = make([]interface{}, 0)
-	for _, a := range riskProfile.SensitiveDataTypes {
-		tflog.Trace(ctx, fmt.Sprintf("a: %T %s", a, utils.PrettyPrint(a)))
-		sensitiveDataTypes = append(sensitiveDataTypes, a)
-	}
-	riskProfileMap["sensitive_data_types"] = sensitiveDataTypes
-
-	var regulatoryStandards = make([]interface{}, 0)
-	for _, a := range riskProfile.RegulatoryStandards {
-		tflog.Trace(ctx, fmt.Sprintf("a: %T %s", a, utils.PrettyPrint(a)))
-		regulatoryStandards = append(regulatoryStandards, a)
+// expandProjectRiskProfile func - return the risk profile of the risk_profile block, the api treats a patch to riskProfile as an override
+func expandProjectRiskProfile(ctx context.Context, riskProfiles []projectRiskProfileModel) (*wiz.ProjectRiskProfileInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	output := &wiz.ProjectRiskProfileInput{}
+	for _, riskProfile := range riskProfiles {
+		output.BusinessImpact = riskProfile.BusinessImpact.ValueString()
+		output.IsActivelyDeveloped = riskProfile.IsActivelyDeveloped.ValueString()
+		output.HasAuthentication = riskProfile.HasAuthentication.ValueString()
+		output.HasExposedAPI = riskProfile.HasExposedAPI.ValueString()
+		output.IsInternetFacing = riskProfile.IsInternetFacing.ValueString()
+		output.IsCustomerFacing = riskProfile.IsCustomerFacing.ValueString()
+		output.StoresData = riskProfile.StoresData.ValueString()
+		output.IsRegulated = riskProfile.IsRegulated.ValueString()
+
+		var elementDiags diag.Diagnostics
+		output.SensitiveDataTypes, elementDiags = stringListElements(ctx, riskProfile.SensitiveDataTypes)
+		diags.Append(elementDiags...)
+		output.RegulatoryStandards, elementDiags = stringListElements(ctx, riskProfile.RegulatoryStandards)
+		diags.Append(elementDiags...)
 	}
-	riskProfileMap["regulatory_standards"] = regulatoryStandards
-
-	output = append(output, riskProfileMap)
-	return output
+	return output, diags
 }
 
-func flattenCloudOrganizationLinks(ctx context.Context, cloudOrganizationLink []*wiz.ProjectCloudOrganizationLink) []interface{} {
-	var output = make([]interface{}, 0)
-
-	for _, b := range cloudOrganizationLink {
-		cloudOrganizatinLinksMap := make(map[string]interface{})
-		cloudOrganizatinLinksMap["cloud_organization"] = b.CloudOrganization.ID
-		cloudOrganizatinLinksMap["shared"] = b.Shared
-		cloudOrganizatinLinksMap["environment"] = b.Environment
-
-		var resourceTags = make([]interface{}, 0)
-		for _, d := range b.ResourceTags {
-			var resourceTag = make(map[string]interface{})
-			resourceTag["key"] = d.Key
-			resourceTag["value"] = d.Value
-			resourceTags = append(resourceTags, resourceTag)
-		}
-
-		var resourceGroups = make([]interface{}, 0)
-		for _, d := range b.ResourceGroups {
-			resourceGroups = append(resourceGroups, d)
-		}
-
-		cloudOrganizatinLinksMap["resource_tags"] = resourceTags
-		cloudOrganizatinLinksMap["resource_groups"] = resourceGroups
-
-		output = append(output, cloudOrganizatinLinksMap)
+// expandResourceTags func - return the resource tags of a resource_tags block
+func expandResourceTags(resourceTags []resourceTagModel) []*wiz.ResourceTagInput {
+	var output []*wiz.ResourceTagInput
+	for _, resourceTag := range resourceTags {
+		output = append(output, &wiz.ResourceTagInput{
+			Key:   resourceTag.Key.ValueString(),
+			Value: resourceTag.Value.ValueString(),
+		})
 	}
 	return output
 }
 
-func flattenCloudAccountLinks(ctx context.Context, cloudAccountLink []*wiz.ProjectCloudAccountLink) []interface{} {
-	var output = make([]interface{}, 0)
-
-	for _, b := range cloudAccountLink {
-		cloudAccountLinksMap := make(map[string]interface{})
-		cloudAccountLinksMap["cloud_account_id"] = b.CloudAccount.ID
-		cloudAccountLinksMap["shared"] = b.Shared
-		cloudAccountLinksMap["environment"] = b.Environment
-
-		var resourceTags = make([]interface{}, 0)
-		for _, d := range b.ResourceTags {
-			var resourceTag = make(map[string]interface{})
-			resourceTag["key"] = d.Key
-			resourceTag["value"] = d.Value
-			resourceTags = append(resourceTags, resourceTag)
-		}
-
-		var resourceGroups = make([]interface{}, 0)
-		for _, d := range b.ResourceGroups {
-			resourceGroups = append(resourceGroups, d)
-		}
-
-		cloudAccountLinksMap["resource_tags"] = resourceTags
-		cloudAccountLinksMap["resource_groups"] = resourceGroups
-
-		output = append(output, cloudAccountLinksMap)
+// expandProjectCloudOrganizationLinks func - return the organization links of the cloud_organization_link blocks
+func expandProjectCloudOrganizationLinks(ctx context.Context, links []projectCloudOrganizationLinkModel) ([]*wiz.ProjectCloudOrganizationLinkInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	output := make([]*wiz.ProjectCloudOrganizationLinkInput, 0, len(links))
+	for _, link := range links {
+		resourceGroups, elementDiags := stringListElements(ctx, link.ResourceGroups)
+		diags.Append(elementDiags...)
+		output = append(output, &wiz.ProjectCloudOrganizationLinkInput{
+			CloudOrganization: link.CloudOrganization.ValueString(),
+			Environment:       link.Environment.ValueString(),
+			Shared:            link.Shared.ValueBool(),
+			ResourceGroups:    resourceGroups,
+			ResourceTags:      expandResourceTags(link.ResourceTags),
+		})
 	}
-	return output
+	return output, diags
 }
 
-func flattenKubernetesClusterLinks(ctx context.Context, kubernetesClusterLink []*wiz.ProjectKubernetesClusterLink) []interface{} {
-	var output = make([]interface{}, 0)
-
-	for _, b := range kubernetesClusterLink {
-		clusterLinksMap := make(map[string]interface{})
-		clusterLinksMap["kubernetes_cluster"] = b.KubernetesCluster.ID
-		clusterLinksMap["shared"] = b.Shared
-		clusterLinksMap["environment"] = b.Environment
-
-		var namespaces = make([]interface{}, 0)
-		for _, d := range b.Namespaces {
-			namespaces = append(namespaces, d)
-		}
-		clusterLinksMap["namespaces"] = namespaces
-
-		output = append(output, clusterLinksMap)
+// expandProjectCloudAccountLinks func - return the account links of the cloud_account_link blocks
+// an account link without shared lets Wiz decide whether the account is shared.
+func expandProjectCloudAccountLinks(ctx context.Context, links []projectCloudAccountLinkModel) ([]*wiz.ProjectCloudAccountLinkInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	output := make([]*wiz.ProjectCloudAccountLinkInput, 0, len(links))
+	for _, link := range links {
+		resourceGroups, elementDiags := stringListElements(ctx, link.ResourceGroups)
+		diags.Append(elementDiags...)
+		output = append(output, &wiz.ProjectCloudAccountLinkInput{
+			CloudAccount:   link.CloudAccountID.ValueString(),
+			Environment:    link.Environment.ValueString(),
+			Shared:         boolPointer(link.Shared),
+			ResourceGroups: resourceGroups,
+			ResourceTags:   expandResourceTags(link.ResourceTags),
+		})
 	}
-	return output
+	return output, diags
 }
 
-func flattenUserIds(ctx context.Context, userIds []*wiz.User) []interface{} {
-	var output = make([]interface{}, 0)
-	for _, b := range userIds {
-		output = append(output, b.ID)
+// expandProjectKubernetesClusterLinks func - return the cluster links of the kubernetes_cluster_link blocks
+func expandProjectKubernetesClusterLinks(ctx context.Context, links []projectKubernetesClusterLinkModel) ([]*wiz.ProjectKubernetesClusterLinkInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	output := make([]*wiz.ProjectKubernetesClusterLinkInput, 0, len(links))
+	for _, link := range links {
+		namespaces, elementDiags := stringListElements(ctx, link.Namespaces)
+		diags.Append(elementDiags...)
+		output = append(output, &wiz.ProjectKubernetesClusterLinkInput{
+			KubernetesCluster: link.KubernetesCluster.ValueString(),
+			Environment:       link.Environment.ValueString(),
+			Shared:            link.Shared.ValueBool(),
+			Namespaces:        namespaces,
+		})
 	}
-	return output
+	return output, diags
 }
 
 // ReadProjectPayload struct -- updates
@@ -767,14 +613,8 @@ type ReadProjectPayload struct {
 	Project wiz.Project `json:"project"`
 }
 
-func resourceWizProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizProjectRead called...")
-
-	// check the id
-	if d.Id() == "" {
-		return nil
-	}
-
+// readProject func - read a project, deleted reports whether it was deleted outside of terraform
+func (r *projectResource) readProject(ctx context.Context, id string) (project *wiz.Project, deleted bool, diags diag.Diagnostics) {
 	// define the graphql query
 	query := `query project  (
 	    $id: ID
@@ -787,7 +627,7 @@ func resourceWizProjectRead(ctx context.Context, d *schema.ResourceData, m inter
 	        isFolder
 	        ancestorProjects {
 	          id
-	        }	
+	        }
 	        description
 	        identifiers
 	        slug
@@ -851,85 +691,248 @@ func resourceWizProjectRead(ctx context.Context, d *schema.ResourceData, m inter
 	            environment
 	            namespaces
 	            shared
-              }
+	        }
 	    }
 	}`
 
 	// populate the graphql variables
 	vars := &internal.QueryVariables{}
-	vars.ID = d.Id()
+	vars.ID = id
 
 	// process the request
 	data := &ReadProjectPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "project", "read")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, r.conf, vars, data, query, "project", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Project.ID) {
+		return nil, true, nil
 	}
+	return &data.Project, false, frameworkDiagnostics(requestDiags)
+}
+
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "projectResource.Read called...")
 
-	err := d.Set("name", data.Project.Name)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	var state projectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err = d.Set("is_folder", data.Project.IsFolder)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	// the parent project will be the first element of the list of ancestor projects
-	if len(data.Project.AncestorProjects) > 0 {
-		err = d.Set("parent_project_id", data.Project.AncestorProjects[0].ID)
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
+	project, deleted, diags := r.readProject(ctx, state.ID.ValueString())
+	if deleted {
+		resp.Diagnostics.Append(removeDeletedFrameworkResource(ctx, &resp.State, "wiz_project", state.ID.ValueString())...)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	err = d.Set("description", data.Project.Description)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	state, diags = flattenProject(ctx, project, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err = d.Set("archived", data.Project.Archived)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// readAfterApply func - read a project after it was created or updated and store it in the state
+func (r *projectResource) readAfterApply(ctx context.Context, plan projectResourceModel, state *tfsdk.State, respDiags *diag.Diagnostics) {
+	project, deleted, diags := r.readProject(ctx, plan.ID.ValueString())
+	if deleted {
+		respDiags.AddError("Unable to read project", fmt.Sprintf("project %s was not found after it was applied", plan.ID.ValueString()))
+		return
 	}
-	err = d.Set("slug", data.Project.Slug)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return
 	}
-	identifiers := utils.ConvertSliceToGenericArray(data.Project.Identifiers)
-	if err = d.Set("identifiers", identifiers); err != nil {
-		return append(diags, diag.FromErr(err)...)
+
+	plan, diags = flattenProject(ctx, project, plan)
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return
 	}
-	projectOwners := flattenUserIds(ctx, data.Project.ProjectOwners)
-	if err := d.Set("project_owners", projectOwners); err != nil {
-		return append(diags, diag.FromErr(err)...)
+	respDiags.Append(state.Set(ctx, &plan)...)
+}
+
+// flattenProject func - return the data of a project read from Wiz, prior is the state or the plan the project is compared with
+func flattenProject(ctx context.Context, project *wiz.Project, prior projectResourceModel) (projectResourceModel, diag.Diagnostics) {
+	var diags, elementDiags diag.Diagnostics
+
+	// an imported project only has an id
+	imported := prior.Name.IsNull()
+
+	output := prior
+	output.ID = types.StringValue(project.ID)
+	output.Name = types.StringValue(project.Name)
+	output.Description = stringValue(prior.Description, project.Description)
+	output.Archived = types.BoolValue(project.Archived)
+	output.IsFolder = types.BoolValue(project.IsFolder)
+	output.BusinessUnit = stringValue(prior.BusinessUnit, project.BusinessUnit)
+	output.Slug = types.StringValue(project.Slug)
+
+	// the parent project will be the first element of the list of ancestor projects
+	var parentProjectID string
+	if len(project.AncestorProjects) > 0 {
+		parentProjectID = project.AncestorProjects[0].ID
+	}
+	output.ParentProjectID = stringValue(prior.ParentProjectID, parentProjectID)
+
+	output.Identifiers, elementDiags = stringListValue(ctx, prior.Identifiers, project.Identifiers)
+	diags.Append(elementDiags...)
+	output.ProjectOwners, elementDiags = stringListValue(ctx, prior.ProjectOwners, flattenUserIds(project.ProjectOwners))
+	diags.Append(elementDiags...)
+	output.SecurityChampions, elementDiags = stringListValue(ctx, prior.SecurityChampions, flattenUserIds(project.SecurityChampions))
+	diags.Append(elementDiags...)
+
+	// every project has a risk profile, it is only stored when configured
+	output.RiskProfile = []projectRiskProfileModel{}
+	if imported || len(prior.RiskProfile) > 0 {
+		var priorRiskProfile projectRiskProfileModel
+		if len(prior.RiskProfile) > 0 {
+			priorRiskProfile = prior.RiskProfile[0]
+		}
+		riskProfile, elementDiags := flattenProjectRiskProfile(ctx, &project.RiskProfile, priorRiskProfile)
+		diags.Append(elementDiags...)
+		output.RiskProfile = append(output.RiskProfile, riskProfile)
+	}
+
+	output.CloudOrganizationLink, elementDiags = flattenProjectCloudOrganizationLinks(ctx, project.CloudOrganizationLinks, prior.CloudOrganizationLink)
+	diags.Append(elementDiags...)
+	output.CloudAccountLink, elementDiags = flattenProjectCloudAccountLinks(ctx, project.CloudAccountLinks, prior.CloudAccountLink)
+	diags.Append(elementDiags...)
+	output.KubernetesClusterLink, elementDiags = flattenProjectKubernetesClusterLinks(ctx, project.KubernetesClustersLinks, prior.KubernetesClusterLink)
+	diags.Append(elementDiags...)
+
+	return output, diags
+}
+
+// flattenProjectRiskProfile func - return the risk_profile block of a risk profile
+func flattenProjectRiskProfile(ctx context.Context, riskProfile *wiz.ProjectRiskProfile, prior projectRiskProfileModel) (projectRiskProfileModel, diag.Diagnostics) {
+	var diags, elementDiags diag.Diagnostics
+	output := projectRiskProfileModel{
+		BusinessImpact:      types.StringValue(riskProfile.BusinessImpact),
+		IsActivelyDeveloped: types.StringValue(riskProfile.IsActivelyDeveloped),
+		HasAuthentication:   types.StringValue(riskProfile.HasAuthentication),
+		HasExposedAPI:       types.StringValue(riskProfile.HasExposedAPI),
+		IsInternetFacing:    types.StringValue(riskProfile.IsInternetFacing),
+		IsCustomerFacing:    types.StringValue(riskProfile.IsCustomerFacing),
+		StoresData:          types.StringValue(riskProfile.StoresData),
+		IsRegulated:         types.StringValue(riskProfile.IsRegulated),
+	}
+	output.SensitiveDataTypes, elementDiags = stringListValue(ctx, prior.SensitiveDataTypes, riskProfile.SensitiveDataTypes)
+	diags.Append(elementDiags...)
+	output.RegulatoryStandards, elementDiags = stringListValue(ctx, prior.RegulatoryStandards, riskProfile.RegulatoryStandards)
+	diags.Append(elementDiags...)
+	return output, diags
+}
+
+// flattenResourceTags func - return the resource_tags blocks of resource tags
+func flattenResourceTags(resourceTags []*wiz.ResourceTag) []resourceTagModel {
+	output := make([]resourceTagModel, 0, len(resourceTags))
+	for _, resourceTag := range resourceTags {
+		output = append(output, resourceTagModel{
+			Key:   types.StringValue(resourceTag.Key),
+			Value: types.StringValue(resourceTag.Value),
+		})
+	}
+	return output
+}
+
+// flattenProjectCloudOrganizationLinks func - return the cloud_organization_link blocks of organization links
+// prior holds the blocks the links are compared with, matched by organization.
+func flattenProjectCloudOrganizationLinks(ctx context.Context, links []*wiz.ProjectCloudOrganizationLink, prior []projectCloudOrganizationLinkModel) ([]projectCloudOrganizationLinkModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	priorResourceGroups := make(map[string]types.List, len(prior))
+	for _, link := range prior {
+		priorResourceGroups[link.CloudOrganization.ValueString()] = link.ResourceGroups
 	}
-	securityChampions := flattenUserIds(ctx, data.Project.SecurityChampions)
-	if err := d.Set("security_champions", securityChampions); err != nil {
-		return append(diags, diag.FromErr(err)...)
+
+	output := make([]projectCloudOrganizationLinkModel, 0, len(links))
+	for _, link := range links {
+		resourceGroups, elementDiags := stringListValue(ctx, nullIfMissing(priorResourceGroups, link.CloudOrganization.ID), link.ResourceGroups)
+		diags.Append(elementDiags...)
+		output = append(output, projectCloudOrganizationLinkModel{
+			CloudOrganization: types.StringValue(link.CloudOrganization.ID),
+			Environment:       types.StringValue(link.Environment),
+			Shared:            types.BoolValue(link.Shared),
+			ResourceGroups:    resourceGroups,
+			ResourceTags:      flattenResourceTags(link.ResourceTags),
+		})
 	}
-	err = d.Set("business_unit", data.Project.BusinessUnit)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+	return output, diags
+}
+
+// flattenProjectCloudAccountLinks func - return the cloud_account_link blocks of account links
+// prior holds the blocks the links are compared with, matched by account.
+func flattenProjectCloudAccountLinks(ctx context.Context, links []*wiz.ProjectCloudAccountLink, prior []projectCloudAccountLinkModel) ([]projectCloudAccountLinkModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	priorResourceGroups := make(map[string]types.List, len(prior))
+	for _, link := range prior {
+		priorResourceGroups[link.CloudAccountID.ValueString()] = link.ResourceGroups
 	}
-	RiskProfile := flattenRiskProfile(ctx, &data.Project.RiskProfile)
-	if err := d.Set("risk_profile", RiskProfile); err != nil {
-		return append(diags, diag.FromErr(err)...)
+
+	output := make([]projectCloudAccountLinkModel, 0, len(links))
+	for _, link := range links {
+		resourceGroups, elementDiags := stringListValue(ctx, nullIfMissing(priorResourceGroups, link.CloudAccount.ID), link.ResourceGroups)
+		diags.Append(elementDiags...)
+		output = append(output, projectCloudAccountLinkModel{
+			CloudAccountID: types.StringValue(link.CloudAccount.ID),
+			Environment:    types.StringValue(link.Environment),
+			Shared:         types.BoolValue(link.Shared),
+			ResourceGroups: resourceGroups,
+			ResourceTags:   flattenResourceTags(link.ResourceTags),
+		})
 	}
-	cloudOrganizationLinks := flattenCloudOrganizationLinks(ctx, data.Project.CloudOrganizationLinks)
-	if err := d.Set("cloud_organization_link", cloudOrganizationLinks); err != nil {
-		return append(diags, diag.FromErr(err)...)
+	return output, diags
+}
+
+// flattenProjectKubernetesClusterLinks func - return the kubernetes_cluster_link blocks of cluster links
+// prior holds the blocks the links are compared with, matched by cluster.
+func flattenProjectKubernetesClusterLinks(ctx context.Context, links []*wiz.ProjectKubernetesClusterLink, prior []projectKubernetesClusterLinkModel) ([]projectKubernetesClusterLinkModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	priorNamespaces := make(map[string]types.List, len(prior))
+	for _, link := range prior {
+		priorNamespaces[link.KubernetesCluster.ValueString()] = link.Namespaces
 	}
-	cloudAccountLinks := flattenCloudAccountLinks(ctx, data.Project.CloudAccountLinks)
-	if err := d.Set("cloud_account_link", cloudAccountLinks); err != nil {
-		return append(diags, diag.FromErr(err)...)
+
+	output := make([]projectKubernetesClusterLinkModel, 0, len(links))
+	for _, link := range links {
+		namespaces, elementDiags := stringListValue(ctx, nullIfMissing(priorNamespaces, link.KubernetesCluster.ID), link.Namespaces)
+		diags.Append(elementDiags...)
+		output = append(output, projectKubernetesClusterLinkModel{
+			KubernetesCluster: types.StringValue(link.KubernetesCluster.ID),
+			Environment:       types.StringValue(link.Environment),
+			Shared:            types.BoolValue(link.Shared),
+			Namespaces:        namespaces,
+		})
 	}
-	kubernetesClusterLinks := flattenKubernetesClusterLinks(ctx, data.Project.KubernetesClustersLinks)
-	if err := d.Set("kubernetes_cluster_link", kubernetesClusterLinks); err != nil {
-		return append(diags, diag.FromErr(err)...)
+	return output, diags
+}
+
+// nullIfMissing func - return the prior list of a link, null for a link that was not in the prior blocks
+func nullIfMissing(prior map[string]types.List, key string) types.List {
+	if list, ok := prior[key]; ok {
+		return list
 	}
+	return types.ListNull(types.StringType)
+}
 
-	return diags
+// flattenUserIds func - return the ids of users
+func flattenUserIds(users []*wiz.User) []string {
+	var output = make([]string, 0, len(users))
+	for _, user := range users {
+		output = append(output, user.ID)
+	}
+	return output
 }
 
 // UpdateProject struct
@@ -939,16 +942,25 @@ type UpdateProject struct {
 
 /*
   In order to effectively manage the lifecycle of project settings, we need to override instead of patch
-  Resource state change detection is not observed for UpdateContext, all attributes are captured in the request
+  Resource state change detection is not observed for Update, all attributes are captured in the request
 */
 
-func resourceWizProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizProjectUpdate called...")
+func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "projectResource.Update called...")
+
+	var plan projectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// check the id
-	if d.Id() == "" {
-		return nil
+	updateTimeout, diags := plan.Timeouts.Update(ctx, longUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	// define the graphql query
 	query := `mutation UpdateProject($input: UpdateProjectInput!) {
@@ -961,132 +973,40 @@ func resourceWizProjectUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	// populate the graphql variables
 	vars := &wiz.UpdateProjectInput{}
-	vars.ID = d.Id()
-
-	vars.Override.Archived = utils.ConvertBoolToPointer(d.Get("archived").(bool))
-	vars.Override.Name = d.Get("name").(string)
-	vars.Override.Description = d.Get("description").(string)
-	vars.Override.BusinessUnit = d.Get("business_unit").(string)
-	vars.Override.Identifiers = utils.ConvertListToString((d.Get("identifiers")).([]interface{}))
-	vars.Override.Slug = d.Get("slug").(string)
-	vars.Override.ParentProjectID = d.Get("parent_project_id").(string)
-
-	// The API treats a patch to riskProfile as an override so we set all values
-	riskProfile := &wiz.ProjectRiskProfileInput{}
-	riskProfile.BusinessImpact = d.Get("risk_profile.0.business_impact").(string)
-	riskProfile.IsActivelyDeveloped = d.Get("risk_profile.0.is_actively_developed").(string)
-	riskProfile.HasAuthentication = d.Get("risk_profile.0.has_authentication").(string)
-	riskProfile.HasExposedAPI = d.Get("risk_profile.0.has_exposed_api").(string)
-	riskProfile.IsInternetFacing = d.Get("risk_profile.0.is_internet_facing").(string)
-	riskProfile.IsCustomerFacing = d.Get("risk_profile.0.is_customer_facing").(string)
-	riskProfile.StoresData = d.Get("risk_profile.0.stores_data").(string)
-	riskProfile.IsRegulated = d.Get("risk_profile.0.is_regulated").(string)
-	riskProfile.SensitiveDataTypes = utils.ConvertListToString((d.Get("risk_profile.0.sensitive_data_types")).([]interface{}))
-	riskProfile.RegulatoryStandards = utils.ConvertListToString((d.Get("risk_profile.0.regulatory_standards")).([]interface{}))
-	vars.Override.RiskProfile = riskProfile
-
-	vars.Override.ProjectOwners = utils.ConvertListToString((d.Get("project_owners")).([]interface{}))
-	vars.Override.SecurityChampions = utils.ConvertListToString((d.Get("security_champions")).([]interface{}))
-
-	var updateOrgLinks = []*wiz.ProjectCloudOrganizationLinkInput{}
-	cloudOrganizationlinks := d.Get("cloud_organization_link").(*schema.Set).List()
-	for _, b := range cloudOrganizationlinks {
-		var updateOrgLink = &wiz.ProjectCloudOrganizationLinkInput{}
-		for c, d := range b.(map[string]interface{}) {
-			switch c {
-			case "environment":
-				updateOrgLink.Environment = d.(string)
-			case "shared":
-				updateOrgLink.Shared = d.(bool)
-			case "cloud_organization":
-				updateOrgLink.CloudOrganization = d.(string)
-			case "resource_groups":
-				updateOrgLink.ResourceGroups = utils.ConvertListToString(d.([]interface{}))
-			case "resource_tags":
-				var updateResourceTags = []*wiz.ResourceTagInput{}
-				for _, f := range d.(*schema.Set).List() {
-					var updateResourceTag = &wiz.ResourceTagInput{}
-					for g, h := range f.(map[string]interface{}) {
-						if g == "key" {
-							updateResourceTag.Key = h.(string)
-						}
-						if g == "value" {
-							updateResourceTag.Value = h.(string)
-						}
-					}
-					updateResourceTags = append(updateResourceTags, updateResourceTag)
-				}
-				updateOrgLink.ResourceTags = updateResourceTags
-			}
-		}
-		updateOrgLinks = append(updateOrgLinks, updateOrgLink)
-	}
-	vars.Override.CloudOrganizationLinks = updateOrgLinks
-
-	var updateAccountLinks = []*wiz.ProjectCloudAccountLinkInput{}
-	cloudAccountLinks := d.Get("cloud_account_link").(*schema.Set).List()
-	for _, b := range cloudAccountLinks {
-		var updateAccountLink = &wiz.ProjectCloudAccountLinkInput{}
-		for c, d := range b.(map[string]interface{}) {
-			switch c {
-			case "environment":
-				updateAccountLink.Environment = d.(string)
-			case "shared":
-				updateAccountLink.Shared = utils.ConvertBoolToPointer(d.(bool))
-			case "cloud_account_id":
-				updateAccountLink.CloudAccount = d.(string)
-			case "resource_groups":
-				updateAccountLink.ResourceGroups = utils.ConvertListToString(d.([]interface{}))
-			case "resource_tags":
-				var updateResourceTags = []*wiz.ResourceTagInput{}
-				for _, f := range d.(*schema.Set).List() {
-					var updateResourceTag = &wiz.ResourceTagInput{}
-					for g, h := range f.(map[string]interface{}) {
-						if g == "key" {
-							updateResourceTag.Key = h.(string)
-						}
-						if g == "value" {
-							updateResourceTag.Value = h.(string)
-						}
-					}
-					updateResourceTags = append(updateResourceTags, updateResourceTag)
-				}
-				updateAccountLink.ResourceTags = updateResourceTags
-			}
-		}
-		updateAccountLinks = append(updateAccountLinks, updateAccountLink)
-	}
-	vars.Override.CloudAccountLinks = updateAccountLinks
-
-	var updateKubernetesClusterLinks = []*wiz.ProjectKubernetesClusterLinkInput{}
-	kubernetesClusterLinks := d.Get("kubernetes_cluster_link").(*schema.Set).List()
-	for _, b := range kubernetesClusterLinks {
-		var updateClusterLink = &wiz.ProjectKubernetesClusterLinkInput{}
-		for c, d := range b.(map[string]interface{}) {
-			switch c {
-			case "environment":
-				updateClusterLink.Environment = d.(string)
-			case "shared":
-				updateClusterLink.Shared = d.(bool)
-			case "namespaces":
-				updateClusterLink.Namespaces = utils.ConvertListToString(d.([]interface{}))
-			case "kubernetes_cluster":
-				updateClusterLink.KubernetesCluster = d.(string)
-			}
-		}
-		updateKubernetesClusterLinks = append(updateKubernetesClusterLinks, updateClusterLink)
+	vars.ID = plan.ID.ValueString()
+	vars.Override.Archived = boolPointer(plan.Archived)
+	vars.Override.Name = plan.Name.ValueString()
+	vars.Override.Description = plan.Description.ValueString()
+	vars.Override.BusinessUnit = plan.BusinessUnit.ValueString()
+	vars.Override.Slug = plan.Slug.ValueString()
+	vars.Override.ParentProjectID = plan.ParentProjectID.ValueString()
+	vars.Override.RiskProfile, diags = expandProjectRiskProfile(ctx, plan.RiskProfile)
+	resp.Diagnostics.Append(diags...)
+	vars.Override.CloudOrganizationLinks, diags = expandProjectCloudOrganizationLinks(ctx, plan.CloudOrganizationLink)
+	resp.Diagnostics.Append(diags...)
+	vars.Override.CloudAccountLinks, diags = expandProjectCloudAccountLinks(ctx, plan.CloudAccountLink)
+	resp.Diagnostics.Append(diags...)
+	vars.Override.KubernetesClusterLinks, diags = expandProjectKubernetesClusterLinks(ctx, plan.KubernetesClusterLink)
+	resp.Diagnostics.Append(diags...)
+	vars.Override.Identifiers, diags = stringListElements(ctx, plan.Identifiers)
+	resp.Diagnostics.Append(diags...)
+	vars.Override.ProjectOwners, diags = stringListElements(ctx, plan.ProjectOwners)
+	resp.Diagnostics.Append(diags...)
+	vars.Override.SecurityChampions, diags = stringListElements(ctx, plan.SecurityChampions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	vars.Override.KubernetesClusterLinks = updateKubernetesClusterLinks
 
 	// process the request
 	data := &UpdateProject{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "project", "update")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
+	requestDiags := client.ProcessRequest(ctx, r.conf, vars, data, query, "project", "update")
+	resp.Diagnostics.Append(frameworkDiagnostics(requestDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceWizProjectRead(ctx, d, m)
+	r.readAfterApply(ctx, plan, &resp.State, &resp.Diagnostics)
 }
 
 /*
@@ -1095,38 +1015,46 @@ func resourceWizProjectUpdate(ctx context.Context, d *schema.ResourceData, m int
   The slug itself also needs to be included in update/delete contexts
 */
 
-func resourceWizProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	tflog.Info(ctx, "resourceWizProjectDelete called...")
+func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "projectResource.Delete called...")
+
+	var state projectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// check the id
-	if d.Id() == "" {
-		return nil
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	// define the graphql query
 	query := `mutation UpdateProject($input: UpdateProjectInput!) {
-          updateProject(input: $input) {
-            project {
-              id
-            }
-          }
-        }`
+	  updateProject(input: $input) {
+	    project {
+	      id
+	    }
+	  }
+	}`
 
 	// populate the graphql variables
 	vars := &wiz.UpdateProjectInput{}
-	vars.ID = d.Id()
-	vars.Override.Name = d.Get("slug").(string)
-	vars.Override.Slug = d.Get("slug").(string)
+	vars.ID = state.ID.ValueString()
+	vars.Override.Name = state.Slug.ValueString()
+	vars.Override.Slug = state.Slug.ValueString()
 	archive := true
 	vars.Override.Archived = &archive
 
 	// process the request
 	data := &UpdateProject{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "project", "delete")
-	diags = append(diags, requestDiags...)
-	if len(diags) > 0 {
-		return diags
-	}
+	requestDiags := client.ProcessRequest(ctx, r.conf, vars, data, query, "project", "delete")
+	resp.Diagnostics.Append(frameworkDiagnostics(requestDiags)...)
+}
 
-	return diags
+func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
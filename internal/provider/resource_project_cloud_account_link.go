@@ -127,6 +127,7 @@ func resourceWizProjectCloudAccountLink() *schema.Resource {
 		ReadContext:   resourceWizProjectCloudAccountLinkRead,
 		UpdateContext: resourceWizProjectCloudAccountLinkUpdate,
 		DeleteContext: resourceWizProjectCloudAccountLinkDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				// schema for import id: link|<project_id>|<cloud_account_id>
@@ -381,7 +382,10 @@ func resourceWizProjectCloudAccountLinkRead(ctx context.Context, d *schema.Resou
 
 	// process the request
 	data := &ReadProjectPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "project", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "project", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Project.ID) {
+		return removeDeletedResource(ctx, d, "wiz_project_cloud_account_link")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
 		return diags
@@ -392,10 +396,10 @@ func resourceWizProjectCloudAccountLinkRead(ctx context.Context, d *schema.Resou
 		return append(diags, diag.FromErr(err)...)
 	}
 
-	// extract the single cloud account link we want
+	// extract the single cloud account link we want, the link is gone when the cloud account was unlinked outside of terraform
 	cloudAccountLink, err := extractCloudAccountLink(data.Project.CloudAccountLinks, cloudAccountWizID)
 	if err != nil {
-		return append(diags, diag.FromErr(err)...)
+		return removeDeletedResource(ctx, d, "wiz_project_cloud_account_link")
 	}
 
 	err = d.Set("cloud_account_id", cloudAccountLink.CloudAccount.ID)
@@ -3,39 +3,46 @@ package provider
 import (
 	"context"
 	"reflect"
-	"sort"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
-func TestFlattenRiskProfileAll(t *testing.T) {
+// stringList returns a list of strings value
+func stringList(values ...string) types.List {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+func TestFlattenProjectRiskProfileAll(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"business_impact":       "HBI",
-			"has_authentication":    "Yes",
-			"has_exposed_api":       "Yes",
-			"is_actively_developed": "Yes",
-			"is_customer_facing":    "Yes",
-			"is_internet_facing":    "Yes",
-			"is_regulated":          "Yes",
-			"regulatory_standards": []interface{}{
-				"ISO_27001",
-				"ISO_27017",
-				"ISO_27018",
-				"ISO_27701",
-			},
-			"sensitive_data_types": []interface{}{
-				"HEALTH",
-				"PII",
-			},
-			"stores_data": "Yes",
-		},
+	expected := projectRiskProfileModel{
+		BusinessImpact:      types.StringValue("HBI"),
+		HasAuthentication:   types.StringValue("Yes"),
+		HasExposedAPI:       types.StringValue("Yes"),
+		IsActivelyDeveloped: types.StringValue("Yes"),
+		IsCustomerFacing:    types.StringValue("Yes"),
+		IsInternetFacing:    types.StringValue("Yes"),
+		IsRegulated:         types.StringValue("Yes"),
+		RegulatoryStandards: stringList(
+			"ISO_27001",
+			"ISO_27017",
+			"ISO_27018",
+			"ISO_27701",
+		),
+		SensitiveDataTypes: stringList(
+			"HEALTH",
+			"PII",
+		),
+		StoresData: types.StringValue("Yes"),
 	}
 
 	var expanded = &wiz.ProjectRiskProfile{
@@ -59,66 +66,66 @@ func TestFlattenRiskProfileAll(t *testing.T) {
 		StoresData: "Yes",
 	}
 
-	riskProfile := flattenRiskProfile(ctx, expanded)
+	riskProfile, diags := flattenProjectRiskProfile(ctx, expanded, projectRiskProfileModel{})
 
-	if !reflect.DeepEqual(riskProfile, expected) {
+	if diags.HasError() || !reflect.DeepEqual(riskProfile, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			riskProfile,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenRiskProfileRequired(t *testing.T) {
+func TestFlattenProjectRiskProfileRequired(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"business_impact":       "HBI",
-			"has_authentication":    "",
-			"has_exposed_api":       "",
-			"is_actively_developed": "",
-			"is_customer_facing":    "",
-			"is_internet_facing":    "",
-			"is_regulated":          "",
-			"regulatory_standards":  []interface{}{},
-			"sensitive_data_types":  []interface{}{},
-			"stores_data":           "",
-		},
+	// an imported risk profile has no prior, the unset lists are null
+	expected := projectRiskProfileModel{
+		BusinessImpact:      types.StringValue("HBI"),
+		HasAuthentication:   types.StringValue(""),
+		HasExposedAPI:       types.StringValue(""),
+		IsActivelyDeveloped: types.StringValue(""),
+		IsCustomerFacing:    types.StringValue(""),
+		IsInternetFacing:    types.StringValue(""),
+		IsRegulated:         types.StringValue(""),
+		RegulatoryStandards: types.ListNull(types.StringType),
+		SensitiveDataTypes:  types.ListNull(types.StringType),
+		StoresData:          types.StringValue(""),
 	}
 
 	var expanded = &wiz.ProjectRiskProfile{
 		BusinessImpact: "HBI",
 	}
 
-	riskProfile := flattenRiskProfile(ctx, expanded)
+	riskProfile, diags := flattenProjectRiskProfile(ctx, expanded, projectRiskProfileModel{})
 
-	if !reflect.DeepEqual(riskProfile, expected) {
+	if diags.HasError() || !reflect.DeepEqual(riskProfile, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			riskProfile,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenRiskProfileDefaults(t *testing.T) {
+func TestFlattenProjectRiskProfileDefaults(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"business_impact":       "MBI",
-			"has_authentication":    "Unknown",
-			"has_exposed_api":       "Unknown",
-			"is_actively_developed": "Unknown",
-			"is_customer_facing":    "Unknown",
-			"is_internet_facing":    "Unknown",
-			"is_regulated":          "Unknown",
-			"regulatory_standards":  []interface{}{},
-			"sensitive_data_types":  []interface{}{},
-			"stores_data":           "Unknown",
-		},
+	// unset lists stay null, lists configured empty stay empty
+	expected := projectRiskProfileModel{
+		BusinessImpact:      types.StringValue("MBI"),
+		HasAuthentication:   types.StringValue("Unknown"),
+		HasExposedAPI:       types.StringValue("Unknown"),
+		IsActivelyDeveloped: types.StringValue("Unknown"),
+		IsCustomerFacing:    types.StringValue("Unknown"),
+		IsInternetFacing:    types.StringValue("Unknown"),
+		IsRegulated:         types.StringValue("Unknown"),
+		RegulatoryStandards: types.ListNull(types.StringType),
+		SensitiveDataTypes:  stringList(),
+		StoresData:          types.StringValue("Unknown"),
 	}
 
 	var expanded = &wiz.ProjectRiskProfile{
@@ -134,27 +141,30 @@ func TestFlattenRiskProfileDefaults(t *testing.T) {
 		StoresData:          "Unknown",
 	}
 
-	riskProfile := flattenRiskProfile(ctx, expanded)
+	prior := projectRiskProfileModel{
+		RegulatoryStandards: types.ListNull(types.StringType),
+		SensitiveDataTypes:  stringList(),
+	}
+	riskProfile, diags := flattenProjectRiskProfile(ctx, expanded, prior)
 
-	if !reflect.DeepEqual(riskProfile, expected) {
+	if diags.HasError() || !reflect.DeepEqual(riskProfile, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			riskProfile,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenKubernetesClusterLinks(t *testing.T) {
+func TestFlattenProjectKubernetesClusterLinks(t *testing.T) {
 	ctx := context.Background()
-	expected := []interface{}{
-		map[string]interface{}{
-			"environment":        "STAGING",
-			"kubernetes_cluster": "77de7ca1-02f9-5ed2-a94b-5d19c683efa2",
-			"shared":             true,
-			"namespaces": []interface{}{
-				"kube-system",
-			},
+	expected := []projectKubernetesClusterLinkModel{
+		{
+			Environment:       types.StringValue("STAGING"),
+			KubernetesCluster: types.StringValue("77de7ca1-02f9-5ed2-a94b-5d19c683efa2"),
+			Shared:            types.BoolValue(true),
+			Namespaces:        stringList("kube-system"),
 		},
 	}
 	var clusterlink = &wiz.ProjectKubernetesClusterLink{
@@ -171,38 +181,39 @@ func TestFlattenKubernetesClusterLinks(t *testing.T) {
 	expanded := []*wiz.ProjectKubernetesClusterLink{}
 	expanded = append(expanded, clusterlink)
 
-	var flattened = flattenKubernetesClusterLinks(ctx, expanded)
-	if !reflect.DeepEqual(flattened, expected) {
+	flattened, diags := flattenProjectKubernetesClusterLinks(ctx, expanded, nil)
+	if diags.HasError() || !reflect.DeepEqual(flattened, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
-			expanded,
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
+			flattened,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenCloudAccountLinksWithTags(t *testing.T) {
+func TestFlattenProjectCloudAccountLinksWithTags(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"cloud_account_id": "3225def3-0e0e-5cb8-955a-3583f696f77f",
-			"shared":           true,
-			"environment":      "PRODUCTION",
-			"resource_tags": []interface{}{
-				map[string]interface{}{
-					"key":   "k1",
-					"value": "v1",
+	expected := []projectCloudAccountLinkModel{
+		{
+			CloudAccountID: types.StringValue("3225def3-0e0e-5cb8-955a-3583f696f77f"),
+			Shared:         types.BoolValue(true),
+			Environment:    types.StringValue("PRODUCTION"),
+			ResourceTags: []resourceTagModel{
+				{
+					Key:   types.StringValue("k1"),
+					Value: types.StringValue("v1"),
 				},
-				map[string]interface{}{
-					"key":   "k2",
-					"value": "v2",
+				{
+					Key:   types.StringValue("k2"),
+					Value: types.StringValue("v2"),
 				},
 			},
-			"resource_groups": []interface{}{
+			ResourceGroups: stringList(
 				"v1",
 				"v2",
-			},
+			),
 		},
 	}
 
@@ -231,149 +242,160 @@ func TestFlattenCloudAccountLinksWithTags(t *testing.T) {
 	expanded := []*wiz.ProjectCloudAccountLink{}
 	expanded = append(expanded, projectCloudAccountLink1)
 
-	cloudAccountLinks := flattenCloudAccountLinks(ctx, expanded)
+	cloudAccountLinks, diags := flattenProjectCloudAccountLinks(ctx, expanded, nil)
 
-	if !reflect.DeepEqual(cloudAccountLinks, expected) {
+	if diags.HasError() || !reflect.DeepEqual(cloudAccountLinks, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			cloudAccountLinks,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenCloudAccountLinksNoTags(t *testing.T) {
+func TestFlattenProjectCloudAccountLinksNoTags(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"cloud_account_id": "3225def3-0e0e-5cb8-955a-3583f696f77f",
-			"shared":           true,
-			"environment":      "PRODUCTION",
-			"resource_tags":    []interface{}{},
-			"resource_groups":  []interface{}{},
+	// the resource groups of the second link were configured as an empty list
+	expected := []projectCloudAccountLinkModel{
+		{
+			CloudAccountID: types.StringValue("3225def3-0e0e-5cb8-955a-3583f696f77f"),
+			Shared:         types.BoolValue(true),
+			Environment:    types.StringValue("PRODUCTION"),
+			ResourceTags:   []resourceTagModel{},
+			ResourceGroups: types.ListNull(types.StringType),
+		},
+		{
+			CloudAccountID: types.StringValue("d8181cf9-38bb-486c-8278-f95f416afb3c"),
+			Shared:         types.BoolValue(false),
+			Environment:    types.StringValue("PRODUCTION"),
+			ResourceTags:   []resourceTagModel{},
+			ResourceGroups: stringList(),
 		},
 	}
 
-	var projectCloudAccountLink1 = &wiz.ProjectCloudAccountLink{
-		CloudAccount: wiz.CloudAccount{
-			ID: "3225def3-0e0e-5cb8-955a-3583f696f77f",
+	expanded := []*wiz.ProjectCloudAccountLink{
+		{
+			CloudAccount: wiz.CloudAccount{
+				ID: "3225def3-0e0e-5cb8-955a-3583f696f77f",
+			},
+			Shared:         true,
+			Environment:    "PRODUCTION",
+			ResourceTags:   []*wiz.ResourceTag{},
+			ResourceGroups: []string{},
+		},
+		{
+			CloudAccount: wiz.CloudAccount{
+				ID: "d8181cf9-38bb-486c-8278-f95f416afb3c",
+			},
+			Shared:      false,
+			Environment: "PRODUCTION",
 		},
-		Shared:         true,
-		Environment:    "PRODUCTION",
-		ResourceTags:   []*wiz.ResourceTag{},
-		ResourceGroups: []string{},
 	}
 
-	expanded := []*wiz.ProjectCloudAccountLink{}
-	expanded = append(expanded, projectCloudAccountLink1)
-
-	cloudAccountLinks := flattenCloudAccountLinks(ctx, expanded)
+	prior := []projectCloudAccountLinkModel{
+		{
+			CloudAccountID: types.StringValue("d8181cf9-38bb-486c-8278-f95f416afb3c"),
+			ResourceGroups: stringList(),
+		},
+	}
+	cloudAccountLinks, diags := flattenProjectCloudAccountLinks(ctx, expanded, prior)
 
-	if !reflect.DeepEqual(cloudAccountLinks, expected) {
+	if diags.HasError() || !reflect.DeepEqual(cloudAccountLinks, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			cloudAccountLinks,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestGetCloudAccountLinksVar(t *testing.T) {
+func TestExpandProjectCloudAccountLinks(t *testing.T) {
 	ctx := context.Background()
 
-	var expectedAcclink1 = &wiz.ProjectCloudAccountLinkInput{
-		CloudAccount: "3225def3-0e0e-5cb8-955a-3583f696f77f",
-		Environment:  "PRODUCTION",
-		Shared:       utils.ConvertBoolToPointer(true),
-		ResourceTags: []*wiz.ResourceTagInput{
-			{
-				Key:   "7982c5c6-1c66-435c-a509-68fae7718bd8",
-				Value: "fbf63c90-67ed-4198-af07-05ee17a58c1d",
+	// the second link leaves shared to Wiz
+	var expected = []*wiz.ProjectCloudAccountLinkInput{
+		{
+			CloudAccount: "3225def3-0e0e-5cb8-955a-3583f696f77f",
+			Environment:  "PRODUCTION",
+			Shared:       utils.ConvertBoolToPointer(true),
+			ResourceTags: []*wiz.ResourceTagInput{
+				{
+					Key:   "7982c5c6-1c66-435c-a509-68fae7718bd8",
+					Value: "fbf63c90-67ed-4198-af07-05ee17a58c1d",
+				},
+			},
+			ResourceGroups: []string{
+				"v1",
+				"v2",
 			},
 		},
-		ResourceGroups: []string{
-			"v1",
-			"v2",
+		{
+			CloudAccount: "d8181cf9-38bb-486c-8278-f95f416afb3c",
+			Environment:  "PRODUCTION",
 		},
 	}
 
-	var expectedAcclink2 = &wiz.ProjectCloudAccountLinkInput{
-		CloudAccount: "d8181cf9-38bb-486c-8278-f95f416afb3c",
-		Environment:  "PRODUCTION",
-		Shared:       utils.ConvertBoolToPointer(false),
-	}
-
-	var expected = []*wiz.ProjectCloudAccountLinkInput{}
-	expected = append(expected, expectedAcclink1)
-	expected = append(expected, expectedAcclink2)
-
-	d := schema.TestResourceDataRaw(
-		t,
-		resourceWizProject().Schema,
-		map[string]interface{}{
-			"name": "my project",
-			"cloud_account_link": []interface{}{
-				map[string]interface{}{
-					"cloud_account_id": "3225def3-0e0e-5cb8-955a-3583f696f77f",
-					"environment":      "PRODUCTION",
-					"shared":           true,
-					"resource_tags": []interface{}{
-						map[string]interface{}{
-							"key":   "7982c5c6-1c66-435c-a509-68fae7718bd8",
-							"value": "fbf63c90-67ed-4198-af07-05ee17a58c1d",
-						},
-					},
-					"resource_groups": []interface{}{
-						"v1",
-						"v2",
-					},
-				},
-				map[string]interface{}{
-					"cloud_account_id": "d8181cf9-38bb-486c-8278-f95f416afb3c",
-					"environment":      "PRODUCTION",
-					"shared":           false,
+	links := []projectCloudAccountLinkModel{
+		{
+			CloudAccountID: types.StringValue("3225def3-0e0e-5cb8-955a-3583f696f77f"),
+			Environment:    types.StringValue("PRODUCTION"),
+			Shared:         types.BoolValue(true),
+			ResourceTags: []resourceTagModel{
+				{
+					Key:   types.StringValue("7982c5c6-1c66-435c-a509-68fae7718bd8"),
+					Value: types.StringValue("fbf63c90-67ed-4198-af07-05ee17a58c1d"),
 				},
 			},
+			ResourceGroups: stringList(
+				"v1",
+				"v2",
+			),
 		},
-	)
-
-	accLink := getAccountLinksVar(ctx, d)
+		{
+			CloudAccountID: types.StringValue("d8181cf9-38bb-486c-8278-f95f416afb3c"),
+			Environment:    types.StringValue("PRODUCTION"),
+			Shared:         types.BoolUnknown(),
+			ResourceGroups: types.ListNull(types.StringType),
+		},
+	}
 
-	sort.SliceStable(expected, func(i, j int) bool { return expected[i].CloudAccount < expected[j].CloudAccount })
-	sort.SliceStable(accLink, func(i, j int) bool { return accLink[i].CloudAccount < accLink[j].CloudAccount })
+	accLink, diags := expandProjectCloudAccountLinks(ctx, links)
 
-	if !reflect.DeepEqual(expected, accLink) {
+	if diags.HasError() || !reflect.DeepEqual(expected, accLink) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			utils.PrettyPrint(accLink),
 			utils.PrettyPrint(expected),
+			diags,
 		)
 	}
 }
 
-func TestFlattenCloudOrganizationLinksWithTags(t *testing.T) {
+func TestFlattenProjectCloudOrganizationLinksWithTags(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"cloud_organization": "f2b48c0b-57c6-4e1c-9bea-09c92c2fe0ed",
-			"shared":             true,
-			"environment":        "PRODUCTION",
-			"resource_tags": []interface{}{
-				map[string]interface{}{
-					"key":   "k1",
-					"value": "v1",
+	expected := []projectCloudOrganizationLinkModel{
+		{
+			CloudOrganization: types.StringValue("f2b48c0b-57c6-4e1c-9bea-09c92c2fe0ed"),
+			Shared:            types.BoolValue(true),
+			Environment:       types.StringValue("PRODUCTION"),
+			ResourceTags: []resourceTagModel{
+				{
+					Key:   types.StringValue("k1"),
+					Value: types.StringValue("v1"),
 				},
-				map[string]interface{}{
-					"key":   "k2",
-					"value": "v2",
+				{
+					Key:   types.StringValue("k2"),
+					Value: types.StringValue("v2"),
 				},
 			},
-			"resource_groups": []interface{}{
+			ResourceGroups: stringList(
 				"v1",
 				"v2",
-			},
+			),
 		},
 	}
 
@@ -402,49 +424,28 @@ func TestFlattenCloudOrganizationLinksWithTags(t *testing.T) {
 	expanded := []*wiz.ProjectCloudOrganizationLink{}
 	expanded = append(expanded, projectCloudOrganizationLink1)
 
-	cloudOrganizationLinks := flattenCloudOrganizationLinks(ctx, expanded)
+	cloudOrganizationLinks, diags := flattenProjectCloudOrganizationLinks(ctx, expanded, nil)
 
-	if !reflect.DeepEqual(cloudOrganizationLinks, expected) {
+	if diags.HasError() || !reflect.DeepEqual(cloudOrganizationLinks, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			cloudOrganizationLinks,
 			expected,
+			diags,
 		)
 	}
 }
 
-func TestFlattenUserIds(t *testing.T) {
-	ctx := context.Background()
-	expected := []interface{}{
-		"01882691-fb1b-5e72-b4cf-dc207a875907",
-	}
-	var userID = &wiz.User{
-		ID: "01882691-fb1b-5e72-b4cf-dc207a875907",
-	}
-
-	expanded := []*wiz.User{}
-	expanded = append(expanded, userID)
-
-	userIds := flattenUserIds(ctx, expanded)
-	if !reflect.DeepEqual(userIds, expected) {
-		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
-			userIds,
-			expected,
-		)
-	}
-}
-
-func TestFlattenCloudOrganizationLinksNoTags(t *testing.T) {
+func TestFlattenProjectCloudOrganizationLinksNoTags(t *testing.T) {
 	ctx := context.Background()
 
-	expected := []interface{}{
-		map[string]interface{}{
-			"cloud_organization": "f2b48c0b-57c6-4e1c-9bea-09c92c2fe0ed",
-			"shared":             true,
-			"environment":        "PRODUCTION",
-			"resource_tags":      []interface{}{},
-			"resource_groups":    []interface{}{},
+	expected := []projectCloudOrganizationLinkModel{
+		{
+			CloudOrganization: types.StringValue("f2b48c0b-57c6-4e1c-9bea-09c92c2fe0ed"),
+			Shared:            types.BoolValue(true),
+			Environment:       types.StringValue("PRODUCTION"),
+			ResourceTags:      []resourceTagModel{},
+			ResourceGroups:    types.ListNull(types.StringType),
 		},
 	}
 
@@ -460,78 +461,121 @@ func TestFlattenCloudOrganizationLinksNoTags(t *testing.T) {
 	expanded := []*wiz.ProjectCloudOrganizationLink{}
 	expanded = append(expanded, projectCloudOrganizationLink1)
 
-	cloudOrganizationLinks := flattenCloudOrganizationLinks(ctx, expanded)
+	cloudOrganizationLinks, diags := flattenProjectCloudOrganizationLinks(ctx, expanded, nil)
 
-	if !reflect.DeepEqual(cloudOrganizationLinks, expected) {
+	if diags.HasError() || !reflect.DeepEqual(cloudOrganizationLinks, expected) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			cloudOrganizationLinks,
 			expected,
+			diags,
+		)
+	}
+}
+
+func TestFlattenUserIds(t *testing.T) {
+	expected := []string{
+		"01882691-fb1b-5e72-b4cf-dc207a875907",
+	}
+	var userID = &wiz.User{
+		ID: "01882691-fb1b-5e72-b4cf-dc207a875907",
+	}
+
+	expanded := []*wiz.User{}
+	expanded = append(expanded, userID)
+
+	userIds := flattenUserIds(expanded)
+	if !reflect.DeepEqual(userIds, expected) {
+		t.Fatalf(
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			userIds,
+			expected,
 		)
 	}
 }
 
-func TestGetOrganizationLinksVar(t *testing.T) {
+func TestExpandProjectCloudOrganizationLinks(t *testing.T) {
 	ctx := context.Background()
 
-	var expectedOrgLink1 = &wiz.ProjectCloudOrganizationLinkInput{
-		CloudOrganization: "98a51e0c-7ab9-4f40-b8d9-a4fc398ad98a",
-		Environment:       "PRODUCTION",
-		ResourceTags: []*wiz.ResourceTagInput{
-			{
-				Key:   "7982c5c6-1c66-435c-a509-68fae7718bd8",
-				Value: "fbf63c90-67ed-4198-af07-05ee17a58c1d",
+	var expected = []*wiz.ProjectCloudOrganizationLinkInput{
+		{
+			CloudOrganization: "98a51e0c-7ab9-4f40-b8d9-a4fc398ad98a",
+			Environment:       "PRODUCTION",
+			ResourceTags: []*wiz.ResourceTagInput{
+				{
+					Key:   "7982c5c6-1c66-435c-a509-68fae7718bd8",
+					Value: "fbf63c90-67ed-4198-af07-05ee17a58c1d",
+				},
 			},
+			Shared: true,
+		},
+		{
+			CloudOrganization: "d8181cf9-38bb-486c-8278-f95f416afb3c",
+			Environment:       "PRODUCTION",
+			Shared:            false,
 		},
-		Shared: true,
 	}
 
-	var expectedOrgLink2 = &wiz.ProjectCloudOrganizationLinkInput{
-		CloudOrganization: "d8181cf9-38bb-486c-8278-f95f416afb3c",
-		Environment:       "PRODUCTION",
-		Shared:            false,
-	}
-
-	var expected = []*wiz.ProjectCloudOrganizationLinkInput{}
-	expected = append(expected, expectedOrgLink1)
-	expected = append(expected, expectedOrgLink2)
-
-	d := schema.TestResourceDataRaw(
-		t,
-		resourceWizProject().Schema,
-		map[string]interface{}{
-			"name": "70bbbb01-6438-4e91-82d9-e1d46e7795f8",
-			"cloud_organization_link": []interface{}{
-				map[string]interface{}{
-					"cloud_organization": "98a51e0c-7ab9-4f40-b8d9-a4fc398ad98a",
-					"environment":        "PRODUCTION",
-					"shared":             true,
-					"resource_tags": []interface{}{
-						map[string]interface{}{
-							"key":   "7982c5c6-1c66-435c-a509-68fae7718bd8",
-							"value": "fbf63c90-67ed-4198-af07-05ee17a58c1d",
-						},
-					},
-				},
-				map[string]interface{}{
-					"cloud_organization": "d8181cf9-38bb-486c-8278-f95f416afb3c",
-					"environment":        "PRODUCTION",
-					"shared":             false,
+	links := []projectCloudOrganizationLinkModel{
+		{
+			CloudOrganization: types.StringValue("98a51e0c-7ab9-4f40-b8d9-a4fc398ad98a"),
+			Environment:       types.StringValue("PRODUCTION"),
+			Shared:            types.BoolValue(true),
+			ResourceTags: []resourceTagModel{
+				{
+					Key:   types.StringValue("7982c5c6-1c66-435c-a509-68fae7718bd8"),
+					Value: types.StringValue("fbf63c90-67ed-4198-af07-05ee17a58c1d"),
 				},
 			},
+			ResourceGroups: types.ListNull(types.StringType),
 		},
-	)
-
-	orgLink := getOrganizationLinksVar(ctx, d)
+		{
+			CloudOrganization: types.StringValue("d8181cf9-38bb-486c-8278-f95f416afb3c"),
+			Environment:       types.StringValue("PRODUCTION"),
+			Shared:            types.BoolValue(false),
+			ResourceGroups:    types.ListNull(types.StringType),
+		},
+	}
 
-	sort.SliceStable(expected, func(i, j int) bool { return expected[i].CloudOrganization < expected[j].CloudOrganization })
-	sort.SliceStable(orgLink, func(i, j int) bool { return orgLink[i].CloudOrganization < orgLink[j].CloudOrganization })
+	orgLink, diags := expandProjectCloudOrganizationLinks(ctx, links)
 
-	if !reflect.DeepEqual(expected, orgLink) {
+	if diags.HasError() || !reflect.DeepEqual(expected, orgLink) {
 		t.Fatalf(
-			"Got:\n\n%#v\n\nExpected:\n\n%#v\n",
+			"Got:\n\n%#v\n\nExpected:\n\n%#v\n\nDiagnostics: %v",
 			utils.PrettyPrint(orgLink),
 			utils.PrettyPrint(expected),
+			diags,
 		)
 	}
 }
+
+func TestFlattenProjectRiskProfileConfigured(t *testing.T) {
+	ctx := context.Background()
+	project := &wiz.Project{
+		ID:          "848025a0-9c2d-5863-8c4d-b60799294fff",
+		Name:        "my project",
+		Slug:        "7b1c5d3c-7b1c-4c5d-8c1d-7b1c5d3c7b1c",
+		RiskProfile: wiz.ProjectRiskProfile{BusinessImpact: "MBI"},
+	}
+
+	// a project planned without a risk_profile block keeps it unset
+	planned := projectResourceModel{
+		Name:        types.StringValue("my project"),
+		Description: types.StringNull(),
+		Identifiers: types.ListNull(types.StringType),
+		RiskProfile: []projectRiskProfileModel{},
+	}
+	flattened, diags := flattenProject(ctx, project, planned)
+	if diags.HasError() || len(flattened.RiskProfile) != 0 || !flattened.Description.IsNull() || !flattened.Identifiers.IsNull() {
+		t.Fatalf("Got:\n\n%#v\n\nDiagnostics: %v", flattened, diags)
+	}
+
+	// an imported project gets the risk profile read from Wiz
+	imported := projectResourceModel{
+		ID: types.StringValue(project.ID),
+	}
+	flattened, diags = flattenProject(ctx, project, imported)
+	if diags.HasError() || len(flattened.RiskProfile) != 1 || flattened.RiskProfile[0].BusinessImpact.ValueString() != "MBI" {
+		t.Fatalf("Got:\n\n%#v\n\nDiagnostics: %v", flattened, diags)
+	}
+}
@@ -13,7 +13,6 @@ import (
 
 	"wiz.io/hashicorp/terraform-provider-wiz/internal"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/client"
-	"wiz.io/hashicorp/terraform-provider-wiz/internal/utils"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/wiz"
 )
 
@@ -61,6 +60,7 @@ func resourceWizReportGraphQuery() *schema.Resource {
 		ReadContext:   resourceWizReportGraphQueryRead,
 		UpdateContext: resourceWizReportGraphQueryUpdate,
 		DeleteContext: resourceWizReportDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -209,17 +209,12 @@ func resourceWizReportGraphQueryRead(ctx context.Context, d *schema.ResourceData
 	tflog.Info(ctx, fmt.Sprintf("report ID during read: %s", vars.ID))
 
 	data := &ReadReportPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "report", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "report", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.Report.ID) {
+		return removeDeletedResource(ctx, d, "wiz_report_graph_query")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.Report.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
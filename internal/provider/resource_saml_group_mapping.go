@@ -92,6 +92,7 @@ func resourceWizSAMLGroupMapping() *schema.Resource {
 		ReadContext:   resourceSAMLGroupMappingRead,
 		UpdateContext: resourceSAMLGroupMappingUpdate,
 		DeleteContext: resourceSAMLGroupMappingDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
@@ -142,7 +143,7 @@ func resourceSAMLGroupMappingCreate(ctx context.Context, d *schema.ResourceData,
 		description := groupMapping["description"].(string)
 
 		// verify the mapping doesn't already exist
-		matchingNodes, diags := querySAMLGroupMappings(ctx, m, samlIdpID, groupMappings)
+		matchingNodes, diags, _ := querySAMLGroupMappings(ctx, m, samlIdpID, groupMappings)
 		if len(diags) != 0 {
 			return diags
 		}
@@ -250,7 +251,11 @@ func resourceSAMLGroupMappingRead(ctx context.Context, d *schema.ResourceData, m
 
 	var newGroupMappings []interface{}
 
-	matchingNodes, diags := querySAMLGroupMappings(ctx, m, samlIdpID, groupMappings)
+	matchingNodes, diags, gqlErrors := querySAMLGroupMappings(ctx, m, samlIdpID, groupMappings)
+	// the mappings are gone with the saml identity provider
+	if diags.HasError() && objectDeleted(ctx, diags, gqlErrors, "") {
+		return removeDeletedResource(ctx, d, "wiz_saml_group_mapping")
+	}
 	if len(diags) > 0 {
 		return diags
 	}
@@ -275,6 +280,11 @@ func resourceSAMLGroupMappingRead(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
+	// every mapping was removed outside of terraform
+	if len(newGroupMappings) == 0 {
+		return removeDeletedResource(ctx, d, "wiz_saml_group_mapping")
+	}
+
 	err := d.Set("saml_idp_id", samlIdpID)
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
@@ -383,7 +393,7 @@ func resourceSAMLGroupMappingDelete(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
-func querySAMLGroupMappings(ctx context.Context, m interface{}, samlIdpID string, groupMappings []interface{}) ([]*wiz.SAMLGroupMapping, diag.Diagnostics) {
+func querySAMLGroupMappings(ctx context.Context, m interface{}, samlIdpID string, groupMappings []interface{}) ([]*wiz.SAMLGroupMapping, diag.Diagnostics, client.GraphQLErrors) {
 	// define the graphql query
 	query := `query samlIdentityProviderGroupMappings ($id: ID!, $first: Int! $after: String){
 	    samlIdentityProviderGroupMappings (
@@ -414,9 +424,9 @@ func querySAMLGroupMappings(ctx context.Context, m interface{}, samlIdpID string
 	vars.First = 500
 
 	// Call ProcessPagedRequest
-	diags, allData := client.ProcessPagedRequest(ctx, m, vars, &ReadSAMLGroupMappings{}, query, "saml_idp", "read", 0)
+	diags, allData, gqlErrors := client.ProcessPagedRequestWithErrors(ctx, m, vars, &ReadSAMLGroupMappings{}, query, "saml_idp", "read", 0)
 	if diags.HasError() {
-		return nil, diags
+		return nil, diags, gqlErrors
 	}
 
 	var matchingNodes []*wiz.SAMLGroupMapping
@@ -425,7 +435,7 @@ func querySAMLGroupMappings(ctx context.Context, m interface{}, samlIdpID string
 	for _, data := range allData {
 		typedData, ok := data.(*ReadSAMLGroupMappings)
 		if !ok {
-			return nil, diag.Errorf("data is not of type *ReadSAMLGroupMappings")
+			return nil, diag.Errorf("data is not of type *ReadSAMLGroupMappings"), nil
 		}
 
 		nodes := typedData.SAMLGroupMappings.Nodes
@@ -445,5 +455,5 @@ func querySAMLGroupMappings(ctx context.Context, m interface{}, samlIdpID string
 		}
 	}
 
-	return matchingNodes, nil
+	return matchingNodes, nil, nil
 }
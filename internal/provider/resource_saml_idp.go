@@ -109,6 +109,7 @@ func resourceWizSAMLIdP() *schema.Resource {
 		ReadContext:   resourceWizSAMLIdPRead,
 		UpdateContext: resourceWizSAMLIdPUpdate,
 		DeleteContext: resourceWizSAMLIdPDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -258,17 +259,12 @@ func resourceWizSAMLIdPRead(ctx context.Context, d *schema.ResourceData, m inter
 	// this query returns http 200 with a payload that contains errors and a null data body
 	// error message: oops! an internal error has occurred. for reference purposes, this is your request id
 	data := &ReadSAMLIdentityProviderPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "saml_idp", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "saml_idp", "read")
+	if objectDeletedOrInternalError(ctx, requestDiags, gqlErrors, data.SAMLIdentityProvider.ID) {
+		return removeDeletedResource(ctx, d, "wiz_saml_idp")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.SAMLIdentityProvider.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
@@ -92,6 +92,7 @@ func resourceWizSecurityFramework() *schema.Resource {
 		ReadContext:   resourceWizSecurityFrameworkRead,
 		UpdateContext: resourceWizSecurityFrameworkUpdate,
 		DeleteContext: resourceWizSecurityFrameworkDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -296,17 +297,12 @@ func resourceWizSecurityFrameworkRead(ctx context.Context, d *schema.ResourceDat
 	// this query returns http 200 with a payload that contains errors and a null data body
 	// error message: oops! an internal error has occurred. for reference purposes, this is your request id
 	data := &ReadSecurityFrameworkPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "security_framework", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "security_framework", "read")
+	if objectDeletedOrInternalError(ctx, requestDiags, gqlErrors, data.SecurityFramework.ID) {
+		return removeDeletedResource(ctx, d, "wiz_security_framework")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
-		tflog.Info(ctx, "Error from API call, checking if resource was deleted outside Terraform.")
-		if data.SecurityFramework.ID == "" {
-			tflog.Debug(ctx, fmt.Sprintf("Response: (%T) %s", data, utils.PrettyPrint(data)))
-			tflog.Info(ctx, "Resource not found, marking as new.")
-			d.SetId("")
-			d.MarkNewResource()
-			return nil
-		}
 		return diags
 	}
 
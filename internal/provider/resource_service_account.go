@@ -108,6 +108,7 @@ func resourceWizServiceAccount() *schema.Resource {
 		ReadContext:   resourceWizServiceAccountRead,
 		UpdateContext: resourceWizServiceAccountUpdate,
 		DeleteContext: resourceWizServiceAccountDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -209,7 +210,10 @@ func resourceWizServiceAccountRead(ctx context.Context, d *schema.ResourceData,
 
 	// process the request
 	data := &ReadServiceAccountPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "service_account", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "service_account", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.ServiceAccount.ID) {
+		return removeDeletedResource(ctx, d, "wiz_service_account")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
 		return diags
@@ -61,6 +61,7 @@ func resourceWizUser() *schema.Resource {
 		ReadContext:   resourceWizUserRead,
 		UpdateContext: resourceWizUserUpdate,
 		DeleteContext: resourceWizUserDelete,
+		Timeouts:      defaultResourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -157,7 +158,10 @@ func resourceWizUserRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// process the request
 	data := &ReadUserPayload{}
-	requestDiags := client.ProcessRequest(ctx, m, vars, data, query, "user", "read")
+	requestDiags, gqlErrors := client.ProcessRequestWithErrors(ctx, m, vars, data, query, "user", "read")
+	if objectDeleted(ctx, requestDiags, gqlErrors, data.User.ID) {
+		return removeDeletedResource(ctx, d, "wiz_user")
+	}
 	diags = append(diags, requestDiags...)
 	if len(diags) > 0 {
 		return diags
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// operations checked against the scopes of the session token
+const (
+	scopeOperationRead   = "read"
+	scopeOperationCreate = "create"
+	scopeOperationUpdate = "update"
+	scopeOperationDelete = "delete"
+)
+
+// crudScopes func - return the scopes of each operation on an area of the api, e.g. read:controls and create:controls
+func crudScopes(area string) map[string]string {
+	return map[string]string{
+		scopeOperationRead:   "read:" + area,
+		scopeOperationCreate: "create:" + area,
+		scopeOperationUpdate: "update:" + area,
+		scopeOperationDelete: "delete:" + area,
+	}
+}
+
+// adminScopes func - return the scopes of an area read with a read scope and managed with an admin scope
+func adminScopes(read string, admin string) map[string]string {
+	return map[string]string{
+		scopeOperationRead:   read,
+		scopeOperationCreate: admin,
+		scopeOperationUpdate: admin,
+		scopeOperationDelete: admin,
+	}
+}
+
+// requiredScopes holds the scopes needed by each resource and data source, by operation
+var requiredScopes = map[string]map[string]string{
+	"wiz_automation_rule_aws_sns":                  crudScopes("automation_rules"),
+	"wiz_automation_rule_servicenow_create_ticket": crudScopes("automation_rules"),
+	"wiz_automation_rule_servicenow_update_ticket": crudScopes("automation_rules"),
+	"wiz_automation_rule_jira_transition_ticket":   crudScopes("automation_rules"),
+	"wiz_automation_rule_jira_add_comment":         crudScopes("automation_rules"),
+	"wiz_automation_rule_jira_create_ticket":       crudScopes("automation_rules"),
+	"wiz_cicd_scan_policy":                         crudScopes("scan_policies"),
+	"wiz_cloud_config_rule":                        crudScopes("cloud_configuration"),
+	"wiz_cloud_config_rule_associations":           crudScopes("cloud_configuration"),
+	"wiz_control":                                  crudScopes("controls"),
+	"wiz_control_associations":                     crudScopes("controls"),
+	"wiz_connector_aws":                            crudScopes("connectors"),
+	"wiz_connector_gcp":                            crudScopes("connectors"),
+	"wiz_host_config_rule_associations":            crudScopes("host_configuration"),
+	"wiz_integration_aws_sns":                      crudScopes("integrations"),
+	"wiz_integration_servicenow":                   crudScopes("integrations"),
+	"wiz_integration_jira":                         crudScopes("integrations"),
+	"wiz_report_graph_query":                       crudScopes("reports"),
+	"wiz_project":                                  adminScopes("read:projects", "admin:projects"),
+	"wiz_project_cloud_account_link":               adminScopes("read:projects", "admin:projects"),
+	"wiz_saml_idp":                                 adminScopes("admin:identity_providers", "admin:identity_providers"),
+	"wiz_saml_group_mapping":                       adminScopes("admin:identity_providers", "admin:identity_providers"),
+	"wiz_security_framework":                       crudScopes("security_frameworks"),
+	"wiz_service_account":                          crudScopes("service_accounts"),
+	"wiz_user":                                     adminScopes("read:users", "admin:users"),
+
+	"wiz_cloud_accounts":               {scopeOperationRead: "read:cloud_accounts"},
+	"wiz_cloud_config_rules":           {scopeOperationRead: "read:cloud_configuration"},
+	"wiz_host_config_rules":            {scopeOperationRead: "read:host_configuration"},
+	"wiz_kubernetes_clusters":          {scopeOperationRead: "read:kubernetes_clusters"},
+	"wiz_organizations":                {scopeOperationRead: "read:cloud_accounts"},
+	"wiz_subscription_resource_groups": {scopeOperationRead: "read:inventory"},
+	"wiz_users":                        {scopeOperationRead: "read:users"},
+}
+
+// missingScopes func - return the scopes needed for the operations on typeName that the session token lacks
+func missingScopes(meta interface{}, typeName string, operations ...string) []string {
+	conf, ok := meta.(*config.ProviderConf)
+	if !ok || conf == nil {
+		return nil
+	}
+	var required []string
+	for _, operation := range operations {
+		if scope, ok := requiredScopes[typeName][operation]; ok {
+			required = append(required, scope)
+		}
+	}
+	return config.MissingScopes(conf.Scopes, required)
+}
+
+// scopeWarning func - return a warning for the scopes missing to manage typeName, nil when none are missing
+func scopeWarning(typeName string, missing []string) []*tfprotov5.Diagnostic {
+	if len(missing) == 0 {
+		return nil
+	}
+	return []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  fmt.Sprintf("Session token lacks scopes needed by %s", typeName),
+			Detail:   fmt.Sprintf("The service account used by the provider was not granted %s, the api will reject the request.", strings.Join(missing, ", ")),
+		},
+	}
+}
+
+// isNull func - report whether a dynamic value is null, terraform encodes null states as a single msgpack nil
+func isNull(value *tfprotov5.DynamicValue) bool {
+	if value == nil {
+		return true
+	}
+	if len(value.MsgPack) > 0 {
+		return len(value.MsgPack) == 1 && value.MsgPack[0] == 0xc0
+	}
+	return len(value.JSON) == 0 || string(value.JSON) == "null"
+}
+
+// planOperations func - return the operations a planned change performs
+func planOperations(prior *tfprotov5.DynamicValue, planned *tfprotov5.DynamicValue) []string {
+	switch {
+	case isNull(planned):
+		return []string{scopeOperationDelete}
+	case isNull(prior):
+		return []string{scopeOperationRead, scopeOperationCreate}
+	case bytes.Equal(prior.MsgPack, planned.MsgPack) && bytes.Equal(prior.JSON, planned.JSON):
+		return []string{scopeOperationRead}
+	default:
+		return []string{scopeOperationRead, scopeOperationUpdate}
+	}
+}
+
+// scopeCheckingServer warns at plan time about resources and data sources needing scopes the session token lacks
+type scopeCheckingServer struct {
+	*schema.GRPCProviderServer
+	provider *schema.Provider
+}
+
+// NewGRPCProviderServer returns the protocol server of the provider, warning about missing scopes during plan
+func NewGRPCProviderServer(p *schema.Provider) tfprotov5.ProviderServer {
+	return &scopeCheckingServer{
+		GRPCProviderServer: schema.NewGRPCProviderServer(p),
+		provider:           p,
+	}
+}
+
+// PlanResourceChange implements tfprotov5.ProviderServer
+func (s *scopeCheckingServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.GRPCProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	missing := missingScopes(s.provider.Meta(), req.TypeName, planOperations(req.PriorState, resp.PlannedState)...)
+	resp.Diagnostics = append(resp.Diagnostics, scopeWarning(req.TypeName, missing)...)
+	return resp, nil
+}
+
+// ReadDataSource implements tfprotov5.ProviderServer
+func (s *scopeCheckingServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	resp, err := s.GRPCProviderServer.ReadDataSource(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	missing := missingScopes(s.provider.Meta(), req.TypeName, scopeOperationRead)
+	resp.Diagnostics = append(resp.Diagnostics, scopeWarning(req.TypeName, missing)...)
+	return resp, nil
+}
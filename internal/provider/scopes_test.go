@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal"
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	p := New("test")()
+
+	// every resource and data source declares known scopes
+	var typeNames []string
+	for typeName := range p.ResourcesMap {
+		typeNames = append(typeNames, typeName)
+	}
+	for typeName := range p.DataSourcesMap {
+		typeNames = append(typeNames, typeName)
+	}
+	for _, typeName := range typeNames {
+		scopes, ok := requiredScopes[typeName]
+		if !assert.True(t, ok, typeName) {
+			continue
+		}
+		assert.Contains(t, scopes, scopeOperationRead, typeName)
+		for _, scope := range scopes {
+			assert.True(t, slices.Contains(internal.ServiceAccountScopes, scope), "%s requires unknown scope %s", typeName, scope)
+		}
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	conf := &config.ProviderConf{Scopes: []string{"read:automation_rules", "read:controls", "write:controls"}}
+
+	assert.Equal(t, []string{"create:automation_rules"}, missingScopes(conf, "wiz_automation_rule_jira_add_comment", scopeOperationRead, scopeOperationCreate))
+	assert.Empty(t, missingScopes(conf, "wiz_control", scopeOperationRead, scopeOperationUpdate))
+	assert.Equal(t, []string{"read:users"}, missingScopes(conf, "wiz_users", scopeOperationRead))
+
+	// nothing is reported when the scopes are not known
+	assert.Empty(t, missingScopes(&config.ProviderConf{}, "wiz_users", scopeOperationRead))
+	assert.Empty(t, missingScopes(nil, "wiz_users", scopeOperationRead))
+}
+
+// testDynamicValue returns the msgpack encoding of a state with a single name attribute, a null state when name is nil
+func testDynamicValue(t *testing.T, name interface{}) *tfprotov5.DynamicValue {
+	stateType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+	state := tftypes.NewValue(stateType, nil)
+	if name != nil {
+		state = tftypes.NewValue(stateType, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, name)})
+	}
+	value, err := tfprotov5.NewDynamicValue(stateType, state)
+	assert.NoError(t, err)
+	return &value
+}
+
+func TestPlanOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    interface{}
+		planned  interface{}
+		expected []string
+	}{
+		{name: "create", planned: "one", expected: []string{scopeOperationRead, scopeOperationCreate}},
+		{name: "update", prior: "one", planned: "two", expected: []string{scopeOperationRead, scopeOperationUpdate}},
+		{name: "no changes", prior: "one", planned: "one", expected: []string{scopeOperationRead}},
+		{name: "delete", prior: "one", expected: []string{scopeOperationDelete}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, planOperations(testDynamicValue(t, test.prior), testDynamicValue(t, test.planned)))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources that reshape their attributes bump their SchemaVersion and register a StateUpgrader for the prior version.
+// The upgraders run in order on the raw state terraform stored, before the state is decoded with the current schema,
+// so each upgrader receives the state of its Version and returns the state of the next one.
+// Resources migrated to the plugin framework bump their schema version as well, version 0 being the state of the sdk resource.
+
+// schemaWithJSONAttributes func - return a copy of a schema where the given attributes are the JSON strings they were before they became blocks
+// it describes the prior version of a resource for its StateUpgrader.
+func schemaWithJSONAttributes(s map[string]*schema.Schema, keys ...string) map[string]*schema.Schema {
+	prior := make(map[string]*schema.Schema, len(s))
+	for key, attribute := range s {
+		prior[key] = attribute
+	}
+	for _, key := range keys {
+		prior[key] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+	return prior
+}
+
+// upgradeJSONAttribute func - replace a JSON string attribute of the raw state with the block holding the same settings
+// the string is decoded into T and flattened into the block; settings the block does not support are dropped with a warning,
+// as the state carries no more than the configuration they were written in.
+func upgradeJSONAttribute[T any](ctx context.Context, rawState map[string]interface{}, key string, flatten func(T) []interface{}) error {
+	raw, _ := rawState[key].(string)
+	if raw == "" {
+		rawState[key] = []interface{}{}
+		return nil
+	}
+
+	var v T
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&v); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("%s: %s, the settings the %s block does not support are dropped from the state", key, err, key))
+
+		v = *new(T)
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return fmt.Errorf("unable to upgrade %s, it is not a valid JSON object: %w", key, err)
+		}
+	}
+
+	rawState[key] = flatten(v)
+	return nil
+}
+
+// upgradeSDKState func - return the StateUpgrader of the state a resource stored before it moved to the plugin framework
+// the sdk stored unset optional strings and lists as empty values, which the framework tells apart from null ones,
+// so the empty values of the given attributes become null; a key "block.attribute" applies to the attribute of every block.
+func upgradeSDKState(keys ...string) func(context.Context, resource.UpgradeStateRequest, *resource.UpgradeStateResponse) {
+	return func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+		var rawState map[string]interface{}
+		if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+			resp.Diagnostics.AddError("Unable to upgrade the state", err.Error())
+			return
+		}
+		for _, key := range keys {
+			nullEmptyAttribute(rawState, strings.Split(key, "."))
+		}
+
+		state, err := json.Marshal(rawState)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to upgrade the state", err.Error())
+			return
+		}
+		resp.DynamicValue = &tfprotov6.DynamicValue{JSON: state}
+	}
+}
+
+// nullEmptyAttribute func - set the attribute at path to null when it holds an empty string or list
+func nullEmptyAttribute(rawState map[string]interface{}, path []string) {
+	value, ok := rawState[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) > 1 {
+		blocks, _ := value.([]interface{})
+		for _, block := range blocks {
+			if attributes, ok := block.(map[string]interface{}); ok {
+				nullEmptyAttribute(attributes, path[1:])
+			}
+		}
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			rawState[path[0]] = nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			rawState[path[0]] = nil
+		}
+	}
+}
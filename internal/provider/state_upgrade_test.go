@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sdkProjectState is the state of a wiz_project written by the sdk resource, schema version 0
+const sdkProjectState = `{
+	"id": "848025a0-9c2d-5863-8c4d-b60799294fff",
+	"name": "my project",
+	"description": "",
+	"identifiers": [],
+	"archived": false,
+	"is_folder": false,
+	"business_unit": "",
+	"parent_project_id": "",
+	"project_owners": [],
+	"security_champions": ["01882691-fb1b-5e72-b4cf-dc207a875907"],
+	"slug": "my-project",
+	"risk_profile": [{
+		"business_impact": "MBI",
+		"is_actively_developed": "UNKNOWN",
+		"has_authentication": "UNKNOWN",
+		"has_exposed_api": "UNKNOWN",
+		"is_internet_facing": "UNKNOWN",
+		"is_customer_facing": "UNKNOWN",
+		"stores_data": "UNKNOWN",
+		"is_regulated": "UNKNOWN",
+		"sensitive_data_types": [],
+		"regulatory_standards": ["ISO_27001"]
+	}],
+	"kubernetes_cluster_link": [{
+		"kubernetes_cluster": "77de7ca1-02f9-5ed2-a94b-5d19c683efa2",
+		"environment": "STAGING",
+		"shared": true,
+		"namespaces": []
+	}],
+	"cloud_account_link": [
+		{
+			"cloud_account_id": "3225def3-0e0e-5cb8-955a-3583f696f77f",
+			"environment": "PRODUCTION",
+			"shared": true,
+			"resource_groups": [],
+			"resource_tags": []
+		},
+		{
+			"cloud_account_id": "d8181cf9-38bb-486c-8278-f95f416afb3c",
+			"environment": "PRODUCTION",
+			"shared": false,
+			"resource_groups": ["rg1"],
+			"resource_tags": [{"key": "k1", "value": "v1"}]
+		}
+	],
+	"cloud_organization_link": [{
+		"cloud_organization": "f2b48c0b-57c6-4e1c-9bea-09c92c2fe0ed",
+		"environment": "PRODUCTION",
+		"shared": true,
+		"resource_groups": [],
+		"resource_tags": []
+	}],
+	"timeouts": null
+}`
+
+// sdkCICDScanPolicyState is the state of an IaC wiz_cicd_scan_policy written by the sdk resource, schema version 0
+const sdkCICDScanPolicyState = `{
+	"id": "c9a2ea6c-8b9e-4d79-9c4c-d7c1f1c2b4a3",
+	"name": "iac",
+	"description": "",
+	"builtin": false,
+	"type": "CICDScanPolicyParamsIAC",
+	"disk_vulnerabilities_params": [],
+	"disk_secrets_params": [],
+	"iac_params": [{
+		"severity_threshold": "CRITICAL",
+		"count_threshold": 3,
+		"builtin_ignore_tags_enabled": false,
+		"ignored_rules": [],
+		"security_frameworks": ["fd7dd0c6-4953-4b36-bc39-004ec3d870db"],
+		"custom_ignore_tags": [
+			{
+				"key": "testkey1",
+				"value": "testval1",
+				"ignore_all_rules": false,
+				"rule_ids": ["063fb380-9eda-4c08-a31b-9211ee37bd42"]
+			},
+			{
+				"key": "testkey2",
+				"value": "testval2",
+				"ignore_all_rules": true,
+				"rule_ids": []
+			}
+		]
+	}],
+	"timeouts": null
+}`
+
+// upgradeFrameworkState runs the upgrader of version 0 of a framework resource on a raw state and returns the upgraded state
+func upgradeFrameworkState(t *testing.T, r fwresource.ResourceWithUpgradeState, rawState string) map[string]interface{} {
+	t.Helper()
+
+	ctx := context.Background()
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok)
+
+	resp := &fwresource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(rawState)}}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	require.NotNil(t, resp.DynamicValue)
+
+	var upgraded map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.DynamicValue.JSON, &upgraded))
+	return upgraded
+}
+
+// upgradeState runs the upgraders of a resource on a raw state of the given version, like terraform does on refresh
+// it checks that the upgraded state is valid for the current schema and returns it.
+func upgradeState(t *testing.T, resource *schema.Resource, version int, rawState map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	for _, upgrader := range resource.StateUpgraders {
+		if upgrader.Version < version {
+			continue
+		}
+		var err error
+		rawState, err = upgrader.Upgrade(context.Background(), rawState, nil)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+	}
+	assertStateConforms(t, resource, rawState)
+	return rawState
+}
+
+// assertStateConforms checks that a raw state decodes with the schema of a resource, without unknown attributes or mismatched types
+func assertStateConforms(t *testing.T, resource *schema.Resource, rawState map[string]interface{}) {
+	t.Helper()
+
+	state, err := json.Marshal(rawState)
+	if assert.NoError(t, err) {
+		_, err = ctyjson.Unmarshal(state, resource.CoreConfigSchema().ImpliedType())
+		assert.NoError(t, err)
+	}
+}
+
+func TestResourceStateUpgraders(t *testing.T) {
+	for name, resource := range New("test")().ResourcesMap {
+		t.Run(name, func(t *testing.T) {
+			// one upgrader for each prior version, in order
+			if !assert.Len(t, resource.StateUpgraders, resource.SchemaVersion) {
+				return
+			}
+			for i, upgrader := range resource.StateUpgraders {
+				assert.Equal(t, i, upgrader.Version)
+				assert.NotNil(t, upgrader.Upgrade)
+				assert.NotEqual(t, cty.NilType, upgrader.Type)
+			}
+		})
+	}
+}
+
+func TestSchemaWithJSONAttributes(t *testing.T) {
+	current := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"config": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	prior := schemaWithJSONAttributes(current, "config")
+	assert.Equal(t, current["name"], prior["name"])
+	assert.Equal(t, schema.TypeString, prior["config"].Type)
+	// the current schema is left untouched
+	assert.Equal(t, schema.TypeList, current["config"].Type)
+}
+
+func TestUpgradeJSONAttribute(t *testing.T) {
+	type config struct {
+		Enabled bool `json:"enabled"`
+	}
+	flatten := func(c config) []interface{} {
+		return []interface{}{map[string]interface{}{"enabled": c.Enabled}}
+	}
+
+	tests := []struct {
+		name     string
+		raw      interface{}
+		expected interface{}
+		err      bool
+	}{
+		{
+			name:     "settings",
+			raw:      `{"enabled": true}`,
+			expected: []interface{}{map[string]interface{}{"enabled": true}},
+		},
+		{
+			name:     "unset",
+			raw:      nil,
+			expected: []interface{}{},
+		},
+		{
+			name:     "empty",
+			raw:      "",
+			expected: []interface{}{},
+		},
+		{
+			name:     "unsupported settings are dropped",
+			raw:      `{"enabled": true, "unknown": "value"}`,
+			expected: []interface{}{map[string]interface{}{"enabled": true}},
+		},
+		{
+			name: "invalid",
+			raw:  `{"enabled": `,
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rawState := map[string]interface{}{"config": test.raw}
+			err := upgradeJSONAttribute(context.Background(), rawState, "config", flatten)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, rawState["config"])
+			}
+		})
+	}
+}
+
+func TestUpgradeSDKStateProject(t *testing.T) {
+	upgraded := upgradeFrameworkState(t, NewProjectResource().(*projectResource), sdkProjectState)
+
+	// the empty values of optional attributes are null
+	for _, key := range []string{"description", "business_unit", "parent_project_id", "identifiers", "project_owners"} {
+		assert.Nil(t, upgraded[key], key)
+	}
+	assert.Equal(t, []interface{}{"01882691-fb1b-5e72-b4cf-dc207a875907"}, upgraded["security_champions"])
+	assert.Equal(t, "my project", upgraded["name"])
+	assert.Equal(t, false, upgraded["archived"])
+
+	// and so are those of the attributes of blocks, in every block
+	riskProfile := upgraded["risk_profile"].([]interface{})[0].(map[string]interface{})
+	assert.Nil(t, riskProfile["sensitive_data_types"])
+	assert.Equal(t, []interface{}{"ISO_27001"}, riskProfile["regulatory_standards"])
+	assert.Equal(t, "MBI", riskProfile["business_impact"])
+
+	clusterLink := upgraded["kubernetes_cluster_link"].([]interface{})[0].(map[string]interface{})
+	assert.Nil(t, clusterLink["namespaces"])
+
+	accountLinks := upgraded["cloud_account_link"].([]interface{})
+	assert.Nil(t, accountLinks[0].(map[string]interface{})["resource_groups"])
+	assert.Equal(t, []interface{}{"rg1"}, accountLinks[1].(map[string]interface{})["resource_groups"])
+	// nested blocks are not attributes, an empty list of blocks stays empty
+	assert.Equal(t, []interface{}{}, accountLinks[0].(map[string]interface{})["resource_tags"])
+
+	organizationLink := upgraded["cloud_organization_link"].([]interface{})[0].(map[string]interface{})
+	assert.Nil(t, organizationLink["resource_groups"])
+}
+
+func TestUpgradeSDKStateCICDScanPolicy(t *testing.T) {
+	upgraded := upgradeFrameworkState(t, NewCICDScanPolicyResource().(*cicdScanPolicyResource), sdkCICDScanPolicyState)
+
+	assert.Nil(t, upgraded["description"])
+	assert.Equal(t, []interface{}{}, upgraded["disk_secrets_params"])
+
+	iacParams := upgraded["iac_params"].([]interface{})[0].(map[string]interface{})
+	assert.Nil(t, iacParams["ignored_rules"])
+	assert.Equal(t, []interface{}{"fd7dd0c6-4953-4b36-bc39-004ec3d870db"}, iacParams["security_frameworks"])
+
+	// "iac_params.custom_ignore_tags.rule_ids" goes through two levels of blocks
+	tags := iacParams["custom_ignore_tags"].([]interface{})
+	assert.Equal(t, []interface{}{"063fb380-9eda-4c08-a31b-9211ee37bd42"}, tags[0].(map[string]interface{})["rule_ids"])
+	assert.Nil(t, tags[1].(map[string]interface{})["rule_ids"])
+	assert.Equal(t, true, tags[1].(map[string]interface{})["ignore_all_rules"])
+}
+
+func TestNullEmptyAttribute(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name":        "",
+		"enabled":     false,
+		"count":       float64(0),
+		"description": "kept",
+		"blocks": []interface{}{
+			map[string]interface{}{"values": []interface{}{}},
+			map[string]interface{}{"values": []interface{}{"kept"}},
+		},
+	}
+
+	nullEmptyAttribute(rawState, []string{"name"})
+	nullEmptyAttribute(rawState, []string{"enabled"})
+	nullEmptyAttribute(rawState, []string{"count"})
+	nullEmptyAttribute(rawState, []string{"description"})
+	nullEmptyAttribute(rawState, []string{"blocks", "values"})
+	// attributes missing from the state are left alone
+	nullEmptyAttribute(rawState, []string{"missing"})
+	nullEmptyAttribute(rawState, []string{"missing", "values"})
+
+	assert.Equal(t, map[string]interface{}{
+		"name":        nil,
+		"enabled":     false,
+		"count":       float64(0),
+		"description": "kept",
+		"blocks": []interface{}{
+			map[string]interface{}{"values": nil},
+			map[string]interface{}{"values": []interface{}{"kept"}},
+		},
+	}, rawState)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// default deadlines of the resource operations, the sdk cancels the context passed to the client once they pass
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+
+	// connectors and projects trigger work in Wiz that takes longer to complete, such as the first scan of a cloud account
+	longCreateTimeout = 30 * time.Minute
+	longUpdateTimeout = 30 * time.Minute
+)
+
+// defaultResourceTimeouts func - return the timeouts of a resource, configurable through its timeouts block
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultCreateTimeout),
+		Read:   schema.DefaultTimeout(defaultReadTimeout),
+		Update: schema.DefaultTimeout(defaultUpdateTimeout),
+		Delete: schema.DefaultTimeout(defaultDeleteTimeout),
+	}
+}
+
+// longResourceTimeouts func - return the timeouts of a resource whose create and update take longer than usual
+func longResourceTimeouts() *schema.ResourceTimeout {
+	timeouts := defaultResourceTimeouts()
+	timeouts.Create = schema.DefaultTimeout(longCreateTimeout)
+	timeouts.Update = schema.DefaultTimeout(longUpdateTimeout)
+	return timeouts
+}
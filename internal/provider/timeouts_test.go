@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
+)
+
+// blockingTransport holds every request until its context is done, like an api call that hangs
+type blockingTransport struct{}
+
+func (blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+// newHangingProviderConf returns a provider configuration whose api calls never complete
+func newHangingProviderConf() *config.ProviderConf {
+	return &config.ProviderConf{
+		HTTPClient: &http.Client{Transport: blockingTransport{}},
+		Settings: &config.Settings{
+			WizURL: "http://example.com",
+		},
+		UserAgent: "Test User Agent",
+		TokenType: "Bearer",
+		Token:     "testtoken",
+	}
+}
+
+// decodeTimeouts returns the timeouts of a resource configured with a timeouts block
+func decodeTimeouts(t *testing.T, resource *schema.Resource, timeouts map[string]interface{}) *schema.ResourceTimeout {
+	rt := &schema.ResourceTimeout{}
+	err := rt.ConfigDecode(resource, terraform.NewResourceConfigRaw(map[string]interface{}{schema.TimeoutsConfigKey: timeouts}))
+	assert.NoError(t, err)
+	return rt
+}
+
+// timeoutState returns the state of a resource carrying its timeouts, the way terraform stores them
+func timeoutState(t *testing.T, resource *schema.Resource, rt *schema.ResourceTimeout) *terraform.InstanceState {
+	state := &terraform.InstanceState{
+		// an id valid for every resource, including those with composite ids
+		ID:         "link|00000000-0000-0000-0000-000000000000|00000000-0000-0000-0000-000000000000",
+		Attributes: map[string]string{},
+	}
+	// lists of ids, such as the control_ids of an association, are read one by one
+	for key, s := range resource.Schema {
+		if s.Type == schema.TypeList && strings.HasSuffix(key, "_ids") {
+			state.Attributes[key+".#"] = "1"
+			state.Attributes[key+".0"] = "00000000-0000-0000-0000-000000000000"
+		}
+	}
+	assert.NoError(t, rt.StateEncode(state))
+	return state
+}
+
+// assertDeadlineExceeded checks that an operation stopped with a deadline error shortly after its timeout
+func assertDeadlineExceeded(t *testing.T, diags diag.Diagnostics, elapsed time.Duration) {
+	assert.Less(t, elapsed, 5*time.Second)
+	if assert.True(t, diags.HasError()) {
+		var summaries []string
+		for _, d := range diags {
+			summaries = append(summaries, d.Summary)
+		}
+		assert.Contains(t, summaries, "Operation cancelled")
+	}
+}
+
+func TestResourceTimeouts(t *testing.T) {
+	long := map[string]bool{
+		"wiz_connector_aws": true,
+		"wiz_connector_gcp": true,
+		"wiz_project":       true,
+	}
+
+	for name, resource := range New("test")().ResourcesMap {
+		t.Run(name, func(t *testing.T) {
+			if !assert.NotNil(t, resource.Timeouts) {
+				return
+			}
+
+			// defaults
+			rt := decodeTimeouts(t, resource, map[string]interface{}{})
+			create, update := defaultCreateTimeout, defaultUpdateTimeout
+			if long[name] {
+				create, update = longCreateTimeout, longUpdateTimeout
+			}
+			assert.Equal(t, create, *rt.Create)
+			assert.Equal(t, defaultReadTimeout, *rt.Read)
+			assert.Equal(t, update, *rt.Update)
+			assert.Equal(t, defaultDeleteTimeout, *rt.Delete)
+
+			// the timeouts block overrides them
+			rt = decodeTimeouts(t, resource, map[string]interface{}{
+				"create": "1m",
+				"read":   "50ms",
+				"update": "2m",
+				"delete": "50ms",
+			})
+			assert.Equal(t, time.Minute, *rt.Create)
+			assert.Equal(t, 50*time.Millisecond, *rt.Read)
+			assert.Equal(t, 2*time.Minute, *rt.Update)
+			assert.Equal(t, 50*time.Millisecond, *rt.Delete)
+			state := timeoutState(t, resource, rt)
+
+			// a hanging api call is abandoned once the read deadline passes
+			start := time.Now()
+			_, diags := resource.RefreshWithoutUpgrade(context.Background(), state, newHangingProviderConf())
+			assertDeadlineExceeded(t, diags, time.Since(start))
+
+			// and once the delete deadline passes
+			start = time.Now()
+			_, diags = resource.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, newHangingProviderConf())
+			assertDeadlineExceeded(t, diags, time.Since(start))
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"unicode"
 )
 
 // PrettyPrint prints a struct in formatted json
@@ -99,3 +101,18 @@ func RemoveNullAndEmptyValues(m map[string]interface{}, depth int) {
 		}
 	}
 }
+
+// CamelToSnake converts a camelCase api field name to the snake_case form used for schema attributes
+func CamelToSnake(s string) string {
+	var output strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				output.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		output.WriteRune(r)
+	}
+	return output.String()
+}
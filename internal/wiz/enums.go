@@ -712,3 +712,38 @@ var GcpPubSubIntegrationAccessMethodType = []string{
 	"CONNECTOR_CREDENTIALS",
 	"SERVICE_ACCOUNT_KEY",
 }
+
+// GraphQLErrorCodeNotFound enum of extensions.code values reported when the requested object does not exist
+var GraphQLErrorCodeNotFound = []string{
+	"NOT_FOUND",
+}
+
+// GraphQLErrorCodeUnauthorized enum of extensions.code values reported when the session token is missing, invalid or lacks permissions
+var GraphQLErrorCodeUnauthorized = []string{
+	"UNAUTHENTICATED",
+	"UNAUTHORIZED",
+	"FORBIDDEN",
+}
+
+// GraphQLErrorCodeRateLimited enum of extensions.code values reported when the api throttles requests
+var GraphQLErrorCodeRateLimited = []string{
+	"RATE_LIMIT_EXCEEDED",
+	"TOO_MANY_REQUESTS",
+	"THROTTLED",
+}
+
+// GraphQLErrorCodeConflict enum of extensions.code values reported when the object already exists or was modified concurrently
+var GraphQLErrorCodeConflict = []string{
+	"CONFLICT",
+	"ALREADY_EXISTS",
+}
+
+// GraphQLErrorCodePersistedQueryNotFound enum of extensions.code values reported when a persisted query hash is not known to the api
+var GraphQLErrorCodePersistedQueryNotFound = []string{
+	"PERSISTED_QUERY_NOT_FOUND",
+}
+
+// GraphQLErrorCodePersistedQueryNotSupported enum of extensions.code values reported when the api does not support persisted queries
+var GraphQLErrorCodePersistedQueryNotSupported = []string{
+	"PERSISTED_QUERY_NOT_SUPPORTED",
+}
@@ -658,6 +658,34 @@ type ConnectorConfigGCP struct {
 	Type                    string                      `json:"type"`
 }
 
+// ConnectorAuthParamsGCP struct -- the authParams of a gcp connector, either a managed identity or the fields of a service account key
+type ConnectorAuthParamsGCP struct {
+	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url,omitempty"`
+	AuthURI                 string `json:"auth_uri,omitempty"`
+	ClientEmail             string `json:"client_email,omitempty"`
+	ClientID                string `json:"client_id,omitempty"`
+	ClientX509CertURL       string `json:"client_x509_cert_url,omitempty"`
+	DelegateUser            string `json:"delegateUser,omitempty"`
+	FolderID                string `json:"folder_id,omitempty"`
+	IsManagedIdentity       bool   `json:"isManagedIdentity,omitempty"`
+	OrganizationID          string `json:"organization_id,omitempty"`
+	PrivateKey              string `json:"private_key,omitempty"`
+	PrivateKeyID            string `json:"private_key_id,omitempty"`
+	ProjectID               string `json:"project_id,omitempty"`
+	TokenURI                string `json:"token_uri,omitempty"`
+	Type                    string `json:"type,omitempty"`
+}
+
+// ConnectorExtraConfigGCP struct -- the extraConfig of a gcp connector
+type ConnectorExtraConfigGCP struct {
+	AuditLogMonitorEnabled bool                         `json:"auditLogMonitorEnabled"`
+	AuditLogsConfig        *ConnectorConfigGCPAuditLogs `json:"auditLogsConfig,omitempty"`
+	ExcludedFolders        []string                     `json:"excludedFolders,omitempty"`
+	ExcludedProjects       []string                     `json:"excludedProjects,omitempty"`
+	IncludedFolders        []string                     `json:"includedFolders,omitempty"`
+	Projects               []string                     `json:"projects,omitempty"`
+}
+
 // ConnectorConfigGCPAuditLogs struct -- updates
 type ConnectorConfigGCPAuditLogs struct {
 	PubSub ConnectorConfigGCPPubSub `json:"pub_sub"`
@@ -685,6 +713,24 @@ type ConnectorConfigAWS struct {
 	CloudTrailConfig       ConnectorConfigAWSCloudTrail  `json:"cloudTrailConfig,omitempty"`
 }
 
+// ConnectorAuthParamsAWS struct -- the authParams of an aws connector
+type ConnectorAuthParamsAWS struct {
+	CustomerRoleARN string                         `json:"customerRoleARN"`
+	DiskAnalyzer    *ConnectorAuthConfigAWSOutpost `json:"diskAnalyzer,omitempty"`
+	OutpostID       string                         `json:"outpostId,omitempty"`
+}
+
+// ConnectorExtraConfigAWS struct -- the extraConfig of an aws connector
+type ConnectorExtraConfigAWS struct {
+	AuditLogMonitorEnabled bool                          `json:"auditLogMonitorEnabled"`
+	CloudTrailConfig       *ConnectorConfigAWSCloudTrail `json:"cloudTrailConfig,omitempty"`
+	ExcludedAccounts       []string                      `json:"excludedAccounts,omitempty"`
+	ExcludedOUs            []string                      `json:"excludedOUs,omitempty"`
+	IncludedAccounts       []string                      `json:"includedAccounts,omitempty"`
+	OptedInRegions         []string                      `json:"optedInRegions,omitempty"`
+	SkipOrganizationScan   bool                          `json:"skipOrganizationScan"`
+}
+
 // ConnectorAuthConfigAWSOutpost struct -- updates
 type ConnectorAuthConfigAWSOutpost struct {
 	Scanner ConnectorAuthConfigAWSOutpostScanner `json:"scanner"`
@@ -692,15 +738,15 @@ type ConnectorAuthConfigAWSOutpost struct {
 
 // ConnectorAuthConfigAWSOutpostScanner struct -- updates
 type ConnectorAuthConfigAWSOutpostScanner struct {
-	ExternalID string `json:"externalId"`
+	ExternalID string `json:"externalId,omitempty"`
 	RoleARN    string `json:"roleARN"`
 }
 
 // ConnectorConfigAWSCloudTrail struct -- updates
 type ConnectorConfigAWSCloudTrail struct {
 	BucketName       string `json:"bucketName"`
-	BucketSubAccount string `json:"bucketSubAccount"`
-	TrailOrg         string `json:"trailOrg"`
+	BucketSubAccount string `json:"bucketSubAccount,omitempty"`
+	TrailOrg         string `json:"trailOrg,omitempty"`
 }
 
 // AutomationRule struct -- updates
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
+	"os"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 
+	"wiz.io/hashicorp/terraform-provider-wiz/internal/config"
 	"wiz.io/hashicorp/terraform-provider-wiz/internal/provider"
 )
 
@@ -26,14 +30,39 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug: debugMode,
+	// exit once run has returned, so the deferred telemetry shutdown exports the buffered spans
+	if err := run(debugMode); err != nil {
+		log.Printf("[ERROR] %s", err)
+		os.Exit(1)
+	}
+}
 
-		// TODO: update this string with the full name of your provider as used in your configs
-		ProviderAddr: "wiz.io/hashicorp/wiz",
+// run serves the provider until terraform stops it
+func run(debugMode bool) error {
+	// export OpenTelemetry spans when an OTLP endpoint is set in the environment
+	ctx := context.Background()
+	shutdownTelemetry, err := config.InitTelemetry(ctx, version)
+	if err != nil {
+		log.Printf("[WARN] OpenTelemetry tracing disabled: %s", err)
+	} else {
+		defer func() {
+			if err := shutdownTelemetry(ctx); err != nil {
+				log.Printf("[WARN] failed to export OpenTelemetry spans: %s", err)
+			}
+		}()
+	}
+
+	// serve the sdk provider muxed with the plugin framework provider, resources are migrated to the framework one at a time
+	serverFactory, err := provider.NewProtocol6ProviderServer(ctx, version)
+	if err != nil {
+		return err
+	}
 
-		ProviderFunc: provider.New(version),
+	var serveOpts []tf6server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	// TODO: update this string with the full name of your provider as used in your configs
+	return tf6server.Serve("wiz.io/hashicorp/wiz", serverFactory, serveOpts...)
 }